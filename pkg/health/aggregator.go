@@ -0,0 +1,68 @@
+package health
+
+// QuorumAggregator creates an aggregation function (see WithAggregator) that considers the system available as
+// long as at least quorum checks are up. If fewer checks are up, it returns StatusDegraded if the degraded checks
+// complete the quorum, StatusUnknown if the quorum may still be reached by the unknown checks, and StatusDown
+// otherwise. A quorum that is not positive is always reached.
+func QuorumAggregator(quorum int) func(states map[string]CheckState) AvailabilityStatus {
+	return func(states map[string]CheckState) AvailabilityStatus {
+		var up, degraded, unknown float64
+		for _, state := range states {
+			switch state.Status {
+			case StatusUp:
+				up++
+			case StatusDegraded:
+				degraded++
+			case StatusUnknown:
+				unknown++
+			}
+		}
+
+		return quorumStatus(up, degraded, unknown, float64(quorum))
+	}
+}
+
+// WeightedAggregator creates an aggregation function (see WithAggregator) that weights the checks by their
+// names. Checks without a weight have the weight 1. The system is considered available as long as the checks
+// that are up make up at least the given share (between 0 and 1) of the total weight. Otherwise, it returns
+// StatusDegraded if the degraded checks complete the share, StatusUnknown if the share may still be reached by
+// the unknown checks, and StatusDown otherwise. It returns StatusUp, if there are no checks.
+func WeightedAggregator(weights map[string]float64, share float64) func(states map[string]CheckState) AvailabilityStatus {
+	return func(states map[string]CheckState) AvailabilityStatus {
+		var total, up, degraded, unknown float64
+		for name, state := range states {
+			weight, ok := weights[name]
+			if !ok {
+				weight = 1
+			}
+
+			total += weight
+
+			switch state.Status {
+			case StatusUp:
+				up += weight
+			case StatusDegraded:
+				degraded += weight
+			case StatusUnknown:
+				unknown += weight
+			}
+		}
+
+		return quorumStatus(up, degraded, unknown, share*total)
+	}
+}
+
+// quorumStatus returns the status for the given numbers (or weights) of checks by status relative to the required
+// number for the system to be available (see QuorumAggregator).
+func quorumStatus(up, degraded, unknown, required float64) AvailabilityStatus {
+	switch {
+	case up >= required:
+		return StatusUp
+	case up+degraded >= required:
+		return StatusDegraded
+	case up+degraded+unknown >= required:
+		return StatusUnknown
+	default:
+		return StatusDown
+	}
+}
@@ -0,0 +1,135 @@
+package health_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func statesOf(statuses ...health.AvailabilityStatus) map[string]health.CheckState {
+	states := make(map[string]health.CheckState, len(statuses))
+	for i, status := range statuses {
+		states[string(rune('a'+i))] = health.CheckState{Status: status}
+	}
+
+	return states
+}
+
+func TestQuorumAggregator(t *testing.T) {
+	tests := []struct {
+		name     string
+		states   map[string]health.CheckState
+		expected health.AvailabilityStatus
+	}{
+		{
+			name:     "QuorumIsUp",
+			states:   statesOf(health.StatusUp, health.StatusUp, health.StatusDown),
+			expected: health.StatusUp,
+		},
+		{
+			name:     "DegradedChecksCompleteQuorum",
+			states:   statesOf(health.StatusUp, health.StatusDegraded, health.StatusDown),
+			expected: health.StatusDegraded,
+		},
+		{
+			name:     "UnknownChecksMayCompleteQuorum",
+			states:   statesOf(health.StatusUp, health.StatusUnknown, health.StatusDown),
+			expected: health.StatusUnknown,
+		},
+		{
+			name:     "QuorumIsNotReached",
+			states:   statesOf(health.StatusUp, health.StatusDown, health.StatusDown),
+			expected: health.StatusDown,
+		},
+		{
+			name:     "NoChecks",
+			states:   statesOf(),
+			expected: health.StatusDown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			status := health.QuorumAggregator(2)(tc.states)
+
+			// Assert
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func TestWeightedAggregator(t *testing.T) {
+	// a is the primary database, b and c are replicas (with the default weight).
+	weights := map[string]float64{"a": 2}
+
+	tests := []struct {
+		name     string
+		states   map[string]health.CheckState
+		expected health.AvailabilityStatus
+	}{
+		{
+			name:     "PrimaryIsUp",
+			states:   statesOf(health.StatusUp, health.StatusDown, health.StatusDown),
+			expected: health.StatusUp,
+		},
+		{
+			name:     "ReplicasAreUp",
+			states:   statesOf(health.StatusDown, health.StatusUp, health.StatusUp),
+			expected: health.StatusUp,
+		},
+		{
+			name:     "PrimaryIsDegraded",
+			states:   statesOf(health.StatusDegraded, health.StatusUp, health.StatusDown),
+			expected: health.StatusDegraded,
+		},
+		{
+			name:     "PrimaryIsUnknown",
+			states:   statesOf(health.StatusUnknown, health.StatusDown, health.StatusDown),
+			expected: health.StatusUnknown,
+		},
+		{
+			name:     "OnlyOneReplicaIsUp",
+			states:   statesOf(health.StatusDown, health.StatusUp, health.StatusDown),
+			expected: health.StatusDown,
+		},
+		{
+			name:     "NoChecks",
+			states:   statesOf(),
+			expected: health.StatusUp,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			status := health.WeightedAggregator(weights, 0.5)(tc.states)
+
+			// Assert
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func TestAggregatorReceivesCopyOfStates(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithAggregator(func(states map[string]health.CheckState) health.AvailabilityStatus {
+			clear(states)
+			return health.StatusUp
+		}),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Contains(t, res.Details, "db")
+	_, ok := ckr.(health.StateReader).LastCheckState("db")
+	assert.True(t, ok)
+}
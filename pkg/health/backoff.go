@@ -0,0 +1,83 @@
+package health
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// BackoffStrategy computes the delay before a retry of a failed check function (see WithRetryBackoff).
+// The retry number starts with 1 for the first retry (i.e., the second attempt).
+type BackoffStrategy func(retry uint) time.Duration
+
+// ConstantBackoff returns a BackoffStrategy that waits for the same delay before each retry.
+func ConstantBackoff(delay time.Duration) BackoffStrategy {
+	return func(uint) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff returns a BackoffStrategy that doubles the delay with each retry, starting with the
+// base delay. The delay never exceeds maxDelay. A non-positive maxDelay means that there is no limit.
+func ExponentialBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	return func(retry uint) time.Duration {
+		return exponentialDelay(base, maxDelay, retry)
+	}
+}
+
+// FullJitterBackoff returns a BackoffStrategy that waits for a random delay between zero and the delay of
+// ExponentialBackoff. The randomization prevents that the retries of many instances are synchronized
+// (e.g., after a dependency recovered). The random numbers are drawn from the given source, which allows
+// deterministic delays in tests. If source is nil, the global random number generator is used.
+func FullJitterBackoff(base, maxDelay time.Duration, source rand.Source) BackoffStrategy {
+	int64N := rand.Int64N
+
+	if source != nil {
+		// A rand.Rand is not safe for concurrent use, but the strategy may be shared by several checks.
+		var mtx sync.Mutex
+		rng := rand.New(source)
+
+		int64N = func(n int64) int64 {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			return rng.Int64N(n)
+		}
+	}
+
+	return func(retry uint) time.Duration {
+		delay := exponentialDelay(base, maxDelay, retry)
+		if delay <= 0 {
+			return 0
+		}
+
+		return time.Duration(int64N(int64(delay) + 1))
+	}
+}
+
+func exponentialDelay(base, maxDelay time.Duration, retry uint) time.Duration {
+	delay := base
+	for i := uint(1); i < retry; i++ {
+		// Stop doubling once the limit is reached, which also prevents an overflow.
+		if (maxDelay > 0 && delay >= maxDelay) || delay > time.Duration(1<<62) {
+			break
+		}
+
+		delay *= 2
+	}
+
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
+
+// WithRetryBackoff sets the strategy that computes the delay between two attempts of a retried check
+// function (see WithRetry), such as ExponentialBackoff or FullJitterBackoff. It takes precedence over the
+// constant backoff passed to WithRetry.
+func WithRetryBackoff(strategy BackoffStrategy) CheckOption {
+	return func(check *Check) {
+		check.retryPolicyOrDefault().backoffStrategy = strategy
+	}
+}
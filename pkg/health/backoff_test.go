@@ -0,0 +1,138 @@
+package health_test
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func delays(strategy health.BackoffStrategy, retries uint) []time.Duration {
+	result := make([]time.Duration, 0, retries)
+	for retry := uint(1); retry <= retries; retry++ {
+		result = append(result, strategy(retry))
+	}
+
+	return result
+}
+
+func TestConstantBackoff(t *testing.T) {
+	// Arrange
+	strategy := health.ConstantBackoff(50 * time.Millisecond)
+
+	// Act
+	result := delays(strategy, 4)
+
+	// Assert
+	assert.Equal(t, []time.Duration{
+		50 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond,
+	}, result)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		expected []time.Duration
+	}{
+		{
+			name:     "without limit",
+			expected: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 80 * time.Millisecond},
+		},
+		{
+			name:     "with limit",
+			maxDelay: 30 * time.Millisecond,
+			expected: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond, 30 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			strategy := health.ExponentialBackoff(10*time.Millisecond, tt.maxDelay)
+
+			// Act
+			result := delays(strategy, 4)
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExponentialBackoffDoesNotOverflow(t *testing.T) {
+	// Arrange
+	strategy := health.ExponentialBackoff(time.Second, 0)
+
+	// Act
+	result := strategy(200)
+
+	// Assert
+	assert.Positive(t, result)
+}
+
+func TestFullJitterBackoff(t *testing.T) {
+	// Arrange
+	base, maxDelay := 10*time.Millisecond, 50*time.Millisecond
+	strategy := health.FullJitterBackoff(base, maxDelay, rand.NewPCG(1, 2))
+
+	// The expected delays are drawn from an identically seeded generator.
+	rng := rand.New(rand.NewPCG(1, 2))
+	expected := make([]time.Duration, 0, 5)
+	for _, ceiling := range []time.Duration{base, 2 * base, 4 * base, maxDelay, maxDelay} {
+		expected = append(expected, time.Duration(rng.Int64N(int64(ceiling)+1)))
+	}
+
+	// Act
+	result := delays(strategy, 5)
+
+	// Assert
+	assert.Equal(t, expected, result)
+
+	for i, delay := range result {
+		assert.GreaterOrEqual(t, delay, time.Duration(0), "retry %d", i+1)
+		assert.LessOrEqual(t, delay, maxDelay, "retry %d", i+1)
+	}
+}
+
+func TestFullJitterBackoffWithZeroBase(t *testing.T) {
+	// Arrange
+	strategy := health.FullJitterBackoff(0, time.Second, nil)
+
+	// Act
+	result := strategy(3)
+
+	// Assert
+	assert.Equal(t, time.Duration(0), result)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	// Arrange
+	var (
+		calls   atomic.Int32
+		retries []uint
+	)
+
+	strategy := func(retry uint) time.Duration {
+		retries = append(retries, retry)
+		return time.Millisecond
+	}
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(failingCheck("check", 3, errTransient, &calls),
+			health.WithRetry(4, time.Hour), health.WithRetryBackoff(strategy)),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Equal(t, int32(4), calls.Load())
+	assert.Equal(t, []uint{1, 2, 3}, retries)
+}
@@ -0,0 +1,220 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	// CheckTransition describes the status change of a single check (see WithBatchedTransitions).
+	CheckTransition struct {
+		// Check holds the name of the check.
+		Check string
+		// From holds the status of the check before the transition.
+		From AvailabilityStatus
+		// To holds the status of the check after the transition.
+		To AvailabilityStatus
+		// State holds the state of the check after the transition.
+		State CheckState
+		// IncidentID holds the ID of the incident that is current after the transition (see State.IncidentID).
+		IncidentID string
+	}
+
+	// transitionBatch collects the transitions of periodic checks, so that they are delivered as a single batch
+	// (see WithTransitionBatchWindow).
+	transitionBatch struct {
+		window     time.Duration
+		listener   func(context.Context, []CheckTransition)
+		mtx        sync.Mutex
+		pending    map[string]CheckTransition
+		pendingCtx context.Context
+		clock      TimerClock
+		timer      Timer
+	}
+)
+
+// WithBatchedTransitions registers a listener that is called once per evaluation cycle with all check status
+// transitions of the cycle, e.g., to send a single alert instead of dozens of alerts, if many checks go down at
+// once during a widespread outage. The transitions are ordered by the names of the checks and include status
+// changes that are applied by the Checker itself (see WithSoftDependsOn and WithUnknownTimeout). The listener is
+// not called for cycles without transitions. All synchronous checks are evaluated in the same cycle. Periodic
+// checks are evaluated independently, so their transitions are collected for the batch window (see
+// WithTransitionBatchWindow) and delivered together. The listener is called in addition to the status listeners of
+// the checks (see Check.StatusListener) and of the aggregated status (see WithStatusListener), and it is notified
+// like them (see WithDeferredListeners).
+func WithBatchedTransitions(listener func(ctx context.Context, transitions []CheckTransition)) Option {
+	return func(cfg *checkerConfig) {
+		cfg.transitionsListener = listener
+	}
+}
+
+// WithTransitionBatchWindow sets the duration for which the transitions of periodic checks are collected, before
+// they are delivered as a single batch (see WithBatchedTransitions). The window starts with the first transition
+// of a batch. A check that transitions several times within the window is reported once with its first previous
+// and its latest status (or not at all, if it returned to its previous status). Pending transitions are delivered
+// when the Checker is stopped. If the window is not positive, each evaluation of a periodic check is delivered as
+// a batch of its own. The window is measured with the Clock of the Checker (see WithClock), if it implements
+// TimerClock, and with the system clock otherwise. By default, the window is 1 second.
+func WithTransitionBatchWindow(window time.Duration) Option {
+	return func(cfg *checkerConfig) {
+		cfg.transitionWindow = window
+	}
+}
+
+// checkStatuses returns the statuses of all checks by their names, so that the transitions of an evaluation
+// cycle can be determined (see notifyTransitions). It returns nil if there is no listener for batched transitions.
+// The caller must hold the mutex lock.
+func (ck *defaultChecker) checkStatuses() map[string]AvailabilityStatus {
+	if ck.cfg.transitionsListener == nil {
+		return nil
+	}
+
+	statuses := make(map[string]AvailabilityStatus, len(ck.state.CheckState))
+	for name, state := range ck.state.CheckState {
+		statuses[name] = state.Status
+	}
+
+	return statuses
+}
+
+// notifyTransitions notifies the listener for batched transitions with all checks whose status differs from
+// the given previous statuses (see checkStatuses). The caller must hold the mutex lock.
+func (ck *defaultChecker) notifyTransitions(ctx context.Context, previous map[string]AvailabilityStatus) {
+	listener := ck.cfg.transitionsListener
+	if listener == nil {
+		return
+	}
+
+	var transitions, periodic []CheckTransition
+	for name, state := range ck.state.CheckState {
+		from := previous[name]
+		if from == state.Status {
+			continue
+		}
+
+		transition := CheckTransition{
+			Check:      name,
+			From:       from,
+			To:         state.Status,
+			State:      state,
+			IncidentID: ck.state.IncidentID,
+		}
+
+		if check := ck.cfg.checks[name]; check != nil && isPeriodicCheck(check) {
+			periodic = append(periodic, transition)
+		} else {
+			transitions = append(transitions, transition)
+		}
+	}
+
+	ck.transitionBatch.add(ctx, periodic)
+
+	if len(transitions) == 0 {
+		return
+	}
+
+	sortTransitions(transitions)
+
+	notifyListener(ctx, func(ctx context.Context) { listener(ctx, transitions) })
+}
+
+// notifyStatusListener notifies the status listener of the check (see WithListenerCoolDown).
+func (ck *defaultChecker) notifyStatusListener(ctx context.Context, check *Check, state CheckState) {
+	if check.StatusListener == nil {
+		return
+	}
+
+	notifyListener(ctx, func(ctx context.Context) { ck.listenerThrottle.notify(ctx, check, state) })
+}
+
+func newTransitionBatch(
+	window time.Duration,
+	listener func(context.Context, []CheckTransition),
+	clock Clock,
+) *transitionBatch {
+	if listener == nil {
+		return nil
+	}
+
+	return &transitionBatch{
+		window:   window,
+		listener: listener,
+		clock:    timerClockOf(clock),
+		pending:  map[string]CheckTransition{},
+	}
+}
+
+// add adds the transitions of periodic checks to the pending batch. The batch is delivered once the window
+// is over (see WithTransitionBatchWindow).
+func (tb *transitionBatch) add(ctx context.Context, transitions []CheckTransition) {
+	if tb == nil || len(transitions) == 0 {
+		return
+	}
+
+	if tb.window <= 0 {
+		sortTransitions(transitions)
+		notifyListener(ctx, func(ctx context.Context) { tb.listener(ctx, transitions) })
+
+		return
+	}
+
+	tb.mtx.Lock()
+	defer tb.mtx.Unlock()
+
+	for _, transition := range transitions {
+		if pending, ok := tb.pending[transition.Check]; ok {
+			transition.From = pending.From
+		}
+
+		if transition.From == transition.To {
+			delete(tb.pending, transition.Check)
+			continue
+		}
+
+		tb.pending[transition.Check] = transition
+	}
+
+	// The batch is delivered after the evaluation, so only the values of its context are retained.
+	tb.pendingCtx = context.WithoutCancel(ctx)
+
+	if tb.timer == nil {
+		tb.timer = tb.clock.AfterFunc(tb.window, tb.flush)
+	}
+}
+
+// flush delivers the pending batch, if there are any pending transitions.
+func (tb *transitionBatch) flush() {
+	if tb == nil {
+		return
+	}
+
+	tb.mtx.Lock()
+
+	if tb.timer != nil {
+		tb.timer.Stop()
+		tb.timer = nil
+	}
+
+	ctx := tb.pendingCtx
+	transitions := make([]CheckTransition, 0, len(tb.pending))
+	for _, transition := range tb.pending {
+		transitions = append(transitions, transition)
+	}
+	clear(tb.pending)
+	tb.pendingCtx = nil
+
+	tb.mtx.Unlock()
+
+	if len(transitions) == 0 {
+		return
+	}
+
+	sortTransitions(transitions)
+	tb.listener(ctx, transitions)
+}
+
+func sortTransitions(transitions []CheckTransition) {
+	sort.Slice(transitions, func(i, j int) bool { return transitions[i].Check < transitions[j].Check })
+}
@@ -0,0 +1,192 @@
+package health_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type transitionsRecorder struct {
+	mtx     sync.Mutex
+	batches [][]health.CheckTransition
+}
+
+func (r *transitionsRecorder) listen(_ context.Context, transitions []health.CheckTransition) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.batches = append(r.batches, transitions)
+}
+
+func TestBatchedTransitions(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	var perCheckCalls atomic.Int32
+	recorder := &transitionsRecorder{}
+	perCheckListener := func(context.Context, string, health.CheckState) { perCheckCalls.Add(1) }
+
+	options := []health.Option{
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithBatchedTransitions(recorder.listen),
+	}
+	for _, name := range []string{"queue", "cache", "db"} {
+		check := toggledCheck(name, &fail)
+		check.StatusListener = perCheckListener
+		options = append(options, health.WithCheck(check))
+	}
+
+	ckr := health.NewChecker(options...)
+	ckr.Check(t.Context())
+	require.Len(t, recorder.batches, 1)
+
+	// Act
+	fail.Store(true)
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Len(t, recorder.batches, 2)
+
+	batch := recorder.batches[1]
+	require.Len(t, batch, 3)
+	assert.Equal(t, []string{"cache", "db", "queue"}, []string{batch[0].Check, batch[1].Check, batch[2].Check})
+	for _, transition := range batch {
+		assert.Equal(t, health.StatusUp, transition.From)
+		assert.Equal(t, health.StatusDown, transition.To)
+		assert.Equal(t, health.StatusDown, transition.State.Status)
+		assert.EqualError(t, transition.State.Result, "unavailable")
+	}
+	// The status listeners of the checks are still called for both transitions of each check.
+	assert.Equal(t, int32(6), perCheckCalls.Load())
+}
+
+func TestBatchedTransitionsOnlyContainChangedChecks(t *testing.T) {
+	// Arrange
+	var fail, stable atomic.Bool
+	recorder := &transitionsRecorder{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithBatchedTransitions(recorder.listen),
+		health.WithCheck(toggledCheck("db", &fail)),
+		health.WithCheck(toggledCheck("cache", &stable)),
+	)
+	ckr.Check(t.Context())
+
+	// Act
+	ckr.Check(t.Context())
+	fail.Store(true)
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Len(t, recorder.batches, 2)
+
+	first := recorder.batches[0]
+	require.Len(t, first, 2)
+	assert.Equal(t, health.StatusUnknown, first[0].From)
+	assert.Equal(t, health.StatusUp, first[0].To)
+
+	second := recorder.batches[1]
+	require.Len(t, second, 1)
+	assert.Equal(t, "db", second[0].Check)
+	assert.Equal(t, health.StatusDown, second[0].To)
+}
+
+func (r *transitionsRecorder) get() [][]health.CheckTransition {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([][]health.CheckTransition{}, r.batches...)
+}
+
+func TestBatchedTransitionsCoalescePeriodicChecks(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	fail.Store(true)
+	recorder := &transitionsRecorder{}
+
+	options := []health.Option{
+		health.WithBatchedTransitions(recorder.listen),
+		health.WithTransitionBatchWindow(100 * time.Millisecond),
+	}
+	for _, name := range []string{"queue", "cache", "db"} {
+		options = append(options, health.WithPeriodicCheck(time.Hour, 0, toggledCheck(name, &fail)))
+	}
+
+	// Act
+	ckr := health.NewChecker(options...)
+	defer ckr.Stop()
+
+	// Assert
+	require.Eventually(t, func() bool { return len(recorder.get()) > 0 }, time.Second, time.Millisecond)
+
+	batches := recorder.get()
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 3)
+	assert.Equal(t, []string{"cache", "db", "queue"}, []string{batches[0][0].Check, batches[0][1].Check, batches[0][2].Check})
+	for _, transition := range batches[0] {
+		assert.Equal(t, health.StatusUnknown, transition.From)
+		assert.Equal(t, health.StatusDown, transition.To)
+	}
+}
+
+func TestBatchedTransitionWindowIsScheduledWithClock(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	clock := newFakeClock(time.Now())
+	recorder := &transitionsRecorder{}
+	ckr := health.NewChecker(
+		health.WithClock(clock),
+		health.WithBatchedTransitions(recorder.listen),
+		health.WithTransitionBatchWindow(time.Minute),
+		health.WithPeriodicCheck(time.Hour, 0, toggledCheck("db", &fail)),
+	)
+	defer ckr.Stop()
+	require.Eventually(t, func() bool {
+		state, _ := ckr.(health.StateReader).LastCheckState("db")
+		return state.Status == health.StatusUp
+	}, time.Second, time.Millisecond)
+	ckr.Check(t.Context()) // waits until the state update of the periodic check is complete
+
+	// Act
+	clock.Advance(59 * time.Second)
+	withinWindow := recorder.get()
+	clock.Advance(time.Second)
+	afterWindow := recorder.get()
+
+	// Assert
+	assert.Empty(t, withinWindow)
+	require.Len(t, afterWindow, 1)
+	assert.Equal(t, health.StatusUp, afterWindow[0][0].To)
+}
+
+func TestBatchedTransitionsAreDeliveredOnStop(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	recorder := &transitionsRecorder{}
+	ckr := health.NewChecker(
+		health.WithBatchedTransitions(recorder.listen),
+		health.WithTransitionBatchWindow(time.Hour),
+		health.WithPeriodicCheck(time.Hour, 0, toggledCheck("db", &fail)),
+	)
+	require.Eventually(t, func() bool {
+		state, _ := ckr.(health.StateReader).LastCheckState("db")
+		return state.Status == health.StatusUp
+	}, time.Second, time.Millisecond)
+
+	// Act
+	ckr.Stop()
+
+	// Assert
+	batches := recorder.get()
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 1)
+	assert.Equal(t, health.StatusUp, batches[0][0].To)
+}
@@ -0,0 +1,355 @@
+package health
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// binaryFormatVersion is the first byte of each binary encoded State or CheckState (see State.MarshalBinary).
+const binaryFormatVersion byte = 1
+
+// Status codes of the binary format. Statuses other than the predefined ones are encoded
+// with statusCodeCustom followed by the status as a string.
+const (
+	statusCodeCustom byte = iota
+	statusCodeUnknown
+	statusCodeUp
+	statusCodeDegraded
+	statusCodeDown
+)
+
+type (
+	binaryWriter struct {
+		buf []byte
+	}
+
+	binaryReader struct {
+		data []byte
+		err  error
+	}
+)
+
+// MarshalBinary encodes the State into a compact binary format, e.g., to share the health of instances
+// of a clustered service by gossip. The layout consists of a version byte followed by the fields of the State
+// (including the incident ID) and its check states (sorted by check name). Integers are encoded as varints,
+// strings are prefixed with their length, times are encoded as Unix nanoseconds (0 for the zero time) and errors
+// are encoded as their messages.
+// Sub-results retain their status, timestamp, error, reason and message. See UnmarshalBinary for the decoding.
+func (s State) MarshalBinary() ([]byte, error) {
+	w := &binaryWriter{buf: []byte{binaryFormatVersion}}
+
+	w.status(s.Status)
+	w.time(s.DownSince)
+	w.time(s.UpSince)
+	w.time(s.LastStatusChangeAt)
+	w.string(s.CycleID)
+	w.string(s.IncidentID)
+
+	names := make([]string, 0, len(s.CheckState))
+	for name := range s.CheckState {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.uvarint(uint64(len(names)))
+	for _, name := range names {
+		w.string(name)
+		w.checkState(s.CheckState[name])
+	}
+
+	return w.buf, nil
+}
+
+// UnmarshalBinary decodes a State that was encoded with State.MarshalBinary. Decoded times are in UTC and
+// decoded errors only retain their messages (i.e., errors.Is no longer matches the original error chain).
+// It returns ErrInvalidBinaryFormat if the data is malformed.
+func (s *State) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader(data)
+	if err != nil {
+		return err
+	}
+
+	state := State{
+		Status:             r.status(),
+		DownSince:          r.time(),
+		UpSince:            r.time(),
+		LastStatusChangeAt: r.time(),
+		CycleID:            r.string(),
+		IncidentID:         r.string(),
+	}
+
+	count := r.count()
+	state.CheckState = make(map[string]CheckState, count)
+	for range count {
+		name := r.string()
+		state.CheckState[name] = r.checkState()
+	}
+
+	if err := r.finish(); err != nil {
+		return err
+	}
+
+	*s = state
+
+	return nil
+}
+
+// MarshalBinary encodes the CheckState into the compact binary format of State.MarshalBinary.
+func (s CheckState) MarshalBinary() ([]byte, error) {
+	w := &binaryWriter{buf: []byte{binaryFormatVersion}}
+	w.checkState(s)
+
+	return w.buf, nil
+}
+
+// UnmarshalBinary decodes a CheckState that was encoded with CheckState.MarshalBinary.
+// Please refer to State.UnmarshalBinary for more information.
+func (s *CheckState) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader(data)
+	if err != nil {
+		return err
+	}
+
+	state := r.checkState()
+
+	if err := r.finish(); err != nil {
+		return err
+	}
+
+	*s = state
+
+	return nil
+}
+
+func (w *binaryWriter) checkState(s CheckState) {
+	w.status(s.Status)
+	w.error(s.Result)
+	w.string(s.Reason)
+	w.time(s.LastCheckedAt)
+	w.time(s.LastSuccessAt)
+	w.time(s.LastFailureAt)
+	w.time(s.FirstCheckStartedAt)
+	w.time(s.LastStatusChangeAt)
+	w.time(s.StartedAt)
+	w.time(s.FinishedAt)
+	w.time(s.LastSkippedAt)
+	w.uvarint(uint64(s.ContiguousFails))
+	w.uvarint(uint64(s.SkippedEvaluations))
+	w.uvarint(uint64(s.Evaluations))
+	w.uvarint(uint64(s.Deviations))
+
+	names := make([]string, 0, len(s.SubResults))
+	for name := range s.SubResults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.uvarint(uint64(len(names)))
+	for _, name := range names {
+		result := s.SubResults[name]
+
+		w.string(name)
+		w.status(result.Status)
+		w.time(result.Timestamp)
+		w.error(result.Error)
+		w.string(result.Reason)
+		w.string(result.Message)
+	}
+}
+
+func (w *binaryWriter) uvarint(v uint64) {
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+func (w *binaryWriter) string(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *binaryWriter) time(t time.Time) {
+	if t.IsZero() {
+		w.buf = binary.AppendVarint(w.buf, 0)
+		return
+	}
+
+	w.buf = binary.AppendVarint(w.buf, t.UnixNano())
+}
+
+func (w *binaryWriter) error(err error) {
+	w.string(errorMessage(err))
+}
+
+func (w *binaryWriter) status(status AvailabilityStatus) {
+	switch status {
+	case StatusUnknown:
+		w.buf = append(w.buf, statusCodeUnknown)
+	case StatusUp:
+		w.buf = append(w.buf, statusCodeUp)
+	case StatusDegraded:
+		w.buf = append(w.buf, statusCodeDegraded)
+	case StatusDown:
+		w.buf = append(w.buf, statusCodeDown)
+	default:
+		w.buf = append(w.buf, statusCodeCustom)
+		w.string(string(status))
+	}
+}
+
+func newBinaryReader(data []byte) (*binaryReader, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty data", ErrInvalidBinaryFormat)
+	}
+
+	if data[0] != binaryFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBinaryFormat, data[0])
+	}
+
+	return &binaryReader{data: data[1:]}, nil
+}
+
+// finish returns the first error that occurred while reading, or an error if not all data was read.
+func (r *binaryReader) finish() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(r.data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinaryFormat, len(r.data))
+	}
+
+	return nil
+}
+
+func (r *binaryReader) fail(what string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: cannot read %s", ErrInvalidBinaryFormat, what)
+	}
+
+	r.data = nil
+}
+
+func (r *binaryReader) checkState() CheckState {
+	s := CheckState{
+		Status:              r.status(),
+		Result:              r.error(),
+		Reason:              r.string(),
+		LastCheckedAt:       r.time(),
+		LastSuccessAt:       r.time(),
+		LastFailureAt:       r.time(),
+		FirstCheckStartedAt: r.time(),
+		LastStatusChangeAt:  r.time(),
+		StartedAt:           r.time(),
+		FinishedAt:          r.time(),
+		LastSkippedAt:       r.time(),
+		ContiguousFails:     uint(r.uvarint()),
+		SkippedEvaluations:  uint(r.uvarint()),
+		Evaluations:         uint(r.uvarint()),
+		Deviations:          uint(r.uvarint()),
+	}
+
+	if count := r.count(); count > 0 {
+		s.SubResults = make(map[string]CheckResult, count)
+		for range count {
+			name := r.string()
+			s.SubResults[name] = CheckResult{
+				Status:    r.status(),
+				Timestamp: r.time(),
+				Error:     r.error(),
+				Reason:    r.string(),
+				Message:   r.string(),
+			}
+		}
+	}
+
+	return s
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail("integer")
+		return 0
+	}
+
+	r.data = r.data[n:]
+
+	return v
+}
+
+// count reads the number of entries of a map. Each entry takes at least one byte, so the
+// count is validated against the remaining data to not allocate huge maps for malformed data.
+func (r *binaryReader) count() int {
+	count := r.uvarint()
+	if count > uint64(len(r.data)) {
+		r.fail("number of entries")
+		return 0
+	}
+
+	return int(count)
+}
+
+func (r *binaryReader) string() string {
+	length := r.uvarint()
+	if length > uint64(len(r.data)) {
+		r.fail("string")
+		return ""
+	}
+
+	s := string(r.data[:length])
+	r.data = r.data[length:]
+
+	return s
+}
+
+func (r *binaryReader) time() time.Time {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail("time")
+		return time.Time{}
+	}
+
+	r.data = r.data[n:]
+
+	if v == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, v).UTC()
+}
+
+func (r *binaryReader) error() error {
+	if msg := r.string(); msg != "" {
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+func (r *binaryReader) status() AvailabilityStatus {
+	if len(r.data) == 0 {
+		r.fail("status")
+		return ""
+	}
+
+	code := r.data[0]
+	r.data = r.data[1:]
+
+	switch code {
+	case statusCodeUnknown:
+		return StatusUnknown
+	case statusCodeUp:
+		return StatusUp
+	case statusCodeDegraded:
+		return StatusDegraded
+	case statusCodeDown:
+		return StatusDown
+	case statusCodeCustom:
+		return AvailabilityStatus(r.string())
+	default:
+		r.fail("status")
+		return ""
+	}
+}
@@ -0,0 +1,196 @@
+package health_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func newGossipState(numChecks int) health.State {
+	now := time.Date(2025, time.June, 2, 12, 0, 0, 123456789, time.UTC)
+
+	state := health.State{
+		Status:             health.StatusDegraded,
+		DownSince:          now.Add(-time.Minute),
+		LastStatusChangeAt: now.Add(-time.Minute),
+		CycleID:            "4f2a9c1e",
+		IncidentID:         "9b1d7e3a",
+		CheckState:         make(map[string]health.CheckState, numChecks),
+	}
+
+	for i := range numChecks {
+		checkState := health.CheckState{
+			Status:              health.StatusUp,
+			LastCheckedAt:       now,
+			LastSuccessAt:       now,
+			FirstCheckStartedAt: now.Add(-time.Hour),
+			StartedAt:           now.Add(-5 * time.Millisecond),
+			FinishedAt:          now,
+		}
+
+		if i%3 == 0 {
+			checkState.Status = health.StatusDegraded
+			checkState.Result = fmt.Errorf("slow: %w", health.ErrDegraded)
+			checkState.Reason = health.ReasonDegraded
+			checkState.LastFailureAt = now
+			checkState.ContiguousFails = 2
+		}
+
+		state.CheckState[fmt.Sprintf("check-%d", i)] = checkState
+	}
+
+	return state
+}
+
+func TestStateBinaryRoundTrip(t *testing.T) {
+	// Arrange
+	state := newGossipState(5)
+	state.CheckState["custom"] = health.CheckState{
+		Status:             "maintenance",
+		SkippedEvaluations: 3,
+		Evaluations:        10,
+		Deviations:         1,
+		SubResults: map[string]health.CheckResult{
+			"replica": {Status: health.StatusDown, Timestamp: state.DownSince, Error: errors.New("lagging"), Reason: health.ReasonError, Message: "5s behind"},
+		},
+	}
+
+	// Act
+	data, err := state.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded health.State
+	err = decoded.UnmarshalBinary(data)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, state.Status, decoded.Status)
+	assert.Equal(t, state.DownSince, decoded.DownSince)
+	assert.True(t, decoded.UpSince.IsZero())
+	assert.Equal(t, state.CycleID, decoded.CycleID)
+	assert.Equal(t, state.IncidentID, decoded.IncidentID)
+	require.Len(t, decoded.CheckState, len(state.CheckState))
+
+	for name, expected := range state.CheckState {
+		actual := decoded.CheckState[name]
+		assert.Equal(t, expected.Status, actual.Status, name)
+		assert.Equal(t, errorString(expected.Result), errorString(actual.Result), name)
+		expected.Result, actual.Result = nil, nil
+
+		for sub, result := range expected.SubResults {
+			assert.Equal(t, errorString(result.Error), errorString(actual.SubResults[sub].Error), name)
+			result.Error = nil
+			expected.SubResults[sub] = result
+
+			actualResult := actual.SubResults[sub]
+			actualResult.Error = nil
+			actual.SubResults[sub] = actualResult
+		}
+
+		assert.Equal(t, expected, actual, name)
+	}
+}
+
+func TestCheckStateBinaryRoundTrip(t *testing.T) {
+	// Arrange
+	state := newGossipState(1).CheckState["check-0"]
+
+	// Act
+	data, err := state.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded health.CheckState
+	err = decoded.UnmarshalBinary(data)
+
+	// Assert
+	require.NoError(t, err)
+	assert.EqualError(t, decoded.Result, "slow: degraded")
+	decoded.Result, state.Result = nil, nil
+	assert.Equal(t, state, decoded)
+}
+
+func TestStateBinaryIsSmallerThanJSON(t *testing.T) {
+	// Arrange
+	state := newGossipState(20)
+
+	// Act
+	binaryData, err := state.MarshalBinary()
+	require.NoError(t, err)
+	jsonData, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Less(t, len(binaryData), len(jsonData)/2)
+}
+
+func TestStateUnmarshalBinaryInvalidData(t *testing.T) {
+	valid, err := newGossipState(2).MarshalBinary()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "Empty", data: nil},
+		{name: "UnsupportedVersion", data: append([]byte{99}, valid[1:]...)},
+		{name: "Truncated", data: valid[:len(valid)/2]},
+		{name: "TrailingBytes", data: append(append([]byte{}, valid...), 0)},
+		{name: "HugeCount", data: []byte{1, 2, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var state health.State
+			err := state.UnmarshalBinary(tt.data)
+
+			// Assert
+			assert.ErrorIs(t, err, health.ErrInvalidBinaryFormat)
+		})
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func BenchmarkStateEncoding(b *testing.B) {
+	state := newGossipState(50)
+
+	b.Run("Binary", func(b *testing.B) {
+		var size int
+
+		b.ReportAllocs()
+
+		for range b.N {
+			data, _ := state.MarshalBinary()
+			size = len(data)
+		}
+
+		b.ReportMetric(float64(size), "bytes")
+	})
+
+	b.Run("JSON", func(b *testing.B) {
+		var size int
+
+		b.ReportAllocs()
+
+		for range b.N {
+			data, _ := json.Marshal(state)
+			size = len(data)
+		}
+
+		b.ReportMetric(float64(size), "bytes")
+	})
+}
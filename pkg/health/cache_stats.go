@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Types of the events that are reported to the event hook (see WithEventHook).
+const (
+	// EventCacheHit is reported if Checker.Check serves the cached state of a check (see WithCacheDuration).
+	EventCacheHit EventType = "CACHE_HIT"
+	// EventCacheMiss is reported if Checker.Check evaluates a check, because its cached state expired.
+	EventCacheMiss EventType = "CACHE_MISS"
+	// EventResponseCacheHit is reported once per response that a handler serves from its response cache
+	// (see WithResponseCache). It does not refer to a check.
+	EventResponseCacheHit EventType = "RESPONSE_CACHE_HIT"
+)
+
+type (
+	// EventType is the type of an Event.
+	EventType string
+
+	// Event describes something that happened inside the Checker (see WithEventHook).
+	Event struct {
+		// Type is the type of the event (e.g., EventCacheHit).
+		Type EventType
+		// Check is the name of the check the event refers to, if any.
+		Check string
+		// Time holds the time (in UTC) of when the event happened.
+		Time time.Time
+	}
+
+	// CacheStats holds the cache statistics of a check (see Stats.Cache).
+	CacheStats struct {
+		// Hits is the number of times Checker.Check or the response cache of a handler (see WithResponseCache)
+		// served the cached state of the check.
+		Hits uint64 `json:"hits"`
+		// Misses is the number of times Checker.Check evaluated the check, because its cached state expired.
+		Misses uint64 `json:"misses"`
+	}
+
+	cacheCounters struct {
+		hits   atomic.Uint64
+		misses atomic.Uint64
+	}
+)
+
+// WithEventHook sets a hook that is called for internal events of the Checker, such as cache hits and misses
+// of checks (see EventCacheHit and EventCacheMiss). This allows to diagnose probe amplification, e.g., by
+// emitting metrics per check. The hook is notified like the listeners of the Checker (see WithDeferredListeners):
+// unless the notifications are deferred, it is called while the Checker holds its lock, so it must be fast and
+// must not call the Checker.
+func WithEventHook(hook func(ctx context.Context, event Event)) Option {
+	return func(cfg *checkerConfig) {
+		cfg.eventHook = hook
+	}
+}
+
+// recordCacheRead counts a cache hit or miss of a synchronous check and reports it to the event hook.
+func (ck *defaultChecker) recordCacheRead(ctx context.Context, check *Check, hit bool, now time.Time) {
+	counters := ck.cacheCounters[check.Name]
+
+	eventType := EventCacheMiss
+	if hit {
+		counters.hits.Add(1)
+		eventType = EventCacheHit
+	} else {
+		counters.misses.Add(1)
+	}
+
+	ck.notifyEventHook(ctx, Event{Type: eventType, Check: check.Name, Time: now.UTC()})
+}
+
+// recordResponseCacheHit counts a cache hit for each enabled synchronous check of the checker, since a response
+// from the response cache of a handler holds their cached states (see WithResponseCache). Like Checker.Check,
+// it skips checks that are disabled (see refreshEnabled), but reports a single event for the response.
+func recordResponseCacheHit(ctx context.Context, checker Checker) {
+	ck, ok := checker.(*defaultChecker)
+	if !ok {
+		return
+	}
+
+	for _, check := range ck.cfg.checks {
+		if !isPeriodicCheck(check) && ck.isEnabled(ctx, check) {
+			ck.cacheCounters[check.Name].hits.Add(1)
+		}
+	}
+
+	ck.notifyEventHook(ctx, Event{Type: EventResponseCacheHit, Time: ck.cfg.clock.Now().UTC()})
+}
+
+func (ck *defaultChecker) notifyEventHook(ctx context.Context, event Event) {
+	if hook := ck.cfg.eventHook; hook != nil {
+		notifyListener(ctx, func(ctx context.Context) { hook(ctx, event) })
+	}
+}
+
+// cacheStats returns the cache statistics of all synchronous checks by their names (see Stats.Cache).
+func (ck *defaultChecker) cacheStats() map[string]CacheStats {
+	if len(ck.cacheCounters) == 0 {
+		return nil
+	}
+
+	stats := make(map[string]CacheStats, len(ck.cacheCounters))
+	for name, counters := range ck.cacheCounters {
+		stats[name] = CacheStats{Hits: counters.hits.Load(), Misses: counters.misses.Load()}
+	}
+
+	return stats
+}
@@ -0,0 +1,156 @@
+package health_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestCacheHitsAndMisses(t *testing.T) {
+	// Arrange
+	var (
+		calls  atomic.Int32
+		mtx    sync.Mutex
+		events []health.Event
+	)
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithClock(clock),
+		health.WithCacheDuration(time.Minute),
+		health.WithEventHook(func(ctx context.Context, event health.Event) {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			events = append(events, event)
+		}),
+		health.WithCheck(countingCheck("db", &calls)),
+		health.WithPeriodicCheck(time.Hour, 0, countingCheck("periodic", &calls)),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+	ckr.Check(t.Context())
+	ckr.Check(t.Context())
+	clock.Advance(2 * time.Minute)
+	ckr.Check(t.Context())
+
+	// Assert
+	stats := ckr.(health.StatsProvider).Stats()
+	assert.Equal(t, map[string]health.CacheStats{"db": {Hits: 2, Misses: 2}}, stats.Cache)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	types := make([]health.EventType, 0, len(events))
+	for _, event := range events {
+		assert.Equal(t, "db", event.Check)
+		types = append(types, event.Type)
+	}
+
+	assert.Equal(t, []health.EventType{
+		health.EventCacheMiss, health.EventCacheHit, health.EventCacheHit, health.EventCacheMiss,
+	}, types)
+	assert.Equal(t, clock.Now(), events[3].Time)
+}
+
+func TestCacheMissesWithDisabledCache(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(countingCheck("db", &calls)),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+	ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.CacheStats{Misses: 2}, ckr.(health.StatsProvider).Stats().Cache["db"])
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCacheHitsOfResponseCache(t *testing.T) {
+	// Arrange
+	var (
+		calls  atomic.Int32
+		mtx    sync.Mutex
+		events []health.EventType
+	)
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithClock(clock),
+		health.WithDisabledCache(),
+		health.WithFlagProvider(&fakeFlagProvider{disabled: map[string]bool{"disabled": true}}),
+		health.WithEventHook(func(ctx context.Context, event health.Event) {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			events = append(events, event.Type)
+		}),
+		health.WithCheck(countingCheck("db", &calls)),
+		health.WithCheck(countingCheck("cache", &calls)),
+		health.WithCheck(countingCheck("disabled", &calls)),
+		health.WithPeriodicCheck(time.Hour, 0, countingCheck("periodic", &calls)),
+	)
+	handler := health.NewHandler(ckr, health.WithResponseCache(time.Minute))
+
+	// Act
+	for range 3 {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	}
+
+	// Assert
+	stats := ckr.(health.StatsProvider).Stats().Cache
+	assert.Equal(t, health.CacheStats{Hits: 2, Misses: 1}, stats["db"])
+	assert.Equal(t, health.CacheStats{Hits: 2, Misses: 1}, stats["cache"])
+	assert.Equal(t, health.CacheStats{}, stats["disabled"], "disabled checks must not be counted")
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	assert.Equal(t, []health.EventType{
+		health.EventCacheMiss, health.EventCacheMiss, health.EventResponseCacheHit, health.EventResponseCacheHit,
+	}, events)
+}
+
+func TestEventHookWithDeferredListeners(t *testing.T) {
+	// Arrange
+	var ckr health.Checker
+
+	events := make(chan health.Event, 1)
+	ckr = health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithEventHook(func(ctx context.Context, event health.Event) {
+			// The hook may call the Checker, because it is not called while the Checker holds its lock.
+			ckr.Check(ctx)
+			events <- event
+		}),
+		health.WithCheck(health.Check{Name: "db", Check: func(context.Context) error { return nil }}),
+	)
+	handler := health.NewHandler(ckr, health.WithDeferredListeners())
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	select {
+	case event := <-events:
+		assert.Equal(t, health.EventCacheMiss, event.Type)
+	case <-time.After(time.Second):
+		t.Fatal("the event hook was not called")
+	}
+}
@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithCanary marks a check as a canary, e.g., while a new check is rolled out. A canary is evaluated and
+// reported like any other check (see CheckResult.Canary), but it does not contribute to the aggregated status
+// (and the status counts, see WithStatusCounts) until it is promoted with CanaryPromoter.PromoteCheck. A canary
+// cannot be referenced by a readiness expression (see WithReadinessExpression).
+func WithCanary() CheckOption {
+	return func(check *Check) {
+		check.canary = true
+	}
+}
+
+// PromoteCheck implements CanaryPromoter.PromoteCheck. Please refer to CanaryPromoter.PromoteCheck for more information.
+func (ck *defaultChecker) PromoteCheck(name string) error {
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+	}
+
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	if !ck.canaries[name] {
+		return nil
+	}
+
+	delete(ck.canaries, name)
+
+	// The aggregated status is updated right away, since the promoted check may change it.
+	ck.updateState(context.Background())
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package health_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestCanaryDoesNotAffectAggregateUntilPromoted(t *testing.T) {
+	// Arrange
+	var statusChanges atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusListener(func(ctx context.Context, state health.State) { statusChanges.Add(1) }),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+		health.WithCheck(statusCheck("new-check", health.StatusDown), health.WithCanary()),
+	)
+
+	// Act
+	before := ckr.Check(t.Context())
+	err := ckr.(health.CanaryPromoter).PromoteCheck("new-check")
+	after := ckr.Check(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+
+	assert.Equal(t, health.StatusUp, before.Status)
+	assert.Equal(t, health.StatusDown, before.Details["new-check"].Status, "a canary must still be reported")
+	assert.True(t, before.Details["new-check"].Canary)
+	assert.False(t, before.Details["db"].Canary)
+
+	assert.Equal(t, health.StatusDown, after.Status)
+	assert.False(t, after.Details["new-check"].Canary)
+	assert.Equal(t, health.StatusDown, ckr.(health.StateReader).State().Status, "the aggregate must be updated on promotion")
+	assert.Equal(t, int32(2), statusChanges.Load())
+}
+
+func TestCanaryIsExcludedFromCountsAndSoftDependencies(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusCounts(),
+		health.WithCheck(statusCheck("db", health.StatusUp), health.WithSoftDependsOn("new-check")),
+		health.WithCheck(statusCheck("new-check", health.StatusDown), health.WithCanary()),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, health.StatusUp, result.Details["db"].Status)
+	require.NotNil(t, result.Counts)
+	assert.Equal(t, 1, result.Counts.Up)
+	assert.Equal(t, 0, result.Counts.Down)
+}
+
+func TestPromoteCheck(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+	)
+
+	// Act
+	errNotCanary := ckr.(health.CanaryPromoter).PromoteCheck("db")
+	errUnknown := ckr.(health.CanaryPromoter).PromoteCheck("unknown")
+
+	// Assert
+	assert.NoError(t, errNotCanary)
+	assert.ErrorIs(t, errUnknown, health.ErrCheckNotFound)
+}
@@ -0,0 +1,108 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// contextBoundCheck returns a check that blocks until its context is done and then reports the context error.
+func contextBoundCheck(name string, timeout time.Duration, entered chan<- struct{}) health.Check {
+	return health.Check{
+		Name:    name,
+		Timeout: timeout,
+		Check: func(ctx context.Context) error {
+			if entered != nil {
+				entered <- struct{}{}
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+}
+
+func TestCancellationCauses(t *testing.T) {
+	tests := []struct {
+		name           string
+		globalTimeout  time.Duration
+		checkTimeout   time.Duration
+		cancelAfter    time.Duration
+		expectedReason string
+		expectedErr    error
+	}{
+		{
+			name:           "GlobalTimeout",
+			globalTimeout:  20 * time.Millisecond,
+			expectedReason: health.ReasonGlobalTimeout,
+			expectedErr:    health.ErrGlobalTimeout,
+		},
+		{
+			name:           "CheckTimeout",
+			globalTimeout:  5 * time.Second,
+			checkTimeout:   20 * time.Millisecond,
+			expectedReason: health.ReasonTimeout,
+			expectedErr:    health.ErrCheckTimeout,
+		},
+		{
+			name:           "RequestCanceled",
+			globalTimeout:  5 * time.Second,
+			cancelAfter:    20 * time.Millisecond,
+			expectedReason: health.ReasonRequestCanceled,
+			expectedErr:    health.ErrRequestCanceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithTimeout(tt.globalTimeout),
+				health.WithCheck(contextBoundCheck("db", tt.checkTimeout, nil)),
+			)
+
+			ctx := t.Context()
+			if tt.cancelAfter > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				time.AfterFunc(tt.cancelAfter, cancel)
+			}
+
+			// Act
+			ckr.Check(ctx)
+
+			// Assert
+			state, _ := ckr.(health.StateReader).LastCheckState("db")
+			assert.Equal(t, health.StatusDown, state.Status)
+			assert.Equal(t, tt.expectedReason, state.Reason)
+			assert.ErrorIs(t, state.Result, tt.expectedErr)
+		})
+	}
+}
+
+func TestGlobalTimeoutIsACheckTimeout(t *testing.T) {
+	assert.ErrorIs(t, health.ErrGlobalTimeout, health.ErrCheckTimeout)
+}
+
+func TestCancellationCauseShutdown(t *testing.T) {
+	// Arrange
+	entered := make(chan struct{}, 1)
+	ckr := health.NewChecker(
+		health.WithPeriodicCheck(time.Hour, 0, contextBoundCheck("db", time.Hour, entered)),
+	)
+	<-entered
+
+	// Act
+	ckr.Stop()
+
+	// Assert
+	state, _ := ckr.(health.StateReader).LastCheckState("db")
+	assert.Equal(t, health.StatusDown, state.Status)
+	assert.Equal(t, health.ReasonShutdown, state.Reason)
+	require.ErrorIs(t, state.Result, health.ErrCheckerStopped)
+}
@@ -0,0 +1,82 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestBuildCheckerRejectsMissingCheckFunc(t *testing.T) {
+	tests := []struct {
+		name   string
+		option health.Option
+	}{
+		{
+			name:   "Check",
+			option: health.WithCheck(health.Check{Name: "db"}),
+		},
+		{
+			name:   "PeriodicCheck",
+			option: health.WithPeriodicCheck(time.Minute, 0, health.Check{Name: "db"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			ckr, err := health.BuildChecker(health.WithDisabledAutostart(), tt.option)
+
+			// Assert
+			require.ErrorIs(t, err, health.ErrMissingCheckFunc)
+			assert.Contains(t, err.Error(), "db")
+			assert.Nil(t, ckr)
+		})
+	}
+}
+
+func TestNewCheckerPanicsOnMissingCheckFunc(t *testing.T) {
+	// Act & Assert
+	assert.PanicsWithError(t, "missing check function: db", func() {
+		health.NewChecker(health.WithDisabledAutostart(), health.WithCheck(health.Check{Name: "db"}))
+	})
+}
+
+func TestBuildCheckerAcceptsAlternativeCheckFuncs(t *testing.T) {
+	// Act
+	_, err := health.BuildChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:  "queue",
+			Value: func(ctx context.Context) (float64, error) { return 0, nil },
+		}),
+		health.WithCheck(health.Check{
+			Name:      "db",
+			SubChecks: func(ctx context.Context) []health.SubResult { return nil },
+		}),
+	)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestNilCheckFuncsAsUp(t *testing.T) {
+	// Arrange
+	ckr, err := health.BuildChecker(
+		health.WithDisabledAutostart(),
+		health.WithNilCheckFuncsAsUp(),
+		health.WithCheck(health.Check{Name: "db"}),
+	)
+	require.NoError(t, err)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, health.StatusUp, result.Details["db"].Status)
+}
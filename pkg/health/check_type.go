@@ -0,0 +1,141 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// CheckSpec is the declarative description of a check, e.g., as part of the configuration of an application.
+	// A Check is created from a CheckSpec by the factory of its type (see RegisterCheckType and NewCheckFromSpec).
+	CheckSpec struct {
+		// Type is the name of the check type (e.g., "http" or "tcp"). Type is a required attribute.
+		Type string `yaml:"type" json:"type"`
+		// Name is the name of the created check. Name is a required attribute.
+		Name string `yaml:"name" json:"name"`
+		// Timeout sets Check.Timeout of the created check, unless the factory sets a timeout itself.
+		Timeout time.Duration `yaml:"timeout" json:"timeout"`
+		// Params holds the parameters of the check type (e.g., the "url" of an "http" check).
+		Params map[string]string `yaml:"params" json:"params"`
+	}
+
+	// CheckFactory creates a Check from a CheckSpec (see RegisterCheckType).
+	CheckFactory func(spec CheckSpec) (Check, error)
+)
+
+var (
+	// ErrInvalidCheckSpec is returned if a CheckSpec lacks a required attribute or parameter.
+	ErrInvalidCheckSpec = errors.New("invalid check spec")
+	// ErrInvalidCheckType is returned if a check type cannot be registered (see RegisterCheckType).
+	ErrInvalidCheckType = errors.New("invalid check type")
+	// ErrUnknownCheckType is returned if a CheckSpec refers to a check type that is not registered.
+	ErrUnknownCheckType = errors.New("unknown check type")
+)
+
+var checkTypes = struct {
+	mtx       sync.RWMutex
+	factories map[string]CheckFactory
+}{
+	factories: map[string]CheckFactory{
+		"http": newHTTPCheckFromSpec,
+		"tcp":  newTCPCheckFromSpec,
+	},
+}
+
+// RegisterCheckType registers a factory for checks of the given type, so that checks of this type can be
+// created from a CheckSpec (see NewCheckFromSpec). This allows third parties to add custom check types that
+// can be referred to by name in the configuration of an application. The types "http" (see HTTPCheck with the
+// parameter "url") and "tcp" (see TCPCheck with the parameter "address") are registered by default. It returns
+// ErrInvalidCheckType if the name is empty, the factory is nil or the type is already registered.
+func RegisterCheckType(name string, factory func(spec CheckSpec) (Check, error)) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidCheckType)
+	}
+
+	if factory == nil {
+		return fmt.Errorf("%w: %s has no factory", ErrInvalidCheckType, name)
+	}
+
+	checkTypes.mtx.Lock()
+	defer checkTypes.mtx.Unlock()
+
+	if _, ok := checkTypes.factories[name]; ok {
+		return fmt.Errorf("%w: %s is already registered", ErrInvalidCheckType, name)
+	}
+
+	checkTypes.factories[name] = factory
+
+	return nil
+}
+
+// NewCheckFromSpec creates a Check from the given CheckSpec with the factory of its type (see RegisterCheckType).
+// The created check is named after the spec and uses the timeout of the spec, unless the factory sets them itself.
+// It returns ErrInvalidCheckSpec if a required attribute of the spec is missing or the factory returns a check
+// without a check function (see Check.Check), and ErrUnknownCheckType if the type is not registered.
+func NewCheckFromSpec(spec CheckSpec) (Check, error) {
+	if spec.Type == "" {
+		return Check{}, fmt.Errorf("%w: missing type", ErrInvalidCheckSpec)
+	}
+
+	if spec.Name == "" {
+		return Check{}, fmt.Errorf("%w: missing name of %s check", ErrInvalidCheckSpec, spec.Type)
+	}
+
+	checkTypes.mtx.RLock()
+	factory, ok := checkTypes.factories[spec.Type]
+	checkTypes.mtx.RUnlock()
+
+	if !ok {
+		return Check{}, fmt.Errorf("%w: %s", ErrUnknownCheckType, spec.Type)
+	}
+
+	check, err := factory(spec)
+	if err != nil {
+		return Check{}, fmt.Errorf("cannot create %s check %s: %w", spec.Type, spec.Name, err)
+	}
+
+	if check.Check == nil && check.Value == nil && check.SubChecks == nil {
+		return Check{}, fmt.Errorf("%w: %s check %s has no check function", ErrInvalidCheckSpec, spec.Type, spec.Name)
+	}
+
+	if check.Name == "" {
+		check.Name = spec.Name
+	}
+
+	if check.Timeout == 0 {
+		check.Timeout = spec.Timeout
+	}
+
+	return check, nil
+}
+
+// Param returns the parameter with the given key. It returns ErrInvalidCheckSpec if the parameter is missing
+// or empty, so that factories can validate their required parameters (see RegisterCheckType).
+func (s CheckSpec) Param(key string) (string, error) {
+	value := s.Params[key]
+	if value == "" {
+		return "", fmt.Errorf("%w: missing parameter %q", ErrInvalidCheckSpec, key)
+	}
+
+	return value, nil
+}
+
+func newHTTPCheckFromSpec(spec CheckSpec) (Check, error) {
+	url, err := spec.Param("url")
+	if err != nil {
+		return Check{}, err
+	}
+
+	return HTTPCheck(spec.Name, url), nil
+}
+
+func newTCPCheckFromSpec(spec CheckSpec) (Check, error) {
+	address, err := spec.Param("address")
+	if err != nil {
+		return Check{}, err
+	}
+
+	return TCPCheck(spec.Name, address), nil
+}
@@ -0,0 +1,165 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestRegisterCheckType(t *testing.T) {
+	// Arrange
+	var checkedQueue string
+	err := health.RegisterCheckType("test-queue", func(spec health.CheckSpec) (health.Check, error) {
+		queue, err := spec.Param("queue")
+		if err != nil {
+			return health.Check{}, err
+		}
+
+		return health.Check{Check: func(ctx context.Context) error {
+			checkedQueue = queue
+			return nil
+		}}, nil
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { health.UnregisterCheckType("test-queue") })
+
+	// Act
+	check, err := health.NewCheckFromSpec(health.CheckSpec{
+		Type:    "test-queue",
+		Name:    "orders",
+		Timeout: time.Second,
+		Params:  map[string]string{"queue": "orders-queue"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "orders", check.Name)
+	assert.Equal(t, time.Second, check.Timeout)
+
+	ckr := health.NewChecker(health.WithDisabledAutostart(), health.WithCheck(check))
+	result := ckr.Check(t.Context())
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, "orders-queue", checkedQueue)
+}
+
+func TestRegisterCheckTypeInvalid(t *testing.T) {
+	factory := func(health.CheckSpec) (health.Check, error) { return health.Check{}, nil }
+	require.NoError(t, health.RegisterCheckType("test-duplicate", factory))
+	t.Cleanup(func() { health.UnregisterCheckType("test-duplicate") })
+
+	tests := []struct {
+		name     string
+		typeName string
+		factory  func(spec health.CheckSpec) (health.Check, error)
+	}{
+		{name: "EmptyName", typeName: "", factory: factory},
+		{name: "NilFactory", typeName: "test-nil", factory: nil},
+		{name: "AlreadyRegistered", typeName: "test-duplicate", factory: factory},
+		{name: "BuiltInType", typeName: "http", factory: factory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := health.RegisterCheckType(tt.typeName, tt.factory)
+
+			// Assert
+			assert.ErrorIs(t, err, health.ErrInvalidCheckType)
+		})
+	}
+}
+
+func TestNewCheckFromSpecErrors(t *testing.T) {
+	errFactory := errors.New("factory failed")
+	require.NoError(t, health.RegisterCheckType("test-failing", func(health.CheckSpec) (health.Check, error) {
+		return health.Check{}, errFactory
+	}))
+	require.NoError(t, health.RegisterCheckType("test-empty", func(health.CheckSpec) (health.Check, error) {
+		return health.Check{}, nil
+	}))
+	t.Cleanup(func() {
+		health.UnregisterCheckType("test-failing")
+		health.UnregisterCheckType("test-empty")
+	})
+
+	tests := []struct {
+		name        string
+		spec        health.CheckSpec
+		expectedErr error
+	}{
+		{
+			name:        "MissingType",
+			spec:        health.CheckSpec{Name: "check"},
+			expectedErr: health.ErrInvalidCheckSpec,
+		},
+		{
+			name:        "MissingName",
+			spec:        health.CheckSpec{Type: "tcp", Params: map[string]string{"address": "localhost:5432"}},
+			expectedErr: health.ErrInvalidCheckSpec,
+		},
+		{
+			name:        "UnknownType",
+			spec:        health.CheckSpec{Type: "unknown", Name: "check"},
+			expectedErr: health.ErrUnknownCheckType,
+		},
+		{
+			name:        "MissingParam",
+			spec:        health.CheckSpec{Type: "http", Name: "check"},
+			expectedErr: health.ErrInvalidCheckSpec,
+		},
+		{
+			name:        "FactoryError",
+			spec:        health.CheckSpec{Type: "test-failing", Name: "check"},
+			expectedErr: errFactory,
+		},
+		{
+			name:        "NoCheckFunction",
+			spec:        health.CheckSpec{Type: "test-empty", Name: "check"},
+			expectedErr: health.ErrInvalidCheckSpec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := health.NewCheckFromSpec(tt.spec)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestNewCheckFromSpecBuiltInTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		spec health.CheckSpec
+	}{
+		{
+			name: "HTTP",
+			spec: health.CheckSpec{Type: "http", Name: "api", Params: map[string]string{"url": "http://localhost"}},
+		},
+		{
+			name: "TCP",
+			spec: health.CheckSpec{Type: "tcp", Name: "db", Params: map[string]string{"address": "localhost:5432"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			check, err := health.NewCheckFromSpec(tt.spec)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.spec.Name, check.Name)
+			assert.NotNil(t, check.Check)
+		})
+	}
+}
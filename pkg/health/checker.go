@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	slogctx "github.com/veqryn/slog-context"
 )
 
 type (
@@ -17,19 +22,85 @@ type (
 		checks               map[string]*Check
 		cacheTTL             time.Duration
 		statusChangeListener func(context.Context, State)
+		transitionPublishers []func(context.Context, State)
+		clock                Clock
+		listenerCoolDown     time.Duration
+		flagProvider         FlagProvider
+		stateStore           StateStore
+		probeHints           *ProbeHints
+		partition            *checkPartition
+		historySize          int
+		groupBudgets         map[string]time.Duration
+		aggregationWindow    time.Duration
+		idGenerator          func() string
+		maxErrorLength       int
+		aggregator           func(map[string]CheckState) AvailabilityStatus
+		minUptime            time.Duration
+		workerPoolSize       int
+		statsInResult        bool
+		selfCheckEnabled     bool
+		selfCheckTarget      *selfCheckTarget
+		panicQuarantine      uint
+		targetDeduplication  bool
+		eventHook            func(context.Context, Event)
+		dependencyLimits     map[string]int
+		onReady              func(time.Duration)
+		nilCheckFuncsAsUp    bool
+		unknownTimeout       time.Duration
+		transitionsListener  func(context.Context, []CheckTransition)
+		transitionWindow     time.Duration
+		resultValidator      func(CheckState) (CheckState, error)
+		readinessExpression  string
+		readiness            func(map[string]CheckState) AvailabilityStatus
+		statusPrecedence     []AvailabilityStatus
 		interceptors         []Interceptor
 		detailsDisabled      bool
+		statusCountsEnabled  bool
 		autostartDisabled    bool
 	}
 
 	defaultChecker struct {
-		started            bool
-		mtx                sync.Mutex
-		cfg                checkerConfig
-		state              State
-		wg                 sync.WaitGroup
-		cancel             context.CancelFunc
-		periodicCheckCount int
+		started             bool
+		mtx                 sync.Mutex
+		cfg                 checkerConfig
+		state               State
+		wg                  sync.WaitGroup
+		cancel              context.CancelCauseFunc
+		periodicCheckCount  int
+		listenerThrottle    *listenerThrottle
+		stateSaver          *stateSaver
+		statePublisher      *statePublisher
+		transitionBatch     *transitionBatch
+		disabledChecks      map[string]bool
+		canaries            map[string]bool
+		draining            atomic.Bool
+		history             *historyBuffer
+		inFlightMtx         sync.Mutex
+		inFlight            map[string]context.CancelCauseFunc
+		forcedStatus        atomic.Pointer[ForcedStatus]
+		snapshot            atomic.Pointer[State]
+		softDegraded        map[string]string
+		pauseStates         map[string]*pauseState
+		errorRates          map[string]*errorRateWindow
+		cacheCounters       map[string]*cacheCounters
+		semaphores          map[string]chan struct{}
+		workers             *workerPool
+		startedAt           atomic.Pointer[time.Time]
+		timeToReady         atomic.Pointer[time.Duration]
+		droppedTickerStates atomic.Uint64
+		tickersDone         atomic.Pointer[chan struct{}]
+	}
+
+	// pauseState controls whether a periodic check is paused (see CheckPauser.PauseCheck) or quarantined
+	// (see WithPanicQuarantine). It also tracks whether the check is disabled (see WithFlagProvider and
+	// WithCheckPartition), so that the state can be read without acquiring the lock of the Checker.
+	pauseState struct {
+		paused  atomic.Bool
+		resumed chan struct{}
+		// contiguousPanics is only accessed by the evaluations of the check, which never run concurrently.
+		contiguousPanics uint
+		quarantined      atomic.Bool
+		disabled         atomic.Bool
 	}
 
 	checkResult struct {
@@ -37,10 +108,35 @@ type (
 		newState  CheckState
 	}
 
+	// checkOutcome is the outcome of a single execution of a check function.
+	checkOutcome struct {
+		err        error
+		subResults []SubResult
+		panicStack []byte
+	}
+
 	jsonCheckResult struct {
-		Status    string    `json:"status"`
-		Timestamp time.Time `json:"timestamp,omitempty"`
-		Error     string    `json:"error,omitempty"`
+		Status             string                 `json:"status"`
+		Timestamp          time.Time              `json:"timestamp,omitempty"`
+		Error              string                 `json:"error,omitempty"`
+		Reason             string                 `json:"reason,omitempty"`
+		Message            string                 `json:"message,omitempty"`
+		SkippedEvaluations uint                   `json:"skippedEvaluations,omitempty"`
+		SubResults         map[string]CheckResult `json:"details,omitempty"`
+		Paused             bool                   `json:"paused,omitempty"`
+		Deviating          bool                   `json:"deviating,omitempty"`
+		Canary             bool                   `json:"canary,omitempty"`
+		StartedAt          time.Time              `json:"startedAt,omitzero"`
+		FinishedAt         time.Time              `json:"finishedAt,omitzero"`
+		Duration           string                 `json:"duration,omitempty"`
+	}
+
+	// FlagProvider decides whether a check is enabled. It allows to control the participation of checks
+	// at runtime, e.g., by an external feature flag service (see WithFlagProvider).
+	FlagProvider interface {
+		// IsEnabled returns true, if the check with the given name should be evaluated and
+		// contribute to the aggregated health status.
+		IsEnabled(ctx context.Context, checkName string) bool
 	}
 
 	// Checker is the main checker interface. It provides all health checking logic.
@@ -66,12 +162,156 @@ type (
 		IsStarted() bool
 	}
 
+	// Drainer is implemented by Checkers that support a draining mode (see NewDrainHandler).
+	Drainer interface {
+		// Drain puts the Checker into draining mode. While draining, readiness handlers (see
+		// NewReadinessHandler) report StatusDown regardless of the check results, so that traffic
+		// can be drained from the service (e.g., before a deployment). The aggregated status of
+		// Checker.Check and liveness handlers are not affected (see Result.Draining). Checks are
+		// still executed.
+		Drain()
+		// Undrain ends the draining mode (see Drainer.Drain).
+		Undrain()
+		// IsDraining returns true, if the Checker is in draining mode (see Drainer.Drain).
+		IsDraining() bool
+	}
+
+	// HistoryProvider is implemented by Checkers that retain the history of their results
+	// (see WithHistory and NewGrafanaHandler).
+	HistoryProvider interface {
+		// History returns the retained results of all checks and the aggregated status
+		// (see WithHistory). The returned History is a copy and may be modified by the caller.
+		History() History
+	}
+
+	// CheckCanceler is implemented by Checkers that allow to cancel running check evaluations.
+	CheckCanceler interface {
+		// CancelCheck cancels the context of the currently running evaluation of the check with
+		// the given name. Other checks are not affected. The cancelled evaluation fails with
+		// ErrCheckCanceled. It returns ErrCheckNotFound if there is no such check and
+		// ErrCheckNotRunning if the check is currently not being evaluated.
+		CancelCheck(name string) error
+	}
+
+	// StatusForcer is implemented by Checkers that allow to override their aggregated status.
+	StatusForcer interface {
+		// ForceStatus overrides the aggregated status reported by Checker.Check regardless of the check
+		// results (and regardless of the draining mode of readiness handlers, see Drainer.Drain) until
+		// ClearForcedStatus is called.
+		// This is useful as a kill switch, e.g., if a critical invariant is violated elsewhere in the application.
+		// The override and its reason are included in the Result (see Result.Forced).
+		ForceStatus(status AvailabilityStatus, reason string)
+		// ClearForcedStatus removes the override set by StatusForcer.ForceStatus.
+		ClearForcedStatus()
+	}
+
+	// StateReader is implemented by Checkers that expose their latest State without evaluating checks
+	// (see NewPrometheusCollector).
+	StateReader interface {
+		// LastCheckState returns the latest state of the check with the given name. It reads
+		// from an immutable snapshot without acquiring any locks, so it is cheap and never blocks
+		// (even if checks are currently being executed). This allows to gate behaviour in hot
+		// paths, e.g., to skip a feature if its dependency is down. The second return value is
+		// false if there is no check with the given name.
+		LastCheckState(name string) (CheckState, bool)
+		// State returns the latest State of the Checker. It reads from the same snapshot as StateReader.LastCheckState
+		// without acquiring any locks. The returned State is a copy and may be modified by the caller.
+		State() State
+	}
+
+	// ConfigSnapshotter is implemented by Checkers that expose their effective configuration
+	// (see NewConfigSnapshotHandler).
+	ConfigSnapshotter interface {
+		// ConfigSnapshot returns the effective configuration of the Checker for diagnostics, such as the
+		// cache TTL, the timeout, the names of the interceptors and all registered checks with their options.
+		// Durations are represented as strings (e.g., "1m30s"). Values of info entries whose key denotes a
+		// secret (e.g., "password" or "token") are replaced with RedactedValue (see NewConfigSnapshotHandler).
+		ConfigSnapshot() map[string]any
+	}
+
+	// StateTicker is implemented by Checkers that deliver their State periodically.
+	StateTicker interface {
+		// Ticker delivers the current State on the returned channel every interval, regardless of whether
+		// it changed (e.g., as a heartbeat feed for dashboards). The State is read from the same snapshot
+		// as in StateReader.LastCheckState and may be modified by the receiver. If the receiver does not consume
+		// a State before the next one is due, the next one is dropped. The returned function stops the ticker
+		// and closes the channel. It must be called to release the resources of the ticker, unless the Checker
+		// is stopped, which stops all of its tickers (see Checker.Stop). If the interval is not positive, the
+		// returned channel is closed right away.
+		Ticker(interval time.Duration) (<-chan State, func())
+	}
+
+	// CheckPauser is implemented by Checkers that allow to pause periodic checks.
+	CheckPauser interface {
+		// PauseCheck pauses the periodic check with the given name (see WithPeriodicCheck): the check is not
+		// evaluated until ResumeCheck is called, e.g., during a planned downtime of the checked dependency.
+		// The check remains registered and keeps contributing its last state to the aggregated status.
+		// It returns ErrCheckNotFound if there is no such check and ErrCheckNotPeriodic if the check is
+		// not a periodic check.
+		PauseCheck(name string) error
+		// ResumeCheck resumes a periodic check that was paused with CheckPauser.PauseCheck. The check is
+		// evaluated right away and then continues with its schedule. It returns the same errors as PauseCheck.
+		ResumeCheck(name string) error
+	}
+
+	// CanaryPromoter is implemented by Checkers that support canary checks (see WithCanary).
+	CanaryPromoter interface {
+		// PromoteCheck promotes the canary with the given name (see WithCanary), so that it contributes to the
+		// aggregated status from now on. The aggregated status is updated right away. Promoting a check that is
+		// not a canary has no effect. It returns ErrCheckNotFound if there is no such check.
+		PromoteCheck(name string) error
+	}
+
+	// CheckDebugger is implemented by Checkers that allow to debug single checks (see NewDebugHandler).
+	CheckDebugger interface {
+		// DebugCheck evaluates the check with the given name once and returns a verbose record of the evaluation,
+		// including the timings and errors of all attempts (see WithRetry) and the stack trace of a panic. The
+		// evaluation bypasses the cache, the worker pool and the interceptors and does not affect the state of the
+		// check. It adheres to the timeout of the check and to the global timeout (see WithTimeout), and panics are
+		// always recovered (regardless of Check.DisablePanicRecovery). It returns ErrCheckNotFound if there is no
+		// such check (see NewDebugHandler).
+		DebugCheck(ctx context.Context, name string) (DebugResult, error)
+	}
+
+	// StatsProvider is implemented by Checkers that expose internal statistics (see SelfCheck).
+	StatsProvider interface {
+		// Stats returns internal statistics of the Checker, such as the number of active workers and queued
+		// evaluations of the shared worker pool (see WithWorkerPool). It does not acquire the lock of the
+		// Checker, so it can be called while checks are being executed.
+		Stats() Stats
+	}
+
+	// ForcedStatus describes an override of the aggregated status (see StatusForcer.ForceStatus).
+	ForcedStatus struct {
+		// Status is the forced aggregated status.
+		Status AvailabilityStatus `json:"status"`
+		// Reason describes why the status was forced.
+		Reason string `json:"reason,omitempty"`
+		// Since holds the time of when the status was forced.
+		Since time.Time `json:"since"`
+	}
+
 	// State represents the current state of the Checker.
 	State struct {
 		// Status is the aggregated system health status.
 		Status AvailabilityStatus
 		// CheckState contains the state of all checks.
 		CheckState map[string]CheckState
+		// DownSince holds the time of when the aggregated status left StatusUp. It is zero while
+		// the aggregated status is StatusUp (or if it was never determined yet).
+		DownSince time.Time
+		// CycleID holds the ID of the evaluation cycle that last updated the state (see CycleIDFromContext).
+		CycleID string
+		// IncidentID holds the ID of the current incident, i.e., of the period in which the aggregated status
+		// is not StatusUp (see DownSince). The ID is assigned when the period starts and retained by all
+		// transitions until the aggregated status returns to StatusUp. It is empty while the aggregated status
+		// is StatusUp (or if it was never determined yet).
+		IncidentID string
+		// UpSince holds the time of when the aggregated status became StatusUp. It is zero while
+		// the aggregated status is not StatusUp.
+		UpSince time.Time
+		// LastStatusChangeAt holds the time of when the aggregated status last changed.
+		LastStatusChangeAt time.Time
 	}
 
 	// CheckState represents the current state of a component check.
@@ -82,14 +322,38 @@ type (
 		LastSuccessAt time.Time
 		// LastFailureAt holds the last time of when the check did return an error.
 		LastFailureAt time.Time
-		// FirstCheckStartedAt holds the time of when the first check was started.
+		// FirstCheckStartedAt holds the time of when the first check was started. For checks with an active window,
+		// it holds the time of when the first check after the last failure outside the window was started
+		// (see WithActiveWindow).
 		FirstCheckStartedAt time.Time
 		// ContiguousFails holds the number of how often the check failed in a row.
 		ContiguousFails uint
 		// Result holds the error of the last check (nil if successful).
 		Result error
+		// Reason holds a machine-readable reason code for the error of the last check
+		// (empty if successful). See ReasonOf for more information.
+		Reason string
 		// The current availability status of the check.
 		Status AvailabilityStatus
+		// SkippedEvaluations holds the number of scheduled evaluations of a periodic check that were
+		// skipped, because the previous evaluation was still running (see WithPeriodicCheck).
+		SkippedEvaluations uint
+		// LastSkippedAt holds the time of when an evaluation of the check was last skipped.
+		LastSkippedAt time.Time
+		// SubResults holds the results of the sub-checks of the last check (see Check.SubChecks).
+		SubResults map[string]CheckResult
+		// Evaluations holds the number of evaluations of a check with an expected status (see WithExpectedStatus).
+		Evaluations uint
+		// Deviations holds the number of evaluations that resulted in a status other than the expected status
+		// (see WithExpectedStatus).
+		Deviations uint
+		// LastStatusChangeAt holds the time of when the status of the check last changed.
+		LastStatusChangeAt time.Time
+		// StartedAt holds the time (in UTC) of when the check function of the last evaluation was started.
+		// Retries (see WithRetry) belong to the same evaluation.
+		StartedAt time.Time
+		// FinishedAt holds the time (in UTC) of when the last evaluation finished. It equals LastCheckedAt.
+		FinishedAt time.Time
 	}
 
 	// Result holds the aggregated system availability status and
@@ -101,6 +365,43 @@ type (
 		Status AvailabilityStatus `json:"status"`
 		// Details contains health information for all checked components.
 		Details map[string]CheckResult `json:"details,omitempty"`
+		// Counts contains the number of checks per availability status (see WithStatusCounts).
+		Counts *StatusCounts `json:"counts,omitempty"`
+		// Draining is true, if the Checker is in draining mode (see Drainer.Drain).
+		Draining bool `json:"draining,omitempty"`
+		// WarmingUp is true, if the minimum uptime of the Checker has not elapsed yet (see WithMinUptime).
+		// Readiness handlers report StatusDown meanwhile (see NewReadinessHandler).
+		WarmingUp bool `json:"warmingUp,omitempty"`
+		// DownSince holds the time of when the aggregated status left StatusUp (see State.DownSince).
+		// It is nil while the aggregated status is StatusUp.
+		DownSince *time.Time `json:"downSince,omitempty"`
+		// Forced is set if the aggregated status is overridden (see StatusForcer.ForceStatus).
+		Forced *ForcedStatus `json:"forced,omitempty"`
+		// CycleID holds the ID of the evaluation cycle that last updated the result (see State.CycleID).
+		CycleID string `json:"cycleId,omitempty"`
+		// IncidentID holds the ID of the current incident (see State.IncidentID).
+		IncidentID string `json:"incidentId,omitempty"`
+		// Stats holds internal statistics of the Checker (see WithStatsInResult).
+		Stats *Stats `json:"stats,omitempty"`
+		// ProbeHints holds recommendations on how to probe the service (see WithProbeHints).
+		ProbeHints *ProbeHints `json:"probeHints,omitempty"`
+
+		// readiness holds the status of the readiness expression, if any (see WithReadinessExpression).
+		readiness AvailabilityStatus
+	}
+
+	// StatusCounts holds the number of checks per availability status.
+	StatusCounts struct {
+		// Total is the total number of checks.
+		Total int `json:"total"`
+		// Up is the number of checks with status StatusUp.
+		Up int `json:"up"`
+		// Degraded is the number of checks with status StatusDegraded.
+		Degraded int `json:"degraded"`
+		// Down is the number of checks with status StatusDown.
+		Down int `json:"down"`
+		// Unknown is the number of checks with status StatusUnknown.
+		Unknown int `json:"unknown"`
 	}
 
 	// CheckResult holds a components health information.
@@ -117,6 +418,26 @@ type (
 		Timestamp time.Time `json:"timestamp,omitempty"`
 		// Error contains the check error message, if the check failed.
 		Error error `json:"error,omitempty"`
+		// Reason contains a machine-readable reason code, if the check failed.
+		Reason string `json:"reason,omitempty"`
+		// Message contains a human-readable description of a sub-result (see SubResult.Message).
+		Message string `json:"message,omitempty"`
+		// SkippedEvaluations contains the number of skipped evaluations (see CheckState.SkippedEvaluations).
+		SkippedEvaluations uint `json:"skippedEvaluations,omitempty"`
+		// SubResults contains the results of the sub-checks of a component (see Check.SubChecks).
+		SubResults map[string]CheckResult `json:"details,omitempty"`
+		// Paused is true, if the check is paused (see CheckPauser.PauseCheck).
+		Paused bool `json:"paused,omitempty"`
+		// Deviating is true, if the status differs from the expected status of the check (see WithExpectedStatus).
+		Deviating bool `json:"deviating,omitempty"`
+		// Canary is true, if the check is a canary that does not contribute to the aggregated status (see WithCanary).
+		Canary bool `json:"canary,omitempty"`
+		// StartedAt holds the time of when the last evaluation started (see CheckState.StartedAt).
+		StartedAt time.Time `json:"startedAt,omitzero"`
+		// FinishedAt holds the time of when the last evaluation finished (see CheckState.FinishedAt).
+		FinishedAt time.Time `json:"finishedAt,omitzero"`
+		// Duration holds the duration of the last evaluation (e.g., "1.5ms").
+		Duration time.Duration `json:"duration,omitempty"`
 	}
 
 	// Interceptor is factory function that allows creating new instances of
@@ -146,11 +467,45 @@ const (
 	// StatusUp holds the information that the system or a component
 	// is up and running.
 	StatusUp AvailabilityStatus = "up"
+	// StatusDegraded holds the information that the system or a component
+	// is available, but with limited functionality or performance.
+	StatusDegraded AvailabilityStatus = "degraded"
 	// StatusDown holds the information that the system or a component
 	// down and not available.
 	StatusDown AvailabilityStatus = "down"
 )
 
+// The Checker created by NewChecker implements all optional interfaces.
+var (
+	_ Drainer           = (*defaultChecker)(nil)
+	_ HistoryProvider   = (*defaultChecker)(nil)
+	_ CheckCanceler     = (*defaultChecker)(nil)
+	_ StatusForcer      = (*defaultChecker)(nil)
+	_ StateReader       = (*defaultChecker)(nil)
+	_ ConfigSnapshotter = (*defaultChecker)(nil)
+	_ StateTicker       = (*defaultChecker)(nil)
+	_ CheckPauser       = (*defaultChecker)(nil)
+	_ CanaryPromoter    = (*defaultChecker)(nil)
+	_ CheckDebugger     = (*defaultChecker)(nil)
+	_ StatsProvider     = (*defaultChecker)(nil)
+)
+
+// Duration returns the duration of the last evaluation of the check (see CheckState.StartedAt and
+// CheckState.FinishedAt). It returns 0 if the check was not evaluated yet.
+func (s CheckState) Duration() time.Duration {
+	return s.FinishedAt.Sub(s.StartedAt)
+}
+
+// DeviationRate returns the share of evaluations that resulted in a status other than the expected status
+// (see WithExpectedStatus) as a value between 0 and 1. It returns 0 if the check was not evaluated yet.
+func (s CheckState) DeviationRate() float64 {
+	if s.Evaluations == 0 {
+		return 0
+	}
+
+	return float64(s.Deviations) / float64(s.Evaluations)
+}
+
 // MarshalJSON provides a custom marshaller for the CheckResult type.
 func (cr CheckResult) MarshalJSON() ([]byte, error) {
 	errorMsg := ""
@@ -158,10 +513,25 @@ func (cr CheckResult) MarshalJSON() ([]byte, error) {
 		errorMsg = cr.Error.Error()
 	}
 
+	duration := ""
+	if cr.Duration != 0 {
+		duration = cr.Duration.String()
+	}
+
 	return json.Marshal(&jsonCheckResult{
-		Status:    string(cr.Status),
-		Timestamp: cr.Timestamp,
-		Error:     errorMsg,
+		Status:             string(cr.Status),
+		Timestamp:          cr.Timestamp,
+		Error:              errorMsg,
+		Reason:             cr.Reason,
+		Message:            cr.Message,
+		SkippedEvaluations: cr.SkippedEvaluations,
+		SubResults:         cr.SubResults,
+		Paused:             cr.Paused,
+		Deviating:          cr.Deviating,
+		Canary:             cr.Canary,
+		StartedAt:          cr.StartedAt,
+		FinishedAt:         cr.FinishedAt,
+		Duration:           duration,
 	})
 }
 
@@ -173,17 +543,37 @@ func (cr *CheckResult) UnmarshalJSON(data []byte) error {
 
 	cr.Status = AvailabilityStatus(result.Status)
 	cr.Timestamp = result.Timestamp
+	cr.Reason = result.Reason
+	cr.Message = result.Message
+	cr.SkippedEvaluations = result.SkippedEvaluations
+	cr.SubResults = result.SubResults
+	cr.Paused = result.Paused
+	cr.Deviating = result.Deviating
+	cr.Canary = result.Canary
+	cr.StartedAt = result.StartedAt
+	cr.FinishedAt = result.FinishedAt
 
 	if result.Error != "" {
 		cr.Error = errors.New(result.Error)
 	}
 
+	if result.Duration != "" {
+		duration, err := time.ParseDuration(result.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+
+		cr.Duration = duration
+	}
+
 	return nil
 }
 
 func (s AvailabilityStatus) criticality() int {
 	switch s {
 	case StatusDown:
+		return 3
+	case StatusDegraded:
 		return 2
 	case StatusUnknown:
 		return 1
@@ -194,19 +584,122 @@ func (s AvailabilityStatus) criticality() int {
 
 var (
 	ErrCheckTimeout = errors.New("check timed out")
+	// ErrAttemptTimeout is reported for an attempt of a check function that exceeded its own timeout (see WithPerAttemptTimeout).
+	ErrAttemptTimeout = errors.New("check attempt timed out")
+	// ErrDegraded can be wrapped into the error returned by a check function to report
+	// that the checked component is degraded (see StatusDegraded) instead of down. Like any
+	// other error, it only takes effect once the thresholds of the check are crossed (see
+	// Check.MaxContiguousFails and Check.MaxTimeInError); until then, the check is up.
+	ErrDegraded = errors.New("degraded")
+	// ErrCheckCanceled is reported for a check evaluation that was cancelled with CheckCanceler.CancelCheck.
+	ErrCheckCanceled = errors.New("check canceled")
+	// ErrGlobalTimeout is reported for a check evaluation that exceeded the timeout of the Checker (see WithTimeout).
+	// It wraps ErrCheckTimeout.
+	ErrGlobalTimeout = fmt.Errorf("%w: global timeout exceeded", ErrCheckTimeout)
+	// ErrRequestCanceled is reported for a check evaluation whose context was cancelled by the caller of
+	// Checker.Check (e.g., because the client of the health endpoint went away).
+	ErrRequestCanceled = errors.New("health check request canceled")
+	// ErrCheckerStopped is reported for a check evaluation that was interrupted by Checker.Stop.
+	ErrCheckerStopped = errors.New("checker stopped")
+	// ErrCheckQuarantined is reported for a periodic check that was quarantined, because it panicked
+	// repeatedly (see WithPanicQuarantine).
+	ErrCheckQuarantined = errors.New("check quarantined")
+	// ErrUnknownTimeout is reported for a check that did not produce a first result in time (see WithUnknownTimeout).
+	ErrUnknownTimeout = errors.New("no check result within unknown timeout")
+	// ErrSelfCheckUnsupported is reported by a SelfCheck for a Checker that does not implement StatsProvider.
+	ErrSelfCheckUnsupported = errors.New("checker does not provide statistics")
+	// ErrInvalidPartition is returned if a check partition is invalid (see WithCheckPartition).
+	ErrInvalidPartition = errors.New("invalid check partition")
+	// ErrInvalidBinaryFormat is returned if binary data cannot be decoded (see State.UnmarshalBinary).
+	ErrInvalidBinaryFormat = errors.New("invalid binary format")
+	// ErrCheckNotFound is returned if a check with the given name does not exist.
+	ErrCheckNotFound = errors.New("check not found")
+	// ErrCheckNotRunning is returned if a check is currently not being evaluated.
+	ErrCheckNotRunning = errors.New("check not running")
+	// ErrCheckNotPeriodic is returned if an operation is only supported for periodic checks.
+	ErrCheckNotPeriodic = errors.New("check not periodic")
+	// ErrInvalidStatusPrecedence is returned if a status precedence does not rank all statuses (see WithStatusPrecedence).
+	ErrInvalidStatusPrecedence = errors.New("invalid status precedence")
+	// ErrMissingCheckFunc is returned if a check has neither a check function nor a value or sub-check function
+	// (see Check.Check and WithNilCheckFuncsAsUp).
+	ErrMissingCheckFunc = errors.New("missing check function")
+	// ErrConflictingAggregation is returned if a readiness expression is combined with an aggregator or a status
+	// precedence (see WithReadinessExpression).
+	ErrConflictingAggregation = errors.New("readiness expression conflicts with aggregator")
+	// ErrCanaryCheck is returned if a canary is referenced where it would affect the aggregated status
+	// (see WithCanary and WithReadinessExpression).
+	ErrCanaryCheck = errors.New("check is a canary")
+	// ErrInvalidThreshold is returned if a threshold of a check is out of range (see DBPoolCheck).
+	ErrInvalidThreshold = errors.New("invalid threshold")
+	// ErrInvalidDependencyLimit is returned if the concurrency limit of a dependency is invalid
+	// (see WithDependencyConcurrency).
+	ErrInvalidDependencyLimit = errors.New("invalid dependency limit")
+	// ErrUnknownDependency is returned if the dependency of a check has no concurrency limit, while limits are
+	// configured for other dependencies (see Check.Dependency and WithDependencyConcurrency).
+	ErrUnknownDependency = errors.New("unknown dependency")
+	// ErrReservedCheckName is returned if a check is registered with the name of the self-check while the
+	// self-check is enabled (see WithSelfCheck and SelfCheckName).
+	ErrReservedCheckName = errors.New("reserved check name")
 )
 
 func newChecker(cfg checkerConfig) *defaultChecker {
+	if cfg.aggregator == nil {
+		cfg.aggregator = WorstStatusAggregator
+	}
+
+	if cfg.aggregationWindow > 0 && cfg.historySize == 0 {
+		cfg.historySize = defaultWindowedAggregationHistorySize
+	}
+
 	checkState := map[string]CheckState{}
 	for _, check := range cfg.checks {
 		checkState[check.Name] = CheckState{Status: StatusUnknown}
 	}
 
 	checker := defaultChecker{
-		cfg:   cfg,
-		state: State{Status: StatusUnknown, CheckState: checkState},
+		cfg:              cfg,
+		state:            State{Status: StatusUnknown, CheckState: checkState},
+		listenerThrottle: newListenerThrottle(cfg.listenerCoolDown, cfg.clock),
+		stateSaver:       newStateSaver(cfg.stateStore),
+		statePublisher:   newStatePublisher(cfg.transitionPublishers),
+		transitionBatch:  newTransitionBatch(cfg.transitionWindow, cfg.transitionsListener, cfg.clock),
+		disabledChecks:   map[string]bool{},
+		canaries:         map[string]bool{},
+		history:          newHistoryBuffer(cfg.historySize),
+		inFlight:         map[string]context.CancelCauseFunc{},
+		softDegraded:     map[string]string{},
+		pauseStates:      map[string]*pauseState{},
+		errorRates:       map[string]*errorRateWindow{},
+		cacheCounters:    map[string]*cacheCounters{},
+		workers:          newWorkerPool(cfg.workerPoolSize),
+		semaphores:       newDependencySemaphores(cfg.dependencyLimits),
+	}
+
+	tickersDone := make(chan struct{})
+	checker.tickersDone.Store(&tickersDone)
+
+	if cfg.selfCheckTarget != nil {
+		cfg.selfCheckTarget.checker.Store(&checker)
+	}
+
+	for _, check := range cfg.checks {
+		if isPeriodicCheck(check) {
+			checker.pauseStates[check.Name] = &pauseState{resumed: make(chan struct{}, 1)}
+		} else {
+			checker.cacheCounters[check.Name] = &cacheCounters{}
+		}
+
+		if check.errorRate != nil {
+			checker.errorRates[check.Name] = &errorRateWindow{}
+		}
+
+		if check.canary {
+			checker.canaries[check.Name] = true
+		}
 	}
 
+	checker.publishSnapshot()
+
 	if !cfg.autostartDisabled {
 		checker.Start()
 	}
@@ -219,10 +712,20 @@ func (ck *defaultChecker) Start() {
 	ck.mtx.Lock()
 
 	if !ck.started {
-		ctx, cancel := context.WithCancel(context.Background())
+		ctx, cancel := context.WithCancelCause(context.Background())
 		ck.cancel = cancel
 
 		ck.started = true
+		startedAt := ck.cfg.clock.Now()
+		ck.startedAt.Store(&startedAt)
+		ck.restoreState(ctx)
+
+		for _, pause := range ck.pauseStates {
+			pause.contiguousPanics = 0
+			pause.quarantined.Store(false)
+		}
+
+		ck.scheduleStateUpdate(ctx, ck.cfg.unknownTimeout)
 		defer ck.startPeriodicChecks(ctx)
 
 		// We run the initial check execution in a separate goroutine so that server startup is not blocked in case of
@@ -238,14 +741,21 @@ func (ck *defaultChecker) Start() {
 
 // Stop implements Checker.Stop. Please refer to Checker.Stop for more information.
 func (ck *defaultChecker) Stop() {
-	ck.cancel()
+	ck.cancel(ErrCheckerStopped)
 	ck.wg.Wait()
+	ck.listenerThrottle.stop()
+	ck.transitionBatch.flush()
+	ck.statePublisher.wait()
+	ck.stopTickers()
 
 	ck.mtx.Lock()
-	defer ck.mtx.Unlock()
-
 	ck.started = false
+	ck.startedAt.Store(nil)
 	ck.periodicCheckCount = 0
+	states := maps.Clone(ck.state.CheckState)
+	ck.mtx.Unlock()
+
+	ck.stateSaver.flush(context.Background(), states)
 }
 
 // GetRunningPeriodicCheckCount implements Checker.GetRunningPeriodicCheckCount.
@@ -263,12 +773,127 @@ func (ck *defaultChecker) IsStarted() bool {
 	return ck.started
 }
 
+// Drain implements Drainer.Drain. Please refer to Drainer.Drain for more information.
+func (ck *defaultChecker) Drain() {
+	ck.draining.Store(true)
+}
+
+// Undrain implements Drainer.Undrain. Please refer to Drainer.Undrain for more information.
+func (ck *defaultChecker) Undrain() {
+	ck.draining.Store(false)
+}
+
+// IsDraining implements Drainer.IsDraining. Please refer to Drainer.IsDraining for more information.
+func (ck *defaultChecker) IsDraining() bool {
+	return ck.draining.Load()
+}
+
+// History implements HistoryProvider.History. Please refer to HistoryProvider.History for more information.
+func (ck *defaultChecker) History() History {
+	return ck.history.snapshot()
+}
+
+// CancelCheck implements CheckCanceler.CancelCheck. Please refer to CheckCanceler.CancelCheck for more information.
+func (ck *defaultChecker) CancelCheck(name string) error {
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+	}
+
+	ck.inFlightMtx.Lock()
+	defer ck.inFlightMtx.Unlock()
+
+	cancel, ok := ck.inFlight[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCheckNotRunning, name)
+	}
+
+	cancel(ErrCheckCanceled)
+
+	return nil
+}
+
+// PauseCheck implements CheckPauser.PauseCheck. Please refer to CheckPauser.PauseCheck for more information.
+func (ck *defaultChecker) PauseCheck(name string) error {
+	pause, err := ck.pauseStateOf(name)
+	if err != nil {
+		return err
+	}
+
+	pause.paused.Store(true)
+
+	return nil
+}
+
+// ResumeCheck implements CheckPauser.ResumeCheck. Please refer to CheckPauser.ResumeCheck for more information.
+func (ck *defaultChecker) ResumeCheck(name string) error {
+	pause, err := ck.pauseStateOf(name)
+	if err != nil {
+		return err
+	}
+
+	if pause.paused.CompareAndSwap(true, false) {
+		select {
+		case pause.resumed <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (ck *defaultChecker) pauseStateOf(name string) (*pauseState, error) {
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+	}
+
+	pause, ok := ck.pauseStates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCheckNotPeriodic, name)
+	}
+
+	return pause, nil
+}
+
+func (ck *defaultChecker) isPaused(name string) bool {
+	pause, ok := ck.pauseStates[name]
+	return ok && pause.paused.Load()
+}
+
+// ForceStatus implements StatusForcer.ForceStatus. Please refer to StatusForcer.ForceStatus for more information.
+func (ck *defaultChecker) ForceStatus(status AvailabilityStatus, reason string) {
+	ck.forcedStatus.Store(&ForcedStatus{Status: status, Reason: reason, Since: ck.cfg.clock.Now().UTC()})
+}
+
+// ClearForcedStatus implements StatusForcer.ClearForcedStatus.
+// Please refer to StatusForcer.ClearForcedStatus for more information.
+func (ck *defaultChecker) ClearForcedStatus() {
+	ck.forcedStatus.Store(nil)
+}
+
+// LastCheckState implements StateReader.LastCheckState. Please refer to StateReader.LastCheckState for more information.
+func (ck *defaultChecker) LastCheckState(name string) (CheckState, bool) {
+	state, ok := ck.snapshot.Load().CheckState[name]
+	return state, ok
+}
+
+// publishSnapshot publishes a copy of the current state for lock-free reads (see LastCheckState).
+// The caller must hold the mutex lock (or have exclusive access to the checker).
+func (ck *defaultChecker) publishSnapshot() {
+	snapshot := ck.state
+	snapshot.CheckState = make(map[string]CheckState, len(ck.state.CheckState))
+	for name, state := range ck.state.CheckState {
+		snapshot.CheckState[name] = state
+	}
+
+	ck.snapshot.Store(&snapshot)
+}
+
 // Check implements Checker.Check. Please refer to Checker.Check for more information.
 func (ck *defaultChecker) Check(ctx context.Context) Result {
 	ck.mtx.Lock()
 	defer ck.mtx.Unlock()
 
-	ctx, cancel := context.WithTimeout(ctx, ck.cfg.timeout)
+	ctx, cancel := context.WithTimeoutCause(ctx, ck.cfg.timeout, ErrGlobalTimeout)
 	defer cancel()
 
 	ck.runSynchronousChecks(ctx)
@@ -277,36 +902,68 @@ func (ck *defaultChecker) Check(ctx context.Context) Result {
 }
 
 func (ck *defaultChecker) runSynchronousChecks(ctx context.Context) {
-	var (
-		numChecks          = len(ck.cfg.checks)
-		numInitiatedChecks = 0
-		resChan            = make(chan checkResult, numChecks)
-	)
+	scratch := getEvaluationScratch()
+	defer putEvaluationScratch(scratch)
+
+	checks := scratch.checks
 
 	for _, check := range ck.cfg.checks {
 		if !isPeriodicCheck(check) {
 			checkState := ck.state.CheckState[check.Name]
 
-			if !isCacheExpired(ck.cfg.cacheTTL, &checkState) {
+			if !ck.refreshEnabled(ctx, check) {
 				continue
 			}
 
-			numInitiatedChecks++
+			now := ck.cfg.clock.Now()
+			if !isCacheExpired(ck.cfg.cacheTTL, &checkState, now) {
+				ck.recordCacheRead(ctx, check, true, now)
+				continue
+			}
 
-			go func() {
-				withCheckContext(ctx, check, func(ctx context.Context) {
-					_, checkState := executeCheck(ctx, &ck.cfg, check, checkState)
-					resChan <- checkResult{check.Name, checkState}
-				})
-			}()
+			ck.recordCacheRead(ctx, check, false, now)
+
+			checks = append(checks, check)
+		}
+	}
+
+	// A new cycle (with its own cycle ID) is only started if there is at least one check to execute.
+	if len(checks) > 0 {
+		ctx = withCycleID(ctx, ck.cfg.idGenerator())
+
+		if ck.cfg.targetDeduplication {
+			ctx = withTargetProbes(ctx)
 		}
 	}
 
-	results := make([]checkResult, 0, numInitiatedChecks)
+	var (
+		numInitiatedChecks = len(checks)
+		resChan            = make(chan checkResult, numInitiatedChecks)
+		groupContexts      = newGroupContexts(ctx, ck.cfg.groupBudgets)
+	)
+
+	defer groupContexts.cancel()
+
+	for _, check := range checks {
+		checkState := ck.state.CheckState[check.Name]
+		checkCtx := groupContexts.get(check.group)
+
+		go func() {
+			ck.withCheckContext(checkCtx, check, func(ctx context.Context) {
+				_, checkState := ck.executeCheck(ctx, check, checkState)
+				resChan <- checkResult{check.Name, checkState}
+			})
+		}()
+	}
+
+	results := scratch.results
 	for len(results) < numInitiatedChecks {
 		results = append(results, <-resChan)
 	}
 
+	// The (possibly grown) buffers are handed back to the scratch object, so that they can be reused.
+	scratch.checks, scratch.results = checks, results
+
 	ck.updateState(ctx, results...)
 }
 
@@ -339,54 +996,242 @@ func (ck *defaultChecker) startPeriodicChecks(ctx context.Context) {
 					}
 				}
 
-				for {
-					withCheckContext(ctx, check, func(ctx context.Context) {
-						ck.mtx.Lock()
-						checkState := ck.state.CheckState[check.Name]
-						ck.mtx.Unlock()
-
-						// ATTENTION: This function may panic, if panic handling is disabled
-						// 	via "check.DisablePanicRecovery".
-						//
-						// ATTENTION: executeCheck is executed with its own copy of the checks
-						// 	state (see checkState above). This means that if there is a global status
-						//	listener that is configured by the user with health.WithStatusListener,
-						//	and that global status listener changes this checks state as long as
-						//  executeCheck is running, the modifications made by the global listener
-						//  will be lost after the function completes, since we overwrite the state
-						//  below using updateState.
-						//  This means that global listeners should not change the checks state
-						//  or accept losing their updates. This will be the case especially for
-						//  long-running checks. Hence, the checkState is read-only for interceptors.
-						ctx, checkState = executeCheck(ctx, &ck.cfg, check, checkState)
-
-						ck.mtx.Lock()
-						ck.updateState(ctx, checkResult{check.Name, checkState})
-						ck.mtx.Unlock()
-					})
-
-					if waitForStopSignal(ctx, check.updateInterval) {
-						return
-					}
-				}
+				ck.schedulePeriodicCheck(ctx, check)
 			}()
 		}
 	}
 }
 
+// schedulePeriodicCheck evaluates a periodic check on a fixed schedule until the context is cancelled.
+// If the previous evaluation is still running when the next one is due, the next one is skipped,
+// so that evaluations of the same check never overlap (see CheckState.SkippedEvaluations).
+func (ck *defaultChecker) schedulePeriodicCheck(ctx context.Context, check *Check) {
+	var running atomic.Bool
+
+	evaluate := func() {
+		ck.wg.Add(1)
+		running.Store(true)
+
+		go func() {
+			defer ck.wg.Done()
+			defer running.Store(false)
+
+			ck.evaluatePeriodicCheck(ctx, check)
+		}()
+	}
+
+	ticker := time.NewTicker(check.updateInterval)
+	defer ticker.Stop()
+
+	pause := ck.pauseStates[check.Name]
+	if !pause.paused.Load() {
+		evaluate()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if pause.quarantined.Load() {
+				ck.mtx.Lock()
+				ck.periodicCheckCount--
+				ck.mtx.Unlock()
+
+				return
+			}
+
+			if pause.paused.Load() {
+				continue
+			}
+
+			if running.Load() {
+				ck.recordSkippedEvaluation(check)
+				continue
+			}
+
+			evaluate()
+		case <-pause.resumed:
+			if !pause.paused.Load() && !running.Load() && !pause.quarantined.Load() {
+				evaluate()
+			}
+		}
+	}
+}
+
+func (ck *defaultChecker) evaluatePeriodicCheck(ctx context.Context, check *Check) {
+	enabled := ck.isEnabled(ctx, check)
+	ck.pauseStates[check.Name].disabled.Store(!enabled)
+
+	if !enabled {
+		ck.mtx.Lock()
+		if !ck.disabledChecks[check.Name] {
+			ck.disabledChecks[check.Name] = true
+			ck.updateState(ctx)
+		}
+		ck.mtx.Unlock()
+
+		return
+	}
+
+	// Each periodic evaluation is a cycle of its own. Group budgets only apply to synchronous checks
+	// (see WithGroupBudget), since periodic checks of a group are not evaluated together.
+	ck.withCheckContext(withCycleID(ctx, ck.cfg.idGenerator()), check, func(ctx context.Context) {
+		ck.mtx.Lock()
+		delete(ck.disabledChecks, check.Name)
+		checkState := ck.state.CheckState[check.Name]
+		ck.mtx.Unlock()
+
+		// ATTENTION: This function may panic, if panic handling is disabled
+		// 	via "check.DisablePanicRecovery".
+		//
+		// ATTENTION: executeCheck is executed with its own copy of the checks
+		// 	state (see checkState above). This means that if there is a global status
+		//	listener that is configured by the user with health.WithStatusListener,
+		//	and that global status listener changes this checks state as long as
+		//  executeCheck is running, the modifications made by the global listener
+		//  will be lost after the function completes, since we overwrite the state
+		//  below using updateState.
+		//  This means that global listeners should not change the checks state
+		//  or accept losing their updates. This will be the case especially for
+		//  long-running checks. Hence, the checkState is read-only for interceptors.
+		ctx, checkState = ck.executeCheck(ctx, check, checkState)
+		checkState = ck.applyPanicQuarantine(check, checkState)
+
+		ck.mtx.Lock()
+		// Skipped evaluations are recorded while this evaluation is running, so they must be retained.
+		current := ck.state.CheckState[check.Name]
+		checkState.SkippedEvaluations = current.SkippedEvaluations
+		checkState.LastSkippedAt = current.LastSkippedAt
+		ck.updateState(ctx, checkResult{check.Name, checkState})
+		ck.mtx.Unlock()
+	})
+}
+
+// recordSkippedEvaluation records that a scheduled evaluation of a check was skipped,
+// because the previous evaluation was still running.
+func (ck *defaultChecker) recordSkippedEvaluation(check *Check) {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	state := ck.state.CheckState[check.Name]
+	state.SkippedEvaluations++
+	state.LastSkippedAt = ck.cfg.clock.Now().UTC()
+	ck.state.CheckState[check.Name] = state
+
+	ck.publishSnapshot()
+}
+
 func (ck *defaultChecker) updateState(ctx context.Context, updates ...checkResult) {
+	now := ck.cfg.clock.Now().UTC()
+	checkStatusChanged := false
+	previousStatuses := ck.checkStatuses()
+
 	for _, update := range updates {
+		if update.newState.Status != ck.state.CheckState[update.checkName].Status {
+			update.newState.LastStatusChangeAt = now
+			checkStatusChanged = true
+		}
+
 		ck.state.CheckState[update.checkName] = update.newState
 	}
 
-	oldStatus := ck.state.Status
-	ck.state.Status = aggregateStatus(ck.state.CheckState)
+	if ck.applyUnknownTimeout(ctx, now) {
+		checkStatusChanged = true
+	}
+
+	ck.applySoftDependencies(now)
+
+	for _, update := range updates {
+		state := ck.state.CheckState[update.checkName]
+		ck.history.recordCheck(update.checkName, HistoryEntry{
+			Timestamp: state.LastCheckedAt,
+			Status:    state.Status,
+		})
+	}
+
+	if cycleID := CycleIDFromContext(ctx); cycleID != "" {
+		ck.state.CycleID = cycleID
+	}
+
+	oldStatus := ck.aggregate(now)
+	ck.history.recordAggregate(HistoryEntry{Timestamp: now, Status: ck.state.Status})
+	ck.recordReadiness(ctx, now)
+	ck.publishSnapshot()
 
-	if oldStatus != ck.state.Status && ck.cfg.statusChangeListener != nil {
-		ck.cfg.statusChangeListener(ctx, ck.state)
+	if checkStatusChanged {
+		ck.saveState(ctx)
+	}
+
+	if oldStatus != ck.state.Status {
+		state := ck.state
+		if listenersDeferred(ctx) {
+			// The listeners are notified after further state changes may have happened.
+			state = copyState(state)
+		}
+
+		if listener := ck.cfg.statusChangeListener; listener != nil {
+			notifyListener(ctx, func(ctx context.Context) { listener(ctx, state) })
+		}
+
+		ck.statePublisher.publish(ctx, ck.state)
+	}
+
+	ck.notifyTransitions(ctx, previousStatuses)
+}
+
+// applySoftDependencies degrades all checks that are up while one of their soft dependencies is down
+// (see WithSoftDependsOn) and restores checks whose soft dependencies are no longer down.
+// The caller must hold the mutex lock.
+func (ck *defaultChecker) applySoftDependencies(now time.Time) {
+	for _, check := range ck.cfg.checks {
+		if len(check.softDependsOn) == 0 {
+			continue
+		}
+
+		state := ck.state.CheckState[check.Name]
+		previousStatus := state.Status
+		reason, degraded := ck.softDegraded[check.Name]
+
+		switch {
+		case ck.isSoftDependencyDown(check) && state.Status == StatusUp:
+			// The original reason is retained, so that it can be restored later.
+			ck.softDegraded[check.Name] = state.Reason
+			state.Status = StatusDegraded
+			state.Reason = ReasonDependencyDown
+		case degraded && !ck.isSoftDependencyDown(check):
+			delete(ck.softDegraded, check.Name)
+
+			if state.Status == StatusDegraded && state.Reason == ReasonDependencyDown {
+				state.Status = StatusUp
+				state.Reason = reason
+			}
+		default:
+			continue
+		}
+
+		if state.Status != previousStatus {
+			state.LastStatusChangeAt = now
+		}
+
+		ck.state.CheckState[check.Name] = state
 	}
 }
 
+func (ck *defaultChecker) isSoftDependencyDown(check *Check) bool {
+	for _, name := range check.softDependsOn {
+		if ck.disabledChecks[name] || ck.canaries[name] {
+			continue
+		}
+
+		if state, ok := ck.state.CheckState[name]; ok && state.Status == StatusDown {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (ck *defaultChecker) mapStateToCheckerResult() Result {
 	var (
 		checkResults map[string]CheckResult
@@ -397,22 +1242,198 @@ func (ck *defaultChecker) mapStateToCheckerResult() Result {
 	if numChecks > 0 && !ck.cfg.detailsDisabled {
 		checkResults = make(map[string]CheckResult, numChecks)
 		for _, check := range ck.cfg.checks {
+			if ck.disabledChecks[check.Name] {
+				continue
+			}
+
 			checkState := ck.state.CheckState[check.Name]
 			checkResults[check.Name] = CheckResult{
-				Status:    checkState.Status,
-				Error:     checkState.Result,
-				Timestamp: checkState.LastCheckedAt,
+				Status:             checkState.Status,
+				Error:              checkState.Result,
+				Reason:             checkState.Reason,
+				Timestamp:          checkState.LastCheckedAt,
+				SkippedEvaluations: checkState.SkippedEvaluations,
+				SubResults:         checkState.SubResults,
+				Paused:             ck.isPaused(check.Name),
+				Deviating:          check.expectedStatus != "" && checkState.Status != check.expectedStatus,
+				Canary:             ck.canaries[check.Name],
+				StartedAt:          checkState.StartedAt,
+				FinishedAt:         checkState.FinishedAt,
+				Duration:           checkState.Duration(),
 			}
 		}
 	}
 
+	var counts *StatusCounts
+	if ck.cfg.statusCountsEnabled {
+		counts = countStatuses(ck.participatingCheckStates())
+	}
+
+	// The readiness expression, the minimum uptime and the draining mode are applied by the readiness handler
+	// (see NewReadinessHandler).
+	now := ck.cfg.clock.Now()
+	warmingUp := ck.isWarmingUp(now)
+	draining := ck.draining.Load()
+
+	var readiness AvailabilityStatus
+	if ck.cfg.readiness != nil {
+		readiness = ck.cfg.readiness(ck.aggregationCheckStates(now))
+	}
+
+	forced := ck.forcedStatus.Load()
+	if forced != nil {
+		status = forced.Status
+	}
+
+	var downSince *time.Time
+	if !ck.state.DownSince.IsZero() {
+		since := ck.state.DownSince
+		downSince = &since
+	}
+
+	var stats *Stats
+	if ck.cfg.statsInResult {
+		s := ck.Stats()
+		stats = &s
+	}
+
 	refreshInfoMap(ck.cfg.info, ck.cfg.infoFuncs)
 
-	return Result{Status: status, Details: checkResults, Info: ck.cfg.info}
+	return Result{
+		Status:     status,
+		Details:    checkResults,
+		Info:       ck.cfg.info,
+		Counts:     counts,
+		Draining:   draining,
+		WarmingUp:  warmingUp,
+		DownSince:  downSince,
+		Forced:     forced,
+		CycleID:    ck.state.CycleID,
+		IncidentID: ck.state.IncidentID,
+		Stats:      stats,
+		ProbeHints: ck.cfg.effectiveProbeHints(),
+		readiness:  readiness,
+	}
+}
+
+// refreshEnabled consults the partition and the FlagProvider (if any) and records whether the check is currently enabled.
+// The caller must hold the mutex lock.
+func (ck *defaultChecker) refreshEnabled(ctx context.Context, check *Check) bool {
+	if ck.isEnabled(ctx, check) {
+		delete(ck.disabledChecks, check.Name)
+		return true
+	}
+
+	ck.disabledChecks[check.Name] = true
+
+	return false
+}
+
+func (ck *defaultChecker) isEnabled(ctx context.Context, check *Check) bool {
+	return ck.isAssignedToPartition(check) && ck.isEnabledByFlagProvider(ctx, check)
+}
+
+func (ck *defaultChecker) isEnabledByFlagProvider(ctx context.Context, check *Check) bool {
+	return ck.cfg.flagProvider == nil || ck.cfg.flagProvider.IsEnabled(ctx, check.Name)
+}
+
+// isWarmingUp returns true, if the minimum uptime of the Checker has not elapsed since it was started at the
+// given time (see WithMinUptime). A Checker that is not started is warming up.
+func (ck *defaultChecker) isWarmingUp(now time.Time) bool {
+	if ck.cfg.minUptime <= 0 {
+		return false
+	}
+
+	startedAt := ck.startedAt.Load()
+
+	return startedAt == nil || now.Sub(*startedAt) < ck.cfg.minUptime
 }
 
-func isCacheExpired(cacheDuration time.Duration, state *CheckState) bool {
-	return state.LastCheckedAt.IsZero() || state.LastCheckedAt.Before(time.Now().Add(-cacheDuration))
+// scheduleStateUpdate updates the state of the Checker once the given delay elapsed after it was started, so that
+// time-dependent statuses take effect even if the state is not updated otherwise (see WithUnknownTimeout).
+// It does nothing if the delay is not positive.
+func (ck *defaultChecker) scheduleStateUpdate(ctx context.Context, delay time.Duration) {
+	if delay <= 0 {
+		return
+	}
+
+	ck.wg.Add(1)
+
+	go func() {
+		defer ck.wg.Done()
+
+		if waitForStopSignal(ctx, delay) {
+			return
+		}
+
+		ck.mtx.Lock()
+		defer ck.mtx.Unlock()
+
+		ck.updateState(ctx)
+	}()
+}
+
+// aggregate updates the aggregated health status and the times and the incident that depend on it.
+// It returns the previous aggregated status. The caller must hold the mutex lock.
+func (ck *defaultChecker) aggregate(now time.Time) AvailabilityStatus {
+	oldStatus := ck.state.Status
+	ck.state.Status = ck.cfg.aggregator(ck.aggregationCheckStates(now))
+	ck.state.DownSince = nextDownSince(ck.state.DownSince, oldStatus, ck.state.Status, now)
+	ck.state.IncidentID = nextIncidentID(ck.state.IncidentID, ck.state.DownSince, ck.cfg.idGenerator)
+
+	if oldStatus != ck.state.Status {
+		ck.state.LastStatusChangeAt = now
+
+		ck.state.UpSince = time.Time{}
+		if ck.state.Status == StatusUp {
+			ck.state.UpSince = now
+		}
+	}
+
+	return oldStatus
+}
+
+// participatingCheckStates returns the states of all checks that contribute to the aggregated
+// health status, i.e., all checks that are neither disabled nor canaries. The returned map is a copy, since it is
+// passed to the aggregator (see WithAggregator). The caller must hold the mutex lock.
+func (ck *defaultChecker) participatingCheckStates() map[string]CheckState {
+	if len(ck.disabledChecks) == 0 && len(ck.canaries) == 0 {
+		return maps.Clone(ck.state.CheckState)
+	}
+
+	states := make(map[string]CheckState, len(ck.state.CheckState))
+	for name, state := range ck.state.CheckState {
+		if !ck.disabledChecks[name] && !ck.canaries[name] {
+			states[name] = state
+		}
+	}
+
+	return states
+}
+
+// aggregationCheckStates returns the states of all participating checks as they are considered
+// for the aggregated health status. The caller must hold the mutex lock.
+func (ck *defaultChecker) aggregationCheckStates(now time.Time) map[string]CheckState {
+	states := ck.participatingCheckStates()
+	if ck.cfg.aggregationWindow <= 0 {
+		return states
+	}
+
+	// With windowed aggregation, the status of a check is determined by its history (see WithWindowedAggregation).
+	history := ck.history.snapshot()
+	since := now.Add(-ck.cfg.aggregationWindow)
+
+	windowed := make(map[string]CheckState, len(states))
+	for name, state := range states {
+		state.Status = windowedStatus(history.Checks[name], state.Status, since)
+		windowed[name] = state
+	}
+
+	return windowed
+}
+
+func isCacheExpired(cacheDuration time.Duration, state *CheckState, now time.Time) bool {
+	return state.LastCheckedAt.IsZero() || state.LastCheckedAt.Before(now.Add(-cacheDuration))
 }
 
 func isPeriodicCheck(check *Check) bool {
@@ -428,25 +1449,41 @@ func waitForStopSignal(ctx context.Context, waitTime time.Duration) bool {
 	}
 }
 
-func withCheckContext(ctx context.Context, check *Check, f func(checkCtx context.Context)) {
+func (ck *defaultChecker) withCheckContext(ctx context.Context, check *Check, f func(checkCtx context.Context)) {
 	cancel := func() {}
-	if check.Timeout > 0 {
-		ctx, cancel = context.WithTimeout(ctx, check.Timeout)
+	if timeout := check.effectiveTimeout(); timeout > 0 {
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, ErrCheckTimeout)
 	}
 	defer cancel()
+
+	// The evaluation is registered as in-flight, so that it can be cancelled individually (see CancelCheck).
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	defer cancelCause(nil)
+
+	ck.inFlightMtx.Lock()
+	ck.inFlight[check.Name] = cancelCause
+	ck.inFlightMtx.Unlock()
+
+	defer func() {
+		ck.inFlightMtx.Lock()
+		delete(ck.inFlight, check.Name)
+		ck.inFlightMtx.Unlock()
+	}()
+
 	f(ctx)
 }
 
-func executeCheck(
+func (ck *defaultChecker) executeCheck(
 	ctx context.Context,
-	cfg *checkerConfig,
 	check *Check,
 	oldState CheckState,
 ) (context.Context, CheckState) {
+	cfg := &ck.cfg
+
 	newState := oldState
 
 	if newState.FirstCheckStartedAt.IsZero() {
-		newState.FirstCheckStartedAt = time.Now().UTC()
+		newState.FirstCheckStartedAt = cfg.clock.Now().UTC()
 	}
 
 	// We copy explicitly to not affect the underlying array of the slices as a side effect.
@@ -456,34 +1493,76 @@ func executeCheck(
 	interceptors = append(interceptors, cfg.interceptors...)
 	interceptors = append(interceptors, check.Interceptors...)
 
+	ctx = withObservabilityTags(ctx, check.tags)
+
 	newState = withInterceptors(interceptors, func(ctx context.Context, _ string, state CheckState) CheckState {
-		checkFuncResult := executeCheckFunc(ctx, check)
-		return createNextCheckState(checkFuncResult, check, state)
+		var startedAt time.Time
+
+		outcome := probeTarget(ctx, check, func() checkOutcome {
+			return ck.runWithDependencyLimit(ctx, check, func() checkOutcome {
+				return ck.workers.run(ctx, func() checkOutcome {
+					startedAt = cfg.clock.Now().UTC()
+					return executeCheckFuncWithRetries(ctx, check)
+				})
+			})
+		})
+		now := cfg.clock.Now().UTC()
+
+		if startedAt.IsZero() {
+			// The evaluation timed out while waiting for a worker (see WithWorkerPool) or its dependency
+			// or reports the probe of another check (see WithTargetDeduplication).
+			startedAt = now
+		}
+
+		state = createNextCheckState(truncateError(outcome.err, cfg.maxErrorLength), check, state, now)
+		state.StartedAt, state.FinishedAt = startedAt, now
+		state.SubResults = newSubCheckResults(outcome.subResults, now, cfg.maxErrorLength)
+
+		if check.errorRate != nil {
+			state = applyErrorRate(state, check.errorRate, ck.errorRates[check.Name], now)
+		}
+
+		if check.expectedStatus != "" {
+			state.Evaluations++
+			if state.Status != check.expectedStatus {
+				state.Deviations++
+			}
+		}
+
+		return state
 	})(ctx, check.Name, newState)
 
-	if check.StatusListener != nil && oldState.Status != newState.Status {
-		check.StatusListener(ctx, check.Name, newState)
+	if cfg.resultValidator != nil {
+		validState, err := cfg.resultValidator(newState)
+		if err != nil {
+			slogctx.Warn(ctx, "Discarding invalid health check result", "check", check.Name, "error", err)
+			validState = oldState
+		}
+
+		newState = validState
+	}
+
+	if oldState.Status != newState.Status {
+		ck.notifyStatusListener(ctx, check, newState)
 	}
 
 	return ctx, newState
 }
 
-func executeCheckFunc(ctx context.Context, check *Check) error {
+func executeCheckFunc(ctx context.Context, check *Check) checkOutcome {
 	// If this channel is not bounded, we may have a goroutine leak (e.g., when ctx.Done signals first then
 	// sending the check result into the channel will block forever).
-	res := make(chan error, 1)
+	res := make(chan checkOutcome, 1)
 
 	go func() {
 		defer func() {
 			if !check.DisablePanicRecovery {
 				if r := recover(); r != nil {
 					err, ok := r.(error)
-					if ok {
-						res <- err
-					} else {
+					if !ok {
 						err = fmt.Errorf("%v", r)
-						res <- err
 					}
+					res <- checkOutcome{err: ErrorWithReason(ReasonPanic, err), panicStack: debug.Stack()}
 					if check.PanicHandler != nil {
 						check.PanicHandler(ctx, err)
 					}
@@ -491,21 +1570,103 @@ func executeCheckFunc(ctx context.Context, check *Check) error {
 			}
 		}()
 
-		res <- check.Check(ctx)
+		res <- runCheckFunc(ctx, check)
 	}()
 
 	select {
-	case err := <-res:
-		return err
+	case outcome := <-res:
+		if ctx.Err() != nil && (errors.Is(outcome.err, context.Canceled) || errors.Is(outcome.err, context.DeadlineExceeded)) {
+			// The check function reported the cancellation of its context, so the cause is reported instead.
+			outcome.err = cancellationError(ctx)
+		}
+
+		return outcome
 	case <-ctx.Done():
+		return checkOutcome{err: cancellationError(ctx)}
+	}
+}
+
+// cancellationError returns the error for an evaluation whose context is done, based on the cause of the
+// cancellation: a cancellation by CheckCanceler.CancelCheck, the timeout of the Checker, a shutdown of the Checker,
+// a cancellation by the caller of Checker.Check or (otherwise) the timeout of the check.
+func cancellationError(ctx context.Context) error {
+	cause := context.Cause(ctx)
+
+	switch {
+	case errors.Is(cause, ErrCheckCanceled), errors.Is(cause, ErrGlobalTimeout), errors.Is(cause, ErrCheckerStopped):
+		return cause
+	case errors.Is(cause, context.Canceled):
+		return ErrRequestCanceled
+	default:
 		return ErrCheckTimeout
 	}
 }
 
-func createNextCheckState(result error, check *Check, state CheckState) CheckState {
-	now := time.Now().UTC()
+func runCheckFunc(ctx context.Context, check *Check) checkOutcome {
+	if len(check.pipeline) == 0 {
+		return runCheckFuncDirectly(ctx, check)
+	}
+
+	var outcome checkOutcome
+
+	// The sub-results are retained from the last execution of the check function by the pipeline (if any).
+	err := withPipeline(check.pipeline, func(ctx context.Context) error {
+		outcome = runCheckFuncDirectly(ctx, check)
+		return outcome.err
+	})(ctx)
+
+	return checkOutcome{err: err, subResults: outcome.subResults}
+}
+
+func runCheckFuncDirectly(ctx context.Context, check *Check) checkOutcome {
+	switch {
+	case check.Check == nil && check.Value != nil:
+		return checkOutcome{err: runValueFunc(ctx, check)}
+	case check.Check == nil && check.SubChecks != nil:
+		subResults := check.SubChecks(ctx)
+		return checkOutcome{err: rollUpSubResults(subResults), subResults: subResults}
+	default:
+		return checkOutcome{err: check.Check(ctx)}
+	}
+}
+
+func runValueFunc(ctx context.Context, check *Check) error {
+	value, err := check.Value(ctx)
+	if err != nil || check.thresholds == nil {
+		return err
+	}
+
+	switch {
+	case value >= check.thresholds.crit:
+		return ErrorWithReason(ReasonThresholdExceeded,
+			fmt.Errorf("value %v exceeds critical threshold %v", value, check.thresholds.crit))
+	case value >= check.thresholds.warn:
+		return ErrorWithReason(ReasonThresholdExceeded,
+			fmt.Errorf("value %v exceeds warning threshold %v: %w", value, check.thresholds.warn, ErrDegraded))
+	default:
+		return nil
+	}
+}
+
+func createNextCheckState(result error, check *Check, state CheckState, now time.Time) CheckState {
+	if result != nil && check.activeWindow != nil && !check.activeWindow.Contains(now) {
+		// Outside the active window of a check, failures do not count. The error is still reported,
+		// but the check is considered to be available. The thresholds of the check are reset, so that
+		// failures only count once the window opened (the time in error is measured from the first
+		// evaluation in the window on).
+		state.Result = result
+		state.Reason = ReasonOutsideActiveWindow
+		state.LastCheckedAt = now
+		state.LastFailureAt = now
+		state.ContiguousFails = 0
+		state.FirstCheckStartedAt = time.Time{}
+		state.Status = StatusUp
+
+		return state
+	}
 
 	state.Result = result
+	state.Reason = ReasonOf(result)
 	state.LastCheckedAt = now
 
 	if state.Result == nil {
@@ -516,23 +1677,32 @@ func createNextCheckState(result error, check *Check, state CheckState) CheckSta
 		state.LastFailureAt = now
 	}
 
-	state.Status = evaluateCheckStatus(&state, check.MaxTimeInError, check.MaxContiguousFails)
+	state.Status = evaluateCheckStatus(&state, check.MaxTimeInError, check.MaxContiguousFails, now)
 
 	return state
 }
 
-func evaluateCheckStatus(state *CheckState, maxTimeInError time.Duration, maxFails uint) AvailabilityStatus {
+func evaluateCheckStatus(
+	state *CheckState,
+	maxTimeInError time.Duration,
+	maxFails uint,
+	now time.Time,
+) AvailabilityStatus {
 	if state.LastCheckedAt.IsZero() {
 		return StatusUnknown
 	} else if state.Result != nil {
-		maxTimeInErrorSinceStartPassed := !state.FirstCheckStartedAt.Add(maxTimeInError).After(time.Now())
+		maxTimeInErrorSinceStartPassed := !state.FirstCheckStartedAt.Add(maxTimeInError).After(now)
 		maxTimeInErrorSinceLastSuccessPassed := state.LastSuccessAt.IsZero() ||
-			!state.LastSuccessAt.Add(maxTimeInError).After(time.Now())
+			!state.LastSuccessAt.Add(maxTimeInError).After(now)
 
 		timeInErrorThresholdCrossed := maxTimeInErrorSinceStartPassed && maxTimeInErrorSinceLastSuccessPassed
 		failCountThresholdCrossed := state.ContiguousFails >= maxFails
 
 		if failCountThresholdCrossed && timeInErrorThresholdCrossed {
+			if errors.Is(state.Result, ErrDegraded) {
+				return StatusDegraded
+			}
+
 			return StatusDown
 		}
 	}
@@ -540,7 +1710,10 @@ func evaluateCheckStatus(state *CheckState, maxTimeInError time.Duration, maxFai
 	return StatusUp
 }
 
-func aggregateStatus(results map[string]CheckState) AvailabilityStatus {
+// WorstStatusAggregator is the default aggregation function of a Checker (see WithAggregator). It returns the
+// most critical status of all checks (in the order down, degraded, unknown, up). It returns StatusUp, if there
+// are no checks.
+func WorstStatusAggregator(results map[string]CheckState) AvailabilityStatus {
 	status := StatusUp
 
 	for _, result := range results {
@@ -552,6 +1725,53 @@ func aggregateStatus(results map[string]CheckState) AvailabilityStatus {
 	return status
 }
 
+// nextDownSince returns the time of when the aggregated status left StatusUp. The initial StatusUnknown
+// (i.e., before any check was executed) does not count as having left StatusUp.
+func nextDownSince(downSince time.Time, oldStatus, newStatus AvailabilityStatus, now time.Time) time.Time {
+	switch {
+	case newStatus == StatusUp:
+		return time.Time{}
+	case !downSince.IsZero():
+		return downSince
+	case newStatus != StatusUnknown || oldStatus == StatusUp:
+		return now
+	default:
+		return downSince
+	}
+}
+
+// nextIncidentID returns the ID of the current incident (see State.IncidentID). A new ID is generated when the
+// aggregated status leaves StatusUp (see nextDownSince) and the ID is cleared once it returns to StatusUp.
+func nextIncidentID(incidentID string, downSince time.Time, generate func() string) string {
+	switch {
+	case downSince.IsZero():
+		return ""
+	case incidentID == "":
+		return generate()
+	default:
+		return incidentID
+	}
+}
+
+func countStatuses(states map[string]CheckState) *StatusCounts {
+	counts := StatusCounts{Total: len(states)}
+
+	for _, state := range states {
+		switch state.Status {
+		case StatusUp:
+			counts.Up++
+		case StatusDegraded:
+			counts.Degraded++
+		case StatusDown:
+			counts.Down++
+		default:
+			counts.Unknown++
+		}
+	}
+
+	return &counts
+}
+
 func withInterceptors(interceptors []Interceptor, target InterceptorFunc) InterceptorFunc {
 	chain := target
 
@@ -2,7 +2,13 @@ package health_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -87,6 +93,55 @@ func TestEvaluateAvailabilityStatus(t *testing.T) {
 				ContiguousFails:     5,
 			},
 		},
+		{
+			name:           "DegradedThenStatusDegraded",
+			expectedStatus: health.StatusDegraded,
+			maxTimeInError: 0,
+			maxFails:       0,
+			state: health.CheckState{
+				LastCheckedAt: time.Now(),
+				Result:        fmt.Errorf("slow: %w", health.ErrDegraded),
+			},
+		},
+		{
+			name:           "DegradedAndMaxFailuresThresholdNotCrossedThenStatusUp",
+			expectedStatus: health.StatusUp,
+			maxTimeInError: 1 * time.Second,
+			maxFails:       10,
+			state: health.CheckState{
+				LastCheckedAt:       time.Now(),
+				Result:              fmt.Errorf("slow: %w", health.ErrDegraded),
+				FirstCheckStartedAt: time.Now().Add(-2 * time.Minute),
+				LastSuccessAt:       time.Now().Add(-3 * time.Minute),
+				ContiguousFails:     1,
+			},
+		},
+		{
+			name:           "DegradedAndMaxTimeInErrorThresholdNotCrossedThenStatusUp",
+			expectedStatus: health.StatusUp,
+			maxTimeInError: 1 * time.Hour,
+			maxFails:       1,
+			state: health.CheckState{
+				LastCheckedAt:       time.Now(),
+				Result:              fmt.Errorf("slow: %w", health.ErrDegraded),
+				FirstCheckStartedAt: time.Now().Add(-3 * time.Minute),
+				LastSuccessAt:       time.Now().Add(-2 * time.Minute),
+				ContiguousFails:     100,
+			},
+		},
+		{
+			name:           "DegradedAndAllThresholdsCrossedThenStatusDegraded",
+			expectedStatus: health.StatusDegraded,
+			maxTimeInError: 1 * time.Second,
+			maxFails:       1,
+			state: health.CheckState{
+				LastCheckedAt:       time.Now(),
+				Result:              fmt.Errorf("slow: %w", health.ErrDegraded),
+				FirstCheckStartedAt: time.Now().Add(-3 * time.Minute),
+				LastSuccessAt:       time.Now().Add(-2 * time.Minute),
+				ContiguousFails:     5,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -122,6 +177,82 @@ func TestStatusDownBeforeStatusUnknown(t *testing.T) {
 	assert.Equal(t, health.StatusDown, result)
 }
 
+func TestStatusDownBeforeStatusDegraded(t *testing.T) {
+	// Arrange
+	testData := map[string]health.CheckState{"check1": {Status: health.StatusDown}, "check2": {Status: health.StatusDegraded}}
+
+	// Act
+	result := health.AggregateStatus(testData)
+
+	// Assert
+	assert.Equal(t, health.StatusDown, result)
+}
+
+func TestStatusDegradedBeforeStatusUnknown(t *testing.T) {
+	// Arrange
+	testData := map[string]health.CheckState{"check1": {Status: health.StatusDegraded}, "check2": {Status: health.StatusUnknown}}
+
+	// Act
+	result := health.AggregateStatus(testData)
+
+	// Assert
+	assert.Equal(t, health.StatusDegraded, result)
+}
+
+func TestDegradedErrorThenStatusDegraded(t *testing.T) {
+	// Arrange
+	state := health.CheckState{
+		LastCheckedAt: time.Now(),
+		Result:        fmt.Errorf("replication lag: %w", health.ErrDegraded),
+	}
+
+	// Act
+	result := health.EvaluateCheckStatus(&state, 0, 0)
+
+	// Assert
+	assert.Equal(t, health.StatusDegraded, result)
+}
+
+func TestStatusCounts(t *testing.T) {
+	// Arrange
+	check := func(name string, err error) health.Check {
+		return health.Check{Name: name, Check: func(ctx context.Context) error { return err }}
+	}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusCounts(),
+		health.WithChecks(
+			check("up1", nil),
+			check("up2", nil),
+			check("degraded", fmt.Errorf("slow: %w", health.ErrDegraded)),
+			check("down", errors.New("unavailable")),
+		),
+		health.WithPeriodicCheck(time.Hour, 0, check("unknown", nil)),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	require.NotNil(t, res.Counts)
+	assert.Equal(t, health.StatusCounts{Total: 5, Up: 2, Degraded: 1, Down: 1, Unknown: 1}, *res.Counts)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"counts":{"total":5,"up":2,"degraded":1,"down":1,"unknown":1}`)
+}
+
+func TestStatusCountsDisabledByDefault(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Nil(t, res.Counts)
+}
+
 func TestStartStopManualPeriodicChecks(t *testing.T) {
 	ckr := health.NewChecker(
 		health.WithDisabledAutostart(),
@@ -225,3 +356,895 @@ func TestPanicRecovery(t *testing.T) {
 	assert.Error(t, checkRes.Error)
 	assert.Equal(t, expectedPanicMsg, (checkRes.Error).Error())
 }
+
+type fakeFlagProvider struct {
+	mtx      sync.Mutex
+	disabled map[string]bool
+}
+
+func (p *fakeFlagProvider) IsEnabled(_ context.Context, checkName string) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return !p.disabled[checkName]
+}
+
+func (p *fakeFlagProvider) set(checkName string, enabled bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.disabled[checkName] = !enabled
+}
+
+func TestFlagProviderTogglesCheckParticipation(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	provider := &fakeFlagProvider{disabled: map[string]bool{"flaky": true}}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithFlagProvider(provider),
+		health.WithCheck(health.Check{
+			Name:  "stable",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+		health.WithCheck(health.Check{
+			Name: "flaky",
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				return errors.New("unavailable")
+			},
+		}),
+	)
+
+	// Act
+	disabledRes := ckr.Check(t.Context())
+	provider.set("flaky", true)
+	enabledRes := ckr.Check(t.Context())
+	provider.set("flaky", false)
+	disabledAgainRes := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, disabledRes.Status)
+	assert.NotContains(t, disabledRes.Details, "flaky")
+	assert.Equal(t, health.StatusDown, enabledRes.Status)
+	assert.Contains(t, enabledRes.Details, "flaky")
+	assert.Equal(t, health.StatusUp, disabledAgainRes.Status)
+	assert.NotContains(t, disabledAgainRes.Details, "flaky")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFlagProviderSkipsPeriodicCheck(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	provider := &fakeFlagProvider{disabled: map[string]bool{"periodic": true}}
+	ckr := health.NewChecker(
+		health.WithFlagProvider(provider),
+		health.WithPeriodicCheck(10*time.Millisecond, 0, health.Check{
+			Name: "periodic",
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				return nil
+			},
+		}),
+	)
+	defer ckr.Stop()
+
+	// Act
+	time.Sleep(50 * time.Millisecond)
+	callsWhileDisabled := calls.Load()
+	provider.set("periodic", true)
+
+	// Assert
+	assert.Equal(t, int32(0), callsWhileDisabled)
+	assert.Eventually(t, func() bool {
+		_, evaluated := ckr.Check(t.Context()).Details["periodic"]
+		return calls.Load() > 0 && evaluated
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestThresholds(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          float64
+		err            error
+		expectedStatus health.AvailabilityStatus
+		expectedReason string
+	}{
+		{
+			name:           "ValueBelowWarnThenUp",
+			value:          10,
+			expectedStatus: health.StatusUp,
+			expectedReason: "",
+		},
+		{
+			name:           "ValueAtWarnThenDegraded",
+			value:          50,
+			expectedStatus: health.StatusDegraded,
+			expectedReason: health.ReasonThresholdExceeded,
+		},
+		{
+			name:           "ValueBetweenWarnAndCritThenDegraded",
+			value:          75,
+			expectedStatus: health.StatusDegraded,
+			expectedReason: health.ReasonThresholdExceeded,
+		},
+		{
+			name:           "ValueAboveCritThenDown",
+			value:          120,
+			expectedStatus: health.StatusDown,
+			expectedReason: health.ReasonThresholdExceeded,
+		},
+		{
+			name:           "ValueErrorThenDown",
+			err:            errors.New("cannot measure"),
+			expectedStatus: health.StatusDown,
+			expectedReason: health.ReasonError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithCheck(health.Check{
+					Name: "queue-length",
+					Value: func(ctx context.Context) (float64, error) {
+						return tc.value, tc.err
+					},
+				}, health.WithThresholds(50, 100)),
+			)
+
+			// Act
+			res := ckr.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			assert.Equal(t, tc.expectedStatus, res.Details["queue-length"].Status)
+			assert.Equal(t, tc.expectedReason, res.Details["queue-length"].Reason)
+		})
+	}
+}
+
+func TestCancelCheck(t *testing.T) {
+	// Arrange
+	started := make(chan struct{})
+	observedCancellation := make(chan error, 1)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "slow",
+			Check: func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				observedCancellation <- context.Cause(ctx)
+				return ctx.Err()
+			},
+		}),
+		health.WithCheck(health.Check{
+			Name:  "fast",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	resChan := make(chan health.Result, 1)
+	go func() {
+		resChan <- ckr.Check(context.Background())
+	}()
+	<-started
+
+	// Act
+	err := ckr.(health.CheckCanceler).CancelCheck("slow")
+
+	// Assert
+	require.NoError(t, err)
+	res := <-resChan
+	assert.Equal(t, health.StatusDown, res.Status)
+	require.ErrorIs(t, res.Details["slow"].Error, health.ErrCheckCanceled)
+	assert.Equal(t, health.ReasonCanceled, res.Details["slow"].Reason)
+	assert.Equal(t, health.StatusUp, res.Details["fast"].Status)
+	require.ErrorIs(t, <-observedCancellation, health.ErrCheckCanceled)
+}
+
+func TestCancelCheckErrors(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{Name: "idle", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	notFoundErr := ckr.(health.CheckCanceler).CancelCheck("unknown")
+	notRunningErr := ckr.(health.CheckCanceler).CancelCheck("idle")
+
+	// Assert
+	require.ErrorIs(t, notFoundErr, health.ErrCheckNotFound)
+	require.ErrorIs(t, notRunningErr, health.ErrCheckNotRunning)
+}
+
+func TestDownSince(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	start := time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithCheck(toggledCheck("check", &fail)),
+	)
+
+	// Act + Assert
+	res := ckr.Check(t.Context())
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Nil(t, res.DownSince)
+
+	clock.Advance(time.Minute)
+	fail.Store(true)
+	res = ckr.Check(t.Context())
+	require.NotNil(t, res.DownSince)
+	assert.Equal(t, start.Add(time.Minute), *res.DownSince)
+
+	// Subsequent failures keep the time of the transition.
+	clock.Advance(time.Minute)
+	res = ckr.Check(t.Context())
+	require.NotNil(t, res.DownSince)
+	assert.Equal(t, start.Add(time.Minute), *res.DownSince)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"downSince":"2025-06-02T12:01:00Z"`)
+
+	clock.Advance(time.Minute)
+	fail.Store(false)
+	res = ckr.Check(t.Context())
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Nil(t, res.DownSince)
+}
+
+func TestIncidentID(t *testing.T) {
+	// Arrange
+	var outcome atomic.Value
+	outcome.Store("up")
+
+	var ids atomic.Int32
+	var transitions []health.State
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithIDGenerator(func() string { return fmt.Sprintf("id-%d", ids.Add(1)) }),
+		health.WithStatusListener(func(_ context.Context, state health.State) {
+			transitions = append(transitions, state)
+		}),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				switch outcome.Load() {
+				case "down":
+					return errors.New("unavailable")
+				case "degraded":
+					return fmt.Errorf("slow: %w", health.ErrDegraded)
+				default:
+					return nil
+				}
+			},
+		}),
+	)
+
+	check := func(status string) health.Result {
+		outcome.Store(status)
+		return ckr.Check(t.Context())
+	}
+
+	// Act
+	up := check("up")
+	down := check("down")
+	degraded := check("degraded")
+	stillDegraded := check("degraded")
+	recovered := check("up")
+	nextIncident := check("down")
+
+	// Assert
+	assert.Empty(t, up.IncidentID)
+	assert.NotEmpty(t, down.IncidentID)
+	assert.Equal(t, down.IncidentID, degraded.IncidentID)
+	assert.Equal(t, down.IncidentID, stillDegraded.IncidentID)
+	assert.Empty(t, recovered.IncidentID)
+	assert.NotEmpty(t, nextIncident.IncidentID)
+	assert.NotEqual(t, down.IncidentID, nextIncident.IncidentID)
+
+	data, err := json.Marshal(nextIncident)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), fmt.Sprintf(`"incidentId":%q`, nextIncident.IncidentID))
+
+	require.Len(t, transitions, 5)
+	incidentIDs := make([]string, 0, len(transitions))
+	for _, state := range transitions {
+		incidentIDs = append(incidentIDs, state.IncidentID)
+	}
+	assert.Equal(t, []string{"", down.IncidentID, down.IncidentID, "", nextIncident.IncidentID}, incidentIDs)
+}
+
+func TestForceStatus(t *testing.T) {
+	// Arrange
+	start := time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithClock(newFakeClock(start)),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	ckr.(health.StatusForcer).ForceStatus(health.StatusDown, "ledger invariant violated")
+	forcedRes := ckr.Check(t.Context())
+	ckr.(health.StatusForcer).ClearForcedStatus()
+	clearedRes := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, forcedRes.Status)
+	assert.Equal(t, health.StatusUp, forcedRes.Details["check"].Status)
+	require.NotNil(t, forcedRes.Forced)
+	assert.Equal(t, health.ForcedStatus{
+		Status: health.StatusDown,
+		Reason: "ledger invariant violated",
+		Since:  start,
+	}, *forcedRes.Forced)
+
+	data, err := json.Marshal(forcedRes)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"forced":{"status":"down","reason":"ledger invariant violated"`)
+
+	assert.Equal(t, health.StatusUp, clearedRes.Status)
+	assert.Nil(t, clearedRes.Forced)
+}
+
+func TestLastCheckState(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(toggledCheck("dependency", &fail)),
+	)
+
+	// Act + Assert
+	state, ok := ckr.(health.StateReader).LastCheckState("dependency")
+	require.True(t, ok)
+	assert.Equal(t, health.StatusUnknown, state.Status)
+
+	ckr.Check(t.Context())
+	state, _ = ckr.(health.StateReader).LastCheckState("dependency")
+	assert.Equal(t, health.StatusUp, state.Status)
+
+	fail.Store(true)
+	ckr.Check(t.Context())
+	state, _ = ckr.(health.StateReader).LastCheckState("dependency")
+	assert.Equal(t, health.StatusDown, state.Status)
+
+	_, ok = ckr.(health.StateReader).LastCheckState("unknown")
+	assert.False(t, ok)
+}
+
+func TestLastCheckStateDoesNotBlockWhileChecksAreRunning(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	started := make(chan struct{})
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{
+			Name: "slow",
+			Check: func(ctx context.Context) error {
+				close(started)
+				<-release
+				return nil
+			},
+		}),
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ckr.Check(context.Background())
+	}()
+	<-started
+
+	// Act
+	var wg sync.WaitGroup
+	var reads atomic.Int32
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				if _, ok := ckr.(health.StateReader).LastCheckState("slow"); ok {
+					reads.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(release)
+	<-done
+
+	// Assert
+	assert.Equal(t, int32(8000), reads.Load())
+	state, _ := ckr.(health.StateReader).LastCheckState("slow")
+	assert.Equal(t, health.StatusUp, state.Status)
+}
+
+func TestPeriodicCheckSkipsOverlappingEvaluations(t *testing.T) {
+	// Arrange
+	var (
+		running    atomic.Int32
+		overlapped atomic.Bool
+		calls      atomic.Int32
+	)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithPeriodicCheck(10*time.Millisecond, 0, health.Check{
+			Name: "slow",
+			Check: func(ctx context.Context) error {
+				if running.Add(1) > 1 {
+					overlapped.Store(true)
+				}
+				defer running.Add(-1)
+
+				calls.Add(1)
+				time.Sleep(55 * time.Millisecond)
+
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	require.Eventually(t, func() bool {
+		state, _ := ckr.(health.StateReader).LastCheckState("slow")
+		return calls.Load() >= 2 && state.SkippedEvaluations >= 3
+	}, 2*time.Second, 5*time.Millisecond)
+
+	assert.False(t, overlapped.Load())
+
+	state, _ := ckr.(health.StateReader).LastCheckState("slow")
+	assert.False(t, state.LastSkippedAt.IsZero())
+	assert.Equal(t, health.StatusUp, state.Status)
+	assert.GreaterOrEqual(t, ckr.Check(t.Context()).Details["slow"].SkippedEvaluations, state.SkippedEvaluations)
+}
+
+func TestSoftDependencyDegradesDependent(t *testing.T) {
+	// Arrange
+	var (
+		parentFailing  atomic.Bool
+		dependentCalls atomic.Int32
+	)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(toggledCheck("cache", &parentFailing)),
+		health.WithCheck(health.Check{
+			Name: "api",
+			Check: func(ctx context.Context) error {
+				dependentCalls.Add(1)
+				return nil
+			},
+		}, health.WithSoftDependsOn("cache")),
+	)
+
+	// Act
+	healthyRes := ckr.Check(t.Context())
+	parentFailing.Store(true)
+	degradedRes := ckr.Check(t.Context())
+	parentFailing.Store(false)
+	recoveredRes := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, int32(3), dependentCalls.Load())
+
+	assert.Equal(t, health.StatusUp, healthyRes.Details["api"].Status)
+	assert.Empty(t, healthyRes.Details["api"].Reason)
+
+	assert.Equal(t, health.StatusDown, degradedRes.Status)
+	assert.Equal(t, health.StatusDown, degradedRes.Details["cache"].Status)
+	assert.Equal(t, health.StatusDegraded, degradedRes.Details["api"].Status)
+	assert.Equal(t, health.ReasonDependencyDown, degradedRes.Details["api"].Reason)
+	assert.NoError(t, degradedRes.Details["api"].Error)
+
+	assert.Equal(t, health.StatusUp, recoveredRes.Status)
+	assert.Equal(t, health.StatusUp, recoveredRes.Details["api"].Status)
+	assert.Empty(t, recoveredRes.Details["api"].Reason)
+}
+
+func TestSoftDependencyDoesNotMaskOwnFailure(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	failing.Store(true)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(toggledCheck("cache", &failing)),
+		health.WithCheck(toggledCheck("api", &failing), health.WithSoftDependsOn("cache", "unknown")),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, res.Details["api"].Status)
+	assert.Equal(t, health.ReasonError, res.Details["api"].Reason)
+}
+
+func TestSoftDependencyRestoresWhenParentRecoversLater(t *testing.T) {
+	// Arrange
+	var parentFailing atomic.Bool
+	parentFailing.Store(true)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithPeriodicCheck(time.Hour, 0, health.Check{
+			Name:  "api",
+			Check: func(ctx context.Context) error { return nil },
+		}, health.WithSoftDependsOn("cache")),
+		health.WithPeriodicCheck(10*time.Millisecond, 0, toggledCheck("cache", &parentFailing)),
+	)
+
+	// Act
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	require.Eventually(t, func() bool {
+		state, _ := ckr.(health.StateReader).LastCheckState("api")
+		return state.Status == health.StatusDegraded
+	}, time.Second, 5*time.Millisecond)
+
+	parentFailing.Store(false)
+
+	require.Eventually(t, func() bool {
+		state, _ := ckr.(health.StateReader).LastCheckState("api")
+		return state.Status == health.StatusUp
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestPeriodicCheckTimeoutFraction(t *testing.T) {
+	// Arrange
+	deadlines := make(chan time.Duration, 1)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithPeriodicCheck(time.Second, 0, health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				deadline, _ := ctx.Deadline()
+				select {
+				case deadlines <- time.Until(deadline):
+				default:
+				}
+				return nil
+			},
+		}, health.WithTimeoutFraction(0.5)),
+	)
+
+	// Act
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	select {
+	case remaining := <-deadlines:
+		assert.LessOrEqual(t, remaining, 500*time.Millisecond)
+		assert.Greater(t, remaining, 400*time.Millisecond)
+	case <-time.After(time.Second):
+		require.FailNow(t, "check was not evaluated")
+	}
+}
+
+func TestCustomAggregator(t *testing.T) {
+	// Arrange
+	// The system is considered available as long as at least two of the replicas are up.
+	quorum := func(states map[string]health.CheckState) health.AvailabilityStatus {
+		up := 0
+		for _, state := range states {
+			if state.Status == health.StatusUp {
+				up++
+			}
+		}
+
+		if up >= 2 {
+			return health.StatusUp
+		}
+
+		return health.StatusDown
+	}
+
+	failing := []*atomic.Bool{{}, {}, {}}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithAggregator(quorum),
+		health.WithCheck(toggledCheck("replica-1", failing[0])),
+		health.WithCheck(toggledCheck("replica-2", failing[1])),
+		health.WithCheck(toggledCheck("replica-3", failing[2])),
+	)
+
+	// Act
+	allUp := ckr.Check(t.Context())
+	failing[0].Store(true)
+	oneDown := ckr.Check(t.Context())
+	failing[1].Store(true)
+	twoDown := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, allUp.Status)
+	assert.Equal(t, health.StatusUp, oneDown.Status)
+	assert.Equal(t, health.StatusDown, oneDown.Details["replica-1"].Status)
+	assert.Equal(t, health.StatusDown, twoDown.Status)
+}
+
+func TestExpectedStatusDeviations(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(toggledCheck("canary", &failing), health.WithExpectedStatus(health.StatusUp)),
+	)
+
+	// Act
+	for i := range 8 {
+		// Every fourth evaluation fails.
+		failing.Store(i%4 == 3)
+		ckr.Check(t.Context())
+	}
+
+	failing.Store(true)
+	deviatingRes := ckr.Check(t.Context())
+
+	// Assert
+	state, ok := ckr.(health.StateReader).LastCheckState("canary")
+	require.True(t, ok)
+	assert.Equal(t, uint(9), state.Evaluations)
+	assert.Equal(t, uint(3), state.Deviations)
+	assert.InDelta(t, 1.0/3.0, state.DeviationRate(), 0.0001)
+	assert.True(t, deviatingRes.Details["canary"].Deviating)
+}
+
+func TestExpectedStatusDown(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:  "decommissioned",
+			Check: func(ctx context.Context) error { return nil },
+		}, health.WithExpectedStatus(health.StatusDown)),
+		health.WithCheck(health.Check{
+			Name:  "regular",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.True(t, res.Details["decommissioned"].Deviating)
+	assert.False(t, res.Details["regular"].Deviating)
+
+	state, _ := ckr.(health.StateReader).LastCheckState("regular")
+	assert.Equal(t, uint(0), state.Evaluations)
+	assert.InDelta(t, 0.0, state.DeviationRate(), 0)
+}
+
+func TestMinUptime(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithMinUptime(time.Minute),
+		health.WithCheck(toggledCheck("check", &failing)),
+	)
+	defer ckr.Stop()
+
+	readiness := health.NewReadinessHandler(ckr)
+	ready := func() int {
+		w := httptest.NewRecorder()
+		readiness.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+		return w.Code
+	}
+
+	// The uptime is measured from the start of the Checker.
+	clock.Advance(time.Hour)
+	ckr.Start()
+
+	// Act
+	justStarted := ckr.Check(t.Context())
+	justStartedReady := ready()
+	clock.Advance(59 * time.Second)
+	almostWarmReady := ready()
+	clock.Advance(time.Second)
+	warm := ckr.Check(t.Context())
+	warmReady := ready()
+	failing.Store(true)
+	clock.Advance(time.Second)
+	warmButFailingReady := ready()
+
+	// Assert
+	assert.Equal(t, health.StatusUp, justStarted.Status, "the aggregated status must not be affected")
+	assert.True(t, justStarted.WarmingUp)
+	assert.Equal(t, http.StatusServiceUnavailable, justStartedReady)
+	assert.Equal(t, http.StatusServiceUnavailable, almostWarmReady)
+
+	assert.False(t, warm.WarmingUp)
+	assert.Equal(t, http.StatusOK, warmReady)
+	assert.Equal(t, http.StatusServiceUnavailable, warmButFailingReady)
+}
+
+func TestMinUptimeDoesNotAffectState(t *testing.T) {
+	// Arrange
+	var (
+		mtx      sync.Mutex
+		statuses []health.AvailabilityStatus
+	)
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithMinUptime(time.Hour),
+		health.WithStatusListener(func(ctx context.Context, state health.State) {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			statuses = append(statuses, state.Status)
+		}),
+		health.WithCheck(statusCheck("check", health.StatusUp)),
+	)
+	defer ckr.Stop()
+	ckr.Start()
+
+	// Act
+	live := httptest.NewRecorder()
+	health.NewHandler(ckr).ServeHTTP(live, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	state := ckr.(health.StateReader).State()
+
+	// Assert
+	assert.Equal(t, http.StatusOK, live.Code)
+	assert.Equal(t, health.StatusUp, state.Status)
+	assert.Empty(t, state.IncidentID)
+	assert.True(t, state.DownSince.IsZero())
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	assert.Equal(t, []health.AvailabilityStatus{health.StatusUp}, statuses, "no down status must be reported")
+}
+
+func TestResultValidatorCorrectsInconsistentState(t *testing.T) {
+	// Arrange
+	forceUp := func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			state = next(ctx, name, state)
+			state.Status = health.StatusUp
+			return state
+		}
+	}
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(forceUp),
+		health.WithResultValidator(func(state health.CheckState) (health.CheckState, error) {
+			if state.Status == health.StatusUp && state.Result != nil {
+				state.Status = health.StatusDegraded
+			}
+			return state, nil
+		}),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return errors.New("slow") },
+		}),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDegraded, result.Status)
+	assert.Equal(t, health.StatusDegraded, result.Details["database"].Status)
+	assert.EqualError(t, result.Details["database"].Error, "slow")
+}
+
+func TestResultValidatorDiscardsInvalidResult(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithResultValidator(func(state health.CheckState) (health.CheckState, error) {
+			if state.Result != nil {
+				return state, errors.New("failures are not accepted")
+			}
+			return state, nil
+		}),
+		health.WithCheck(toggledCheck("database", &failing)),
+	)
+
+	// Act
+	valid := ckr.Check(t.Context())
+	failing.Store(true)
+	time.Sleep(time.Millisecond)
+	invalid := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, valid.Status)
+	assert.Equal(t, health.StatusUp, invalid.Status)
+	assert.Equal(t, valid.Details["database"].Timestamp, invalid.Details["database"].Timestamp)
+}
+
+func TestCheckStateEvaluationTimestamps(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+	state, ok := ckr.(health.StateReader).LastCheckState("database")
+
+	// Assert
+	require.True(t, ok)
+	assert.Equal(t, time.UTC, state.StartedAt.Location())
+	assert.Equal(t, time.UTC, state.FinishedAt.Location())
+	assert.Equal(t, state.LastCheckedAt, state.FinishedAt)
+	assert.Equal(t, state.FinishedAt.Sub(state.StartedAt), state.Duration())
+	assert.GreaterOrEqual(t, state.Duration(), 5*time.Millisecond)
+
+	details := result.Details["database"]
+	assert.Equal(t, state.StartedAt, details.StartedAt)
+	assert.Equal(t, state.FinishedAt, details.FinishedAt)
+	assert.Equal(t, details.FinishedAt.Sub(details.StartedAt), details.Duration)
+}
+
+func TestCheckResultEvaluationTimestampsJSON(t *testing.T) {
+	// Arrange
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	result := health.CheckResult{
+		Status:     health.StatusUp,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(1500 * time.Microsecond),
+		Duration:   1500 * time.Microsecond,
+	}
+
+	// Act
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var decoded health.CheckResult
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// Assert
+	assert.Contains(t, string(data), `"startedAt":"2025-03-01T12:00:00Z"`)
+	assert.Contains(t, string(data), `"finishedAt":"2025-03-01T12:00:00.0015Z"`)
+	assert.Contains(t, string(data), `"duration":"1.5ms"`)
+	assert.Equal(t, result, decoded)
+}
+
+func TestCheckResultWithoutEvaluationOmitsTimestamps(t *testing.T) {
+	// Act
+	data, err := json.Marshal(health.CheckResult{Status: health.StatusUnknown})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "startedAt")
+	assert.NotContains(t, string(data), "finishedAt")
+	assert.NotContains(t, string(data), "duration")
+}
@@ -0,0 +1,110 @@
+package health
+
+import "time"
+
+type (
+	// CheckDefaults holds the settings that a CheckSet applies to each of its checks.
+	CheckDefaults struct {
+		// Timeout is applied as Check.Timeout.
+		Timeout time.Duration
+		// Interval makes each check a periodic check with this update interval (see WithPeriodicCheck).
+		// If zero, the checks are synchronous (see WithCheck).
+		Interval time.Duration
+		// InitialDelay is the initial delay of periodic checks (see WithPeriodicCheck).
+		InitialDelay time.Duration
+		// MaxTimeInError is applied as Check.MaxTimeInError.
+		MaxTimeInError time.Duration
+		// MaxContiguousFails is applied as Check.MaxContiguousFails.
+		MaxContiguousFails uint
+		// Interceptors are applied as Check.Interceptors.
+		Interceptors []Interceptor
+		// Options are applied to each check before the options of the individual check.
+		Options []CheckOption
+	}
+
+	// CheckSet builds a list of checks that share the same defaults (see NewCheckSet).
+	CheckSet struct {
+		defaults CheckDefaults
+		checks   []Check
+	}
+)
+
+// NewCheckSet creates a CheckSet that applies the given defaults to each of its checks. This avoids
+// repeating the same settings for many similar checks. Example:
+//
+//	checks := health.NewCheckSet(health.CheckDefaults{Timeout: 2 * time.Second, Interval: 30 * time.Second}).
+//		HTTP("billing", "http://billing/health").
+//		HTTP("search", "http://search/health", health.WithTimeoutOverride(5*time.Second)).
+//		TCP("database", "db:5432").
+//		Build()
+//
+//	checker := health.NewChecker(health.WithChecks(checks...))
+//
+// The options of an individual check are applied after the defaults, so they override them.
+func NewCheckSet(defaults CheckDefaults) *CheckSet {
+	return &CheckSet{defaults: defaults}
+}
+
+// HTTP adds a check created by HTTPCheck.
+func (cs *CheckSet) HTTP(name, url string, options ...CheckOption) *CheckSet {
+	return cs.Add(HTTPCheck(name, url), options...)
+}
+
+// TCP adds a check created by TCPCheck.
+func (cs *CheckSet) TCP(name, address string, options ...CheckOption) *CheckSet {
+	return cs.Add(TCPCheck(name, address), options...)
+}
+
+// Add adds a check. Settings of the check that have their zero value are set to the defaults.
+func (cs *CheckSet) Add(check Check, options ...CheckOption) *CheckSet {
+	if check.Timeout == 0 {
+		check.Timeout = cs.defaults.Timeout
+	}
+
+	if check.MaxTimeInError == 0 {
+		check.MaxTimeInError = cs.defaults.MaxTimeInError
+	}
+
+	if check.MaxContiguousFails == 0 {
+		check.MaxContiguousFails = cs.defaults.MaxContiguousFails
+	}
+
+	if len(check.Interceptors) == 0 && len(cs.defaults.Interceptors) > 0 {
+		check.Interceptors = append([]Interceptor{}, cs.defaults.Interceptors...)
+	}
+
+	check.updateInterval = cs.defaults.Interval
+	check.initialDelay = cs.defaults.InitialDelay
+
+	applyCheckOptions(&check, cs.defaults.Options)
+	applyCheckOptions(&check, options)
+
+	cs.checks = append(cs.checks, check)
+
+	return cs
+}
+
+// Build returns the checks of the set. Periodic checks (see CheckDefaults.Interval) retain their
+// schedule, so all checks can be registered with WithChecks.
+func (cs *CheckSet) Build() []Check {
+	return append([]Check{}, cs.checks...)
+}
+
+// WithTimeoutOverride overrides the timeout of a check (see Check.Timeout).
+// This is mostly useful to override the defaults of a CheckSet.
+func WithTimeoutOverride(timeout time.Duration) CheckOption {
+	return func(check *Check) {
+		check.Timeout = timeout
+	}
+}
+
+// WithInterval sets the update interval and the initial delay of a check. A check with an
+// update interval greater than zero is a periodic check (see WithPeriodicCheck). An interval of zero
+// makes the check synchronous (see WithCheck). This is mostly useful to override the defaults of a CheckSet.
+// Note that WithPeriodicCheck sets the interval after applying its options.
+func WithInterval(interval, initialDelay time.Duration) CheckOption {
+	return func(check *Check) {
+		check.updateInterval = interval
+		check.initialDelay = initialDelay
+	}
+}
@@ -0,0 +1,75 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestCheckSetAppliesDefaults(t *testing.T) {
+	// Arrange
+	interceptor := func(next health.InterceptorFunc) health.InterceptorFunc { return next }
+	set := health.NewCheckSet(health.CheckDefaults{
+		Timeout:            2 * time.Second,
+		MaxTimeInError:     time.Minute,
+		MaxContiguousFails: 3,
+		Interceptors:       []health.Interceptor{interceptor},
+		Options:            []health.CheckOption{health.WithGroup("backends")},
+	})
+
+	// Act
+	checks := set.
+		HTTP("billing", "http://billing/health").
+		HTTP("search", "http://search/health", health.WithTimeoutOverride(5*time.Second)).
+		TCP("database", "db:5432").
+		Add(health.Check{Name: "custom", MaxContiguousFails: 1, Check: func(ctx context.Context) error { return nil }}).
+		Build()
+
+	// Assert
+	require.Len(t, checks, 4)
+
+	timeouts := map[string]time.Duration{}
+	for _, check := range checks {
+		timeouts[check.Name] = check.Timeout
+		assert.Equal(t, time.Minute, check.MaxTimeInError)
+		assert.Len(t, check.Interceptors, 1)
+		assert.NotNil(t, check.Check)
+	}
+
+	assert.Equal(t, map[string]time.Duration{
+		"billing":  2 * time.Second,
+		"search":   5 * time.Second,
+		"database": 2 * time.Second,
+		"custom":   2 * time.Second,
+	}, timeouts)
+	assert.Equal(t, uint(3), checks[0].MaxContiguousFails)
+	assert.Equal(t, uint(1), checks[3].MaxContiguousFails)
+}
+
+func TestCheckSetWithIntervalRegistersPeriodicChecks(t *testing.T) {
+	// Arrange
+	checks := health.NewCheckSet(health.CheckDefaults{Interval: time.Hour}).
+		Add(health.Check{Name: "periodic", Check: func(ctx context.Context) error { return nil }}).
+		Add(health.Check{Name: "sync", Check: func(ctx context.Context) error { return nil }}, health.WithInterval(0, 0)).
+		Build()
+
+	ckr := health.NewChecker(health.WithDisabledAutostart(), health.WithChecks(checks...))
+
+	// Act
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	assert.Equal(t, 1, ckr.GetRunningPeriodicCheckCount())
+
+	snapshot := ckr.(health.ConfigSnapshotter).ConfigSnapshot()
+	checkSnapshots, ok := snapshot["checks"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, true, checkSnapshots["periodic"].(map[string]any)["periodic"])
+	assert.Equal(t, false, checkSnapshots["sync"].(map[string]any)["periodic"])
+}
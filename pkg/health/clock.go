@@ -0,0 +1,60 @@
+package health
+
+import "time"
+
+type (
+	// Clock provides the current time to a Checker. It allows to control time-dependent
+	// behaviour (such as active windows, see WithActiveWindow), e.g., in tests.
+	Clock interface {
+		// Now returns the current time.
+		Now() time.Time
+	}
+
+	// TimerClock is a Clock that also provides timers. The Checker measures delayed notifications (such as the
+	// cool-down of status listeners, see WithListenerCoolDown) with the timers of its Clock, if the Clock
+	// implements TimerClock. Otherwise, these delays are measured with the system clock entirely.
+	TimerClock interface {
+		Clock
+
+		// AfterFunc calls f once the given duration has elapsed on the clock.
+		AfterFunc(d time.Duration, f func()) Timer
+	}
+
+	// Timer is a timer of a TimerClock (see TimerClock.AfterFunc), e.g., a *time.Timer.
+	Timer interface {
+		// Stop prevents the timer from firing. It returns false if the timer already fired or was stopped.
+		Stop() bool
+	}
+
+	systemClock struct{}
+)
+
+// Now implements Clock.Now using time.Now.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc implements TimerClock.AfterFunc using time.AfterFunc.
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// timerClockOf returns the clock, if it implements TimerClock, or the system clock otherwise, so that delays
+// are measured and scheduled with the same clock.
+func timerClockOf(clock Clock) TimerClock {
+	if tc, ok := clock.(TimerClock); ok {
+		return tc
+	}
+
+	return systemClock{}
+}
+
+// clockOf returns the Clock of the checker (see WithClock), or the system clock if the checker is not
+// created by NewChecker.
+func clockOf(checker Checker) Clock {
+	if ck, ok := checker.(*defaultChecker); ok {
+		return ck.cfg.clock
+	}
+
+	return systemClock{}
+}
@@ -0,0 +1,83 @@
+package health_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type (
+	// fakeClock is a health.TimerClock whose time only changes when it is set or advanced explicitly.
+	// Timers fire synchronously once the time is set or advanced past their deadline.
+	fakeClock struct {
+		mtx    sync.Mutex
+		now    time.Time
+		timers []*fakeTimer
+	}
+
+	fakeTimer struct {
+		clock    *fakeClock
+		deadline time.Time
+		f        func()
+		done     bool
+	}
+)
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) health.Timer {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	timer := &fakeTimer{clock: c, deadline: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+
+	return timer
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.mtx.Lock()
+	c.now = now
+
+	var due, pending []*fakeTimer
+	for _, timer := range c.timers {
+		switch {
+		case timer.done:
+		case !timer.deadline.After(now):
+			timer.done = true
+			due = append(due, timer)
+		default:
+			pending = append(pending, timer)
+		}
+	}
+	c.timers = pending
+	c.mtx.Unlock()
+
+	for _, timer := range due {
+		timer.f()
+	}
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mtx.Lock()
+	defer t.clock.mtx.Unlock()
+
+	stopped := !t.done
+	t.done = true
+
+	return stopped
+}
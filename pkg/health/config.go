@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
+	slogctx "github.com/veqryn/slog-context"
+
 	"github.com/openkcm/common-sdk/pkg/commoncfg"
 )
 
@@ -18,9 +21,22 @@ type (
 
 		// Check is the check function that will be executed to check availability.
 		// This function must return an error if the checked service is considered
-		// not available. Check is a required attribute.
+		// not available. Check is a required attribute (unless Value or SubChecks is set).
 		Check func(ctx context.Context) error // Required
 
+		// Value is an alternative to Check for checks that measure a numeric value (e.g., a queue length or
+		// a replication lag). The value is mapped to an availability status using the thresholds configured
+		// with WithThresholds. If an error is returned, the check is considered failed. Value is only used
+		// if Check is not set.
+		Value func(ctx context.Context) (float64, error) // Optional
+
+		// SubChecks is an alternative to Check for checks that probe several aspects of a component in one call
+		// (e.g., read, write and replication of a database). Each SubResult is reported as a nested entry in the
+		// check details. The status of the check is derived from the worst status of its sub-results: the check
+		// fails if any sub-result failed, and it is degraded if any sub-result is degraded (see ErrDegraded).
+		// SubChecks is only used if neither Check nor Value are set.
+		SubChecks func(ctx context.Context) []SubResult // Optional
+
 		// Timeout will override the global timeout value, if it is smaller than
 		// the global timeout (see WithTimeout).
 		Timeout time.Duration // Optional
@@ -48,13 +64,38 @@ type (
 		// PanicHandler allows to set a panic handler.
 		PanicHandler func(ctx context.Context, err error) // Optional
 
-		updateInterval time.Duration
-		initialDelay   time.Duration
+		// Dependency names the dependency that the check probes (e.g., "postgres"). Checks of the same
+		// dependency share its concurrency limit (see WithDependencyConcurrency and ErrUnknownDependency).
+		Dependency string // Optional
+
+		updateInterval  time.Duration
+		initialDelay    time.Duration
+		activeWindow    *ActiveWindow
+		thresholds      *thresholds
+		group           string
+		retry           *retryPolicy
+		softDependsOn   []string
+		timeoutFraction float64
+		expectedStatus  AvailabilityStatus
+		tags            map[string]string
+		errorRate       *errorRatePolicy
+		target          string
+		canary          bool
+		pipeline        []CheckStage
+		configErr       error
+	}
+
+	thresholds struct {
+		warn float64
+		crit float64
 	}
 
 	// Option is a configuration option for a Checker.
 	Option func(config *checkerConfig)
 
+	// CheckOption is a configuration option for a single Check (see WithCheck and WithPeriodicCheck).
+	CheckOption func(check *Check)
+
 	// HandlerOption is a configuration option for a Handler (see NewHandler).
 	HandlerOption func(*HandlerConfig)
 )
@@ -64,12 +105,30 @@ type (
 // (see Checker.IsStarted), it will be started automatically
 // (see Checker.Start). You can disable this autostart by
 // adding the WithDisabledAutostart configuration option.
+// The returned Checker implements all optional interfaces of this
+// package (e.g., Drainer or StateReader), which can be accessed with
+// a type assertion.
+// NewChecker panics if the configuration is invalid (see BuildChecker).
 func NewChecker(options ...Option) Checker {
+	checker, err := BuildChecker(options...)
+	if err != nil {
+		panic(err)
+	}
+
+	return checker
+}
+
+// BuildChecker creates a new Checker like NewChecker, but returns an error instead of panicking
+// if the configuration is invalid (e.g., see WithReadinessExpression).
+func BuildChecker(options ...Option) (Checker, error) {
 	cfg := checkerConfig{
-		cacheTTL:     1 * time.Second,
-		timeout:      10 * time.Second,
-		checks:       map[string]*Check{},
-		interceptors: []Interceptor{},
+		cacheTTL:         1 * time.Second,
+		timeout:          10 * time.Second,
+		checks:           map[string]*Check{},
+		interceptors:     []Interceptor{},
+		clock:            systemClock{},
+		idGenerator:      newRandomID,
+		transitionWindow: time.Second,
 	}
 
 	for _, opt := range options {
@@ -78,7 +137,84 @@ func NewChecker(options ...Option) Checker {
 		}
 	}
 
-	return newChecker(cfg)
+	if cfg.selfCheckEnabled {
+		if err := cfg.registerSelfCheck(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.validateChecks(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateDependencies(); err != nil {
+		return nil, err
+	}
+
+	if cfg.partition != nil {
+		if err := cfg.partition.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.readinessExpression != "" && (cfg.aggregator != nil || cfg.statusPrecedence != nil) {
+		return nil, ErrConflictingAggregation
+	}
+
+	if cfg.statusPrecedence != nil {
+		aggregator, err := newStatusPrecedenceAggregator(cfg.statusPrecedence)
+		if err != nil {
+			return nil, err
+		}
+
+		if cfg.aggregator == nil {
+			cfg.aggregator = aggregator
+		}
+	}
+
+	if cfg.readinessExpression != "" {
+		readiness, err := compileReadinessExpression(cfg.readinessExpression, cfg.checks)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.readiness = readiness
+	}
+
+	return newChecker(cfg), nil
+}
+
+// WithNilCheckFuncsAsUp makes the Checker accept checks without a check function (see Check.Check). Such
+// checks are always up and a warning is logged for each of them when the Checker is created. By default,
+// BuildChecker returns ErrMissingCheckFunc (and NewChecker panics) for such checks, so that the programming
+// error is detected right away instead of when the check is evaluated.
+func WithNilCheckFuncsAsUp() Option {
+	return func(cfg *checkerConfig) {
+		cfg.nilCheckFuncsAsUp = true
+	}
+}
+
+// validateChecks ensures that all checks are configured correctly (e.g., see DBPoolCheck) and that they
+// have a check function (see WithNilCheckFuncsAsUp).
+func (cfg *checkerConfig) validateChecks() error {
+	for _, check := range cfg.checks {
+		if check.configErr != nil {
+			return check.configErr
+		}
+
+		if check.Check != nil || check.Value != nil || check.SubChecks != nil {
+			continue
+		}
+
+		if !cfg.nilCheckFuncsAsUp {
+			return fmt.Errorf("%w: %s", ErrMissingCheckFunc, check.Name)
+		}
+
+		slogctx.Warn(context.Background(), "Health check has no check function and is always up", "check", check.Name)
+		check.Check = func(context.Context) error { return nil }
+	}
+
+	return nil
 }
 
 // WithDisabledDetails disables all data in the JSON response body. The AvailabilityStatus will be the only
@@ -89,6 +225,26 @@ func WithDisabledDetails() Option {
 	}
 }
 
+// WithStatusCounts adds the number of checks per availability status to each Result (see Result.Counts).
+// Example: { "status":"down", "counts":{ "total":12, "up":10, "degraded":1, "down":1, "unknown":0 } }.
+// Disabled by default.
+func WithStatusCounts() Option {
+	return func(cfg *checkerConfig) {
+		cfg.statusCountsEnabled = true
+	}
+}
+
+// WithMaxErrorLength limits the length of check error messages to the given number of characters. Longer
+// messages are truncated and end with an ellipsis ("..."). This keeps responses and logs small, even if a check
+// returns a huge error (e.g., including a full SQL statement). The limit applies to the check state, so it
+// affects all consumers of check errors, such as the check details, listeners, interceptors and publishers.
+// The error chain is retained, so errors.Is and ReasonOf still work as expected. By default, there is no limit.
+func WithMaxErrorLength(maxLength int) Option {
+	return func(cfg *checkerConfig) {
+		cfg.maxErrorLength = maxLength
+	}
+}
+
 // WithTimeout defines a timeout duration for all checks. You can override
 // this timeout by using the timeout value in the Check configuration.
 // Default value is 10 seconds.
@@ -107,6 +263,126 @@ func WithStatusListener(listener func(ctx context.Context, state State)) Option
 	}
 }
 
+// WithFlagProvider sets a FlagProvider that is consulted before each evaluation of a check. Checks that are
+// disabled by the provider are not evaluated, do not contribute to the aggregated health status and are omitted
+// from the check details. Once a check is enabled again, it participates with its next evaluation.
+func WithFlagProvider(provider FlagProvider) Option {
+	return func(cfg *checkerConfig) {
+		cfg.flagProvider = provider
+	}
+}
+
+// WithHistory enables the retention of the last results of each check and of the aggregated status.
+// At most size entries are retained per check (and for the aggregated status). The history can be
+// read with HistoryProvider.History. By default, no history is retained.
+func WithHistory(size int) Option {
+	return func(cfg *checkerConfig) {
+		cfg.historySize = size
+	}
+}
+
+// WithWindowedAggregation makes the aggregated health status consider the results of each check within the
+// given window instead of only its latest result. A check is considered down for the aggregation only if it was
+// down for the majority of its results in the window. This smooths transient failures on the aggregate level,
+// while the check details still report the latest result of each check. The results are taken from the retained
+// history (see WithHistory). If no history size is configured, the last 100 results of each check are retained.
+func WithWindowedAggregation(window time.Duration) Option {
+	return func(cfg *checkerConfig) {
+		cfg.aggregationWindow = window
+	}
+}
+
+// WithAggregator sets the function that computes the aggregated health status from the states of all checks
+// that participate in the aggregation (e.g., checks disabled by a FlagProvider are excluded). This allows to
+// implement custom rules. The built-in QuorumAggregator and WeightedAggregator consider the system available as
+// long as a quorum of the checks is up. The function receives a copy of the states. It is called while the
+// Checker holds its lock, so it should be fast and must not call the Checker. By default, WorstStatusAggregator
+// is used.
+func WithAggregator(aggregator func(states map[string]CheckState) AvailabilityStatus) Option {
+	return func(cfg *checkerConfig) {
+		cfg.aggregator = aggregator
+	}
+}
+
+// WithStatusPrecedence sets the order in which the statuses of checks take precedence over each other, when
+// the aggregated health status is chosen among mixed statuses (most significant first). For example, the order
+// down, unknown, degraded, up reports unknown (instead of degraded) if one check is degraded while another one is
+// unknown. The order must contain each of StatusDown, StatusDegraded, StatusUnknown and StatusUp exactly once,
+// otherwise BuildChecker fails with ErrInvalidStatusPrecedence (and NewChecker panics). The precedence replaces
+// the WorstStatusAggregator, so it has no effect if an aggregator is set (see WithAggregator) or if a readiness
+// expression is used (see WithReadinessExpression). By default, the order down, degraded, unknown, up is used.
+func WithStatusPrecedence(order []AvailabilityStatus) Option {
+	return func(cfg *checkerConfig) {
+		cfg.statusPrecedence = append([]AvailabilityStatus{}, order...)
+	}
+}
+
+// WithMinUptime makes the readiness handlers of the Checker (see NewReadinessHandler) report StatusDown until the
+// given duration has elapsed since the Checker was started (see Checker.Start), regardless of the check results.
+// This prevents that a just started instance receives traffic right away, e.g., before its caches are warmed up.
+// Checks are still executed and the aggregated status (e.g., of State and the status listener) is not affected,
+// so neither liveness probes nor incidents are triggered by the warm-up (see Result.WarmingUp). The uptime is
+// measured with the Clock of the Checker (see WithClock), and a Checker that is not started is warming up.
+func WithMinUptime(minUptime time.Duration) Option {
+	return func(cfg *checkerConfig) {
+		cfg.minUptime = minUptime
+	}
+}
+
+// WithWorkerPool limits the number of concurrent check evaluations (of both synchronous and periodic checks)
+// to the given number of workers. Evaluations that are due while all workers are busy wait for a worker.
+// The waiting time counts towards the timeout of the check. When evaluations start to queue, a warning is
+// logged. The utilization of the pool can be read with StatsProvider.Stats to tune its size. By default, there
+// is no limit.
+func WithWorkerPool(size int) Option {
+	return func(cfg *checkerConfig) {
+		cfg.workerPoolSize = size
+	}
+}
+
+// WithStatsInResult adds the internal statistics of the Checker to each Result (see StatsProvider.Stats and
+// Result.Stats). Disabled by default.
+func WithStatsInResult() Option {
+	return func(cfg *checkerConfig) {
+		cfg.statsInResult = true
+	}
+}
+
+// WithSelfCheck registers a synchronous check named SelfCheckName that reports the health of the Checker
+// itself, e.g., if periodic checks are no longer evaluated or evaluations are queuing (see SelfCheck).
+// The check can be referred to like any other check, e.g., in a readiness expression (see
+// WithReadinessExpression). BuildChecker returns ErrReservedCheckName if another check is named SelfCheckName.
+// Disabled by default.
+func WithSelfCheck() Option {
+	return func(cfg *checkerConfig) {
+		cfg.selfCheckEnabled = true
+	}
+}
+
+// WithResultValidator sets a hook that validates the state of a check after each evaluation (including all
+// interceptors), before it is accepted into the cache and the aggregated status. The validator can correct
+// inconsistent states (e.g., a check that is up although it reported an error) by returning a modified state.
+// If the validator returns an error, the result is considered invalid: the error is logged, and the result is
+// discarded, so that the check keeps its previous state (which is StatusUnknown before its first valid result).
+func WithResultValidator(validator func(state CheckState) (CheckState, error)) Option {
+	return func(cfg *checkerConfig) {
+		cfg.resultValidator = validator
+	}
+}
+
+// WithListenerCoolDown sets a minimum duration between two notifications of the StatusListener of a check
+// (see Check.StatusListener). Status changes that happen within the cool-down period are coalesced: once the
+// cool-down period is over, the listener is notified only once with the latest state of the check (or not at all,
+// if the check returned to the last reported status in the meantime). In contrast to debouncing, the first
+// status change is reported immediately. The cool-down period is measured with the Clock of the Checker (see
+// WithClock), if it implements TimerClock, and with the system clock otherwise. Pending notifications are delivered
+// when the Checker is stopped. By default, there is no cool-down period.
+func WithListenerCoolDown(coolDown time.Duration) Option {
+	return func(cfg *checkerConfig) {
+		cfg.listenerCoolDown = coolDown
+	}
+}
+
 // WithMiddleware configures a middleware that will be used by the handler
 // to pro- and post-process HTTP requests and health checks.
 // Refer to the documentation of type Middleware for more information.
@@ -142,6 +418,86 @@ func WithResultWriter(writer ResultWriter) HandlerOption {
 	}
 }
 
+// WithDetailsAuthorizer sets a function that decides per request whether the response may contain
+// all information of the health check result (such as check details and info values). If the function
+// returns false, the response only contains the aggregated status. Example: { "status":"down" }.
+// This allows to expose details only to internal callers (e.g., from a private network or authenticated).
+// By default, all callers receive all information.
+func WithDetailsAuthorizer(authorizer func(r *http.Request) bool) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.authorizer = authorizer
+	}
+}
+
+// WithResponseSigner signs each health response with an HMAC-SHA256 of the response body using the given key.
+// The signature is set in the SignatureHeader, so that consumers can verify that the response was created by
+// a service that knows the key and was not tampered with (see VerifyResponseSignature). Responses are buffered
+// to compute the signature before they are written.
+func WithResponseSigner(key []byte) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.signingKey = key
+	}
+}
+
+// WithResponseCache makes the handler serve the complete HTTP response (status code, headers and body) from
+// a cache for the given duration. Within this duration, Checker.Check, the middleware and the ResultWriter are
+// not called at all, which sheds the cost of aggregation and serialization if the request rate spikes (e.g.,
+// when many probes hit the endpoint at the same time). Concurrent requests for an expired response wait for
+// the response of a single request, which is not cancelled with that request. Failed responses are not cached.
+// Responses for callers with and without access to details (see WithDetailsAuthorizer) are cached separately.
+// The expiry is measured with the Clock of the Checker (see WithClock). The duration should be short (e.g.,
+// 100 milliseconds), since the Checker has its own cache (see WithCacheDuration). By default, responses are
+// not cached.
+func WithResponseCache(ttl time.Duration) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.responseCacheTTL = ttl
+	}
+}
+
+// WithDeferredListeners defers the notification of all listeners (see WithStatusListener, Check.StatusListener
+// and WithEventHook) that are triggered by the handler (e.g., by a health check evaluation), until the response
+// was written. The listeners are then notified in a background goroutine in the order of the status changes,
+// so that slow listeners do not delay the response. The contexts passed to the listeners hold the values of the
+// original contexts, but are never cancelled. Transition publishers (e.g., WithMQTTPublisher) are always
+// notified in the background.
+func WithDeferredListeners() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.deferListeners = true
+	}
+}
+
+// WithMaxConcurrentHandlers limits the number of requests that the handler processes at the same time to protect
+// the checked dependencies against probe stampedes (e.g., if the cache is disabled, see WithDisabledCache).
+// Excess requests are rejected with 503 Service Unavailable and a Retry-After header, unless a queue timeout is
+// set (see WithHandlerQueueTimeout). Responses that are served from the response cache (see WithResponseCache)
+// do not count against the limit. By default, there is no limit.
+func WithMaxConcurrentHandlers(n int) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.maxConcurrent = n
+	}
+}
+
+// WithHandlerQueueTimeout makes requests that exceed the limit of WithMaxConcurrentHandlers wait for at most the
+// given duration until another request completes before they are rejected. By default, excess requests are
+// rejected immediately.
+func WithHandlerQueueTimeout(timeout time.Duration) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.queueTimeout = timeout
+	}
+}
+
+// WithResponseTiming adds the time it took to create the response to the ServerTimingHeader ("Server-Timing")
+// of each response, e.g., "aggregation;dur=1.234, serialization;dur=0.056" (in milliseconds). The aggregation
+// covers the middleware and the health check evaluation (see Checker.Check) and the serialization covers the
+// ResultWriter, including any compression it applies. This helps to diagnose slow health responses, e.g., in the
+// network panel of a browser. Responses are buffered to measure the serialization before they are written.
+// Cached responses (see WithResponseCache) hold the timing of their original creation.
+func WithResponseTiming() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.responseTiming = true
+	}
+}
+
 // WithDisabledAutostart disables automatic startup of a Checker instance.
 func WithDisabledAutostart() Option {
 	return func(cfg *checkerConfig) {
@@ -170,15 +526,17 @@ func WithCacheDuration(duration time.Duration) Option {
 // WithCheck adds a new health check that contributes to the overall service availability status.
 // This check will be triggered each time Checker.Check is called (i.e., for each HTTP request).
 // If health checks are expensive, or you expect a higher amount of requests on the health endpoint,
-// consider using WithPeriodicCheck instead.
-func WithCheck(check Check) Option {
+// consider using WithPeriodicCheck instead. The provided check options will be applied to the check.
+func WithCheck(check Check, options ...CheckOption) Option {
+	applyCheckOptions(&check, options)
 	return WithChecks(check)
 }
 
 // WithChecks adds a list of health checks that contribute to the overall service availability status.
 // These checks will be triggered each time Checker.Check is called (i.e., for each HTTP request).
 // If health checks are expensive, or you expect a higher amount of requests on the health endpoint,
-// consider using WithPeriodicCheck instead.
+// consider using WithPeriodicCheck instead. Checks that were made periodic (see WithInterval and CheckSet)
+// are registered as periodic checks.
 func WithChecks(checks ...Check) Option {
 	return func(cfg *checkerConfig) {
 		for i := range checks {
@@ -192,7 +550,17 @@ func WithChecks(checks ...Check) Option {
 // (as in contrast to WithCheck). This allows to process a much higher number of HTTP requests without
 // actually calling the checked services too often or to execute long-running checks.
 // This way Checker.Check (and the health endpoint) always returns the last result of the periodic check.
-func WithPeriodicCheck(refreshPeriod time.Duration, initialDelay time.Duration, check Check) Option {
+// Evaluations of a periodic check never overlap: if the previous evaluation is still running when the next
+// one is due, the next one is skipped (see CheckState.SkippedEvaluations).
+// The provided check options will be applied to the check.
+func WithPeriodicCheck(
+	refreshPeriod time.Duration,
+	initialDelay time.Duration,
+	check Check,
+	options ...CheckOption,
+) Option {
+	applyCheckOptions(&check, options)
+
 	return func(cfg *checkerConfig) {
 		check.updateInterval = refreshPeriod
 		check.initialDelay = initialDelay
@@ -234,6 +602,116 @@ func WithInfoFunc(infoFuncs ...func(info map[string]interface{})) Option {
 	}
 }
 
+// WithClock sets the Clock that is used by the Checker to determine the current time. Delayed notifications
+// are only scheduled with the Clock, if it implements TimerClock. By default, the system clock is used.
+func WithClock(clock Clock) Option {
+	return func(cfg *checkerConfig) {
+		cfg.clock = clock
+	}
+}
+
+// WithIDGenerator sets the function that generates IDs wherever the Checker creates them, such as the IDs
+// of evaluation cycles (see CycleIDFromContext). This allows to use a specific ID format (e.g., ULID) or
+// deterministic IDs in tests. By default, random 128-bit IDs in hexadecimal representation are generated.
+func WithIDGenerator(generator func() string) Option {
+	return func(cfg *checkerConfig) {
+		cfg.idGenerator = generator
+	}
+}
+
+// WithActiveWindow restricts the time in which failures of a check count. Outside the window, a failing
+// check still reports its error, but is considered to be available (see ReasonOutsideActiveWindow). Failures
+// outside the window reset the thresholds of the check (see Check.MaxContiguousFails and Check.MaxTimeInError),
+// so that they only count from the opening of the window on.
+// This is useful for dependencies that are only expected to be available during certain hours.
+// The window is evaluated with the Clock of the Checker (see WithClock).
+func WithActiveWindow(window ActiveWindow) CheckOption {
+	return func(check *Check) {
+		check.activeWindow = &window
+	}
+}
+
+// WithThresholds maps the value measured by Check.Value to an availability status: a value below warn is
+// considered up, a value between warn (inclusive) and crit (exclusive) is considered degraded (see StatusDegraded)
+// and a value of crit or above is considered a failure. Values that cross a threshold are reported with the reason
+// ReasonThresholdExceeded.
+func WithThresholds(warn, crit float64) CheckOption {
+	return func(check *Check) {
+		check.thresholds = &thresholds{warn: warn, crit: crit}
+	}
+}
+
+// WithGroup assigns a check to a group. Groups allow to configure settings that apply to all checks
+// of a group collectively, such as a time budget (see WithGroupBudget).
+func WithGroup(group string) CheckOption {
+	return func(check *Check) {
+		check.group = group
+	}
+}
+
+// WithGroupBudget sets a time budget for all checks of a group (see WithGroup). The evaluations of the
+// checks of the group in one cycle (i.e., one call of Checker.Check) together will not take longer than
+// the budget. This is useful for checks that probe the same dependency. Checks that exceed the budget fail
+// with ErrCheckTimeout. The budget only applies to synchronous checks: periodic checks (see WithPeriodicCheck)
+// are evaluated on their own schedules rather than together with the other checks of their group, so they
+// are only limited by their own timeouts (see Check.Timeout).
+func WithGroupBudget(group string, budget time.Duration) Option {
+	return func(cfg *checkerConfig) {
+		if cfg.groupBudgets == nil {
+			cfg.groupBudgets = map[string]time.Duration{}
+		}
+		cfg.groupBudgets[group] = budget
+	}
+}
+
+// WithTimeoutFraction derives the timeout of a periodic check (see WithPeriodicCheck) from its update interval,
+// e.g., a fraction of 0.8 results in a timeout of 8 seconds for an update interval of 10 seconds. This ensures
+// that a check cannot overrun its own schedule. An explicit timeout (see Check.Timeout) takes precedence.
+// The option has no effect on synchronous checks (see WithCheck).
+func WithTimeoutFraction(fraction float64) CheckOption {
+	return func(check *Check) {
+		check.timeoutFraction = fraction
+	}
+}
+
+// WithExpectedStatus declares the status that a check is expected to report, e.g., for canary checks.
+// Each evaluation that results in another status is counted as a deviation (see CheckState.Deviations and
+// CheckState.DeviationRate), and the check details are flagged while the check deviates (see
+// CheckResult.Deviating). The expected status does not affect the status of the check itself.
+func WithExpectedStatus(status AvailabilityStatus) CheckOption {
+	return func(check *Check) {
+		check.expectedStatus = status
+	}
+}
+
+// effectiveTimeout returns the timeout of the check, considering the timeout fraction (see WithTimeoutFraction).
+func (check *Check) effectiveTimeout() time.Duration {
+	if check.Timeout > 0 || check.timeoutFraction <= 0 || !isPeriodicCheck(check) {
+		return check.Timeout
+	}
+
+	return time.Duration(check.timeoutFraction * float64(check.updateInterval))
+}
+
+// WithSoftDependsOn declares soft dependencies of a check on the checks with the given names. In contrast to
+// a failure of the check itself, a failure of a soft dependency does not make the check fail: the check is still
+// evaluated, but if it is up while one of its soft dependencies is down, it is considered degraded
+// (see ReasonDependencyDown). The check returns to its own status as soon as all soft dependencies are no
+// longer down. Names of checks that do not exist are ignored.
+func WithSoftDependsOn(names ...string) CheckOption {
+	return func(check *Check) {
+		check.softDependsOn = append(check.softDependsOn, names...)
+	}
+}
+
+func applyCheckOptions(check *Check, options []CheckOption) {
+	for _, opt := range options {
+		if opt != nil {
+			opt(check)
+		}
+	}
+}
+
 // WithGRPCServerChecker creates a health check for a gRPC server.
 func WithGRPCServerChecker(grpcCfg commoncfg.GRPCClient) Option {
 	return WithCheck(Check{
@@ -255,7 +733,8 @@ func WithDatabaseChecker(driverName, dataSourceName string) Option {
 		Check: func(ctx context.Context) (err error) {
 			conn, err := sql.Open(driverName, dataSourceName)
 			if err != nil {
-				return fmt.Errorf("%s health check failed on connect: %w", driverName, err)
+				return ErrorWithReason(ReasonConnectFailed,
+					fmt.Errorf("%s health check failed on connect: %w", driverName, err))
 			}
 
 			defer func(conn *sql.DB) {
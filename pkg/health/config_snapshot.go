@@ -0,0 +1,264 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces the values of configuration entries that are considered
+// secret in a configuration snapshot (see ConfigSnapshotter.ConfigSnapshot).
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeyFragments holds the (lower case) key fragments that mark a value as secret.
+var sensitiveKeyFragments = []string{"password", "passwd", "secret", "token", "credential", "apikey", "api_key", "dsn"}
+
+// ConfigSnapshot implements ConfigSnapshotter.ConfigSnapshot. Please refer to ConfigSnapshotter.ConfigSnapshot for more information.
+func (ck *defaultChecker) ConfigSnapshot() map[string]any {
+	// The info map is refreshed while results are created, so reading it requires the mutex lock.
+	ck.mtx.Lock()
+	info := redactInfo(ck.cfg.info)
+	ck.mtx.Unlock()
+
+	cfg := &ck.cfg
+
+	checkNames := make([]string, 0, len(cfg.checks))
+	for name := range cfg.checks {
+		checkNames = append(checkNames, name)
+	}
+	sort.Strings(checkNames)
+
+	checks := make(map[string]any, len(cfg.checks))
+	for _, name := range checkNames {
+		checks[name] = checkConfigSnapshot(cfg.checks[name])
+	}
+
+	groupBudgets := make(map[string]string, len(cfg.groupBudgets))
+	for group, budget := range cfg.groupBudgets {
+		groupBudgets[group] = budget.String()
+	}
+
+	snapshot := map[string]any{
+		"cacheTTL":            cfg.cacheTTL.String(),
+		"timeout":             cfg.timeout.String(),
+		"autostart":           !cfg.autostartDisabled,
+		"details":             !cfg.detailsDisabled,
+		"statusCounts":        cfg.statusCountsEnabled,
+		"statusListener":      cfg.statusChangeListener != nil,
+		"batchedTransitions":  cfg.transitionsListener != nil,
+		"transitionWindow":    cfg.transitionWindow.String(),
+		"listenerCoolDown":    cfg.listenerCoolDown.String(),
+		"historySize":         cfg.historySize,
+		"maxErrorLength":      cfg.maxErrorLength,
+		"minUptime":           cfg.minUptime.String(),
+		"unknownTimeout":      cfg.unknownTimeout.String(),
+		"workerPoolSize":      cfg.workerPoolSize,
+		"statsInResult":       cfg.statsInResult,
+		"selfCheck":           cfg.selfCheckEnabled,
+		"panicQuarantine":     cfg.panicQuarantine,
+		"targetDeduplication": cfg.targetDeduplication,
+		"eventHook":           cfg.eventHook != nil,
+		"onReady":             cfg.onReady != nil,
+		"nilCheckFuncsAsUp":   cfg.nilCheckFuncsAsUp,
+		"resultValidator":     cfg.resultValidator != nil,
+		"readiness":           cfg.readinessExpression,
+		"statusPrecedence":    statusNames(cfg.statusPrecedence),
+		"aggregationWindow":   cfg.aggregationWindow.String(),
+		"groupBudgets":        groupBudgets,
+		"dependencyLimits":    cfg.dependencyLimits,
+		"interceptors":        interceptorNames(cfg.interceptors),
+		"aggregator":          funcName(cfg.aggregator),
+		"checks":              checks,
+		"info":                info,
+		"infoFuncs":           len(cfg.infoFuncs),
+		"clock":               fmt.Sprintf("%T", cfg.clock),
+	}
+
+	if cfg.flagProvider != nil {
+		snapshot["flagProvider"] = fmt.Sprintf("%T", cfg.flagProvider)
+	}
+
+	if hints := cfg.effectiveProbeHints(); hints != nil {
+		snapshot["probeHints"] = map[string]any{
+			"interval":      hints.Interval.String(),
+			"timeout":       hints.Timeout.String(),
+			"livenessPath":  hints.LivenessPath,
+			"readinessPath": hints.ReadinessPath,
+		}
+	}
+
+	if cfg.partition != nil {
+		snapshot["partition"] = map[string]any{
+			"total": cfg.partition.total,
+			"index": cfg.partition.index,
+		}
+	}
+
+	if cfg.stateStore != nil {
+		snapshot["stateStore"] = fmt.Sprintf("%T", cfg.stateStore)
+	}
+
+	return snapshot
+}
+
+func checkConfigSnapshot(check *Check) map[string]any {
+	snapshot := map[string]any{
+		"periodic":           isPeriodicCheck(check),
+		"timeout":            check.Timeout.String(),
+		"maxTimeInError":     check.MaxTimeInError.String(),
+		"maxContiguousFails": check.MaxContiguousFails,
+		"statusListener":     check.StatusListener != nil,
+		"interceptors":       interceptorNames(check.Interceptors),
+		"pipeline":           stageNames(check.pipeline),
+		"panicRecovery":      !check.DisablePanicRecovery,
+		"panicHandler":       check.PanicHandler != nil,
+		"valueCheck":         check.Check == nil && check.Value != nil,
+		"updateInterval":     check.updateInterval.String(),
+		"initialDelay":       check.initialDelay.String(),
+		"group":              check.group,
+		"dependency":         check.Dependency,
+		"canary":             check.canary,
+		"tags":               check.tags,
+		"activeWindow":       nil,
+		"thresholds":         nil,
+		"retry":              nil,
+		"errorRate":          nil,
+	}
+
+	if check.activeWindow != nil {
+		loc := time.UTC
+		if check.activeWindow.Location != nil {
+			loc = check.activeWindow.Location
+		}
+
+		days := make([]string, 0, len(check.activeWindow.Days))
+		for _, day := range check.activeWindow.Days {
+			days = append(days, day.String())
+		}
+
+		snapshot["activeWindow"] = map[string]any{
+			"location": loc.String(),
+			"days":     days,
+			"start":    check.activeWindow.Start.String(),
+			"end":      check.activeWindow.End.String(),
+		}
+	}
+
+	if check.thresholds != nil {
+		snapshot["thresholds"] = map[string]any{
+			"warn": check.thresholds.warn,
+			"crit": check.thresholds.crit,
+		}
+	}
+
+	if check.retry != nil {
+		snapshot["retry"] = map[string]any{
+			"maxAttempts":     check.retry.maxAttempts,
+			"backoff":         check.retry.backoff.String(),
+			"backoffStrategy": funcName(check.retry.backoffStrategy),
+			"conditional":     check.retry.retryIf != nil,
+			"attemptTimeout":  check.retry.attemptTimeout.String(),
+		}
+	}
+
+	if check.errorRate != nil {
+		snapshot["errorRate"] = map[string]any{
+			"window":         check.errorRate.window.String(),
+			"maxRate":        check.errorRate.maxRate,
+			"minEvaluations": check.errorRate.minEvaluations,
+		}
+	}
+
+	return snapshot
+}
+
+// stageNames returns the function names of the given pipeline stages (see WithPipeline).
+func stageNames(stages []CheckStage) []string {
+	names := make([]string, 0, len(stages))
+
+	for _, stage := range stages {
+		names = append(names, funcName(stage))
+	}
+
+	return names
+}
+
+// interceptorNames returns the function names of the given interceptors (e.g., "health.StatsDInterceptor.func1").
+func interceptorNames(interceptors []Interceptor) []string {
+	names := make([]string, 0, len(interceptors))
+
+	for _, interceptor := range interceptors {
+		names = append(names, funcName(interceptor))
+	}
+
+	return names
+}
+
+// funcName returns the package-qualified name of a function (e.g., "health.WorstStatusAggregator").
+func funcName(f any) string {
+	value := reflect.ValueOf(f)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return "<nil>"
+	}
+
+	name := runtime.FuncForPC(value.Pointer()).Name()
+
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// redactInfo returns a copy of the info map where the values of all secret entries
+// (see sensitiveKeyFragments) are replaced with RedactedValue.
+func redactInfo(info map[string]interface{}) map[string]any {
+	redacted := make(map[string]any, len(info))
+
+	for key, value := range info {
+		if isSensitiveKey(key) {
+			value = RedactedValue
+		}
+
+		redacted[key] = value
+	}
+
+	return redacted
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewConfigSnapshotHandler creates an http.Handler that responds with the effective configuration
+// of the checker in JSON format (see ConfigSnapshotter.ConfigSnapshot). It is meant for diagnostics endpoints
+// and should only be exposed to trusted callers. If the checker does not implement ConfigSnapshotter, all
+// requests are answered with 501 Not Implemented.
+func NewConfigSnapshotHandler(checker Checker) http.HandlerFunc {
+	snapshotter, ok := checker.(ConfigSnapshotter)
+	if !ok {
+		return notImplementedHandler
+	}
+
+	return func(w http.ResponseWriter, _ *http.Request) {
+		body, err := json.Marshal(snapshotter.ConfigSnapshot())
+		if err != nil {
+			http.Error(w, "cannot marshal config snapshot: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		disableResponseCache(w)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+}
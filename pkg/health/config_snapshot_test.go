@@ -0,0 +1,93 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestConfigSnapshot(t *testing.T) {
+	// Arrange
+	noop := func(ctx context.Context) error { return nil }
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCacheDuration(5*time.Second),
+		health.WithTimeout(3*time.Second),
+		health.WithStatusCounts(),
+		health.WithInterceptors(health.StatsDInterceptor(&fakeStatsDClient{})),
+		health.WithInfo(map[string]interface{}{"version": "1.2.3", "dbPassword": "s3cr3t", "API_TOKEN": "abc"}),
+		health.WithCheck(health.Check{Name: "sync", Check: noop, Timeout: time.Second}, health.WithRetry(3, 10*time.Millisecond)),
+		health.WithPeriodicCheck(time.Minute, 5*time.Second, health.Check{Name: "periodic", Check: noop, MaxContiguousFails: 2}),
+	)
+
+	// Act
+	snapshot := ckr.(health.ConfigSnapshotter).ConfigSnapshot()
+
+	// Assert
+	assert.Equal(t, "5s", snapshot["cacheTTL"])
+	assert.Equal(t, "3s", snapshot["timeout"])
+	assert.Equal(t, false, snapshot["autostart"])
+	assert.Equal(t, true, snapshot["statusCounts"])
+
+	interceptors, ok := snapshot["interceptors"].([]string)
+	require.True(t, ok)
+	require.Len(t, interceptors, 1)
+	assert.Contains(t, interceptors[0], "health.StatsDInterceptor")
+
+	info, ok := snapshot["info"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "1.2.3", info["version"])
+	assert.Equal(t, health.RedactedValue, info["dbPassword"])
+	assert.Equal(t, health.RedactedValue, info["API_TOKEN"])
+
+	checks, ok := snapshot["checks"].(map[string]any)
+	require.True(t, ok)
+	require.Len(t, checks, 2)
+
+	syncCheck, ok := checks["sync"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, false, syncCheck["periodic"])
+	assert.Equal(t, "1s", syncCheck["timeout"])
+	assert.Equal(t, map[string]any{"maxAttempts": uint(3), "backoff": "10ms", "backoffStrategy": "<nil>", "conditional": false, "attemptTimeout": "0s"}, syncCheck["retry"])
+
+	periodicCheck, ok := checks["periodic"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, true, periodicCheck["periodic"])
+	assert.Equal(t, "1m0s", periodicCheck["updateInterval"])
+	assert.Equal(t, "5s", periodicCheck["initialDelay"])
+	assert.Equal(t, uint(2), periodicCheck["maxContiguousFails"])
+}
+
+func TestConfigSnapshotHandler(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInfo(map[string]interface{}{"secret": "value"}),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := health.NewConfigSnapshotHandler(ckr)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/health/config", nil)
+
+	// Act
+	handler.ServeHTTP(response, request)
+
+	// Assert
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.NotContains(t, response.Body.String(), `"value"`)
+
+	var snapshot map[string]any
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &snapshot))
+	assert.Equal(t, "1s", snapshot["cacheTTL"])
+	assert.Contains(t, snapshot["checks"], "check")
+	assert.Equal(t, map[string]any{"secret": health.RedactedValue}, snapshot["info"])
+}
@@ -105,12 +105,34 @@ func TestWithDisabledDetailsConfig(t *testing.T) {
 	assert.True(t, cfg.detailsDisabled)
 }
 
+func TestWithMaxErrorLengthConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithMaxErrorLength(100)(&cfg)
+
+	// Assert
+	assert.Equal(t, 100, cfg.maxErrorLength)
+}
+
+func TestWithStatusCountsConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithStatusCounts()(&cfg)
+
+	// Assert
+	assert.True(t, cfg.statusCountsEnabled)
+}
+
 func TestWithMiddlewareConfig(t *testing.T) {
 	// Arrange
 	cfg := HandlerConfig{}
 	mw := func(MiddlewareFunc) MiddlewareFunc {
 		return func(r *http.Request) Result {
-			return Result{nil, StatusUp, nil}
+			return Result{Status: StatusUp}
 		}
 	}
 
@@ -149,6 +171,50 @@ func TestWithResultWriterConfig(t *testing.T) {
 	assert.Equal(t, &w, cfg.resultWriter)
 }
 
+func TestWithDetailsAuthorizerConfig(t *testing.T) {
+	// Arrange
+	cfg := HandlerConfig{}
+
+	// Act
+	WithDetailsAuthorizer(func(r *http.Request) bool { return true })(&cfg)
+
+	// Assert
+	assert.NotNil(t, cfg.authorizer)
+}
+
+func TestWithResponseSignerConfig(t *testing.T) {
+	// Arrange
+	cfg := HandlerConfig{}
+
+	// Act
+	WithResponseSigner([]byte("key"))(&cfg)
+
+	// Assert
+	assert.Equal(t, []byte("key"), cfg.signingKey)
+}
+
+func TestWithResponseCacheConfig(t *testing.T) {
+	// Arrange
+	cfg := HandlerConfig{}
+
+	// Act
+	WithResponseCache(time.Second)(&cfg)
+
+	// Assert
+	assert.Equal(t, time.Second, cfg.responseCacheTTL)
+}
+
+func TestWithDeferredListenersConfig(t *testing.T) {
+	// Arrange
+	cfg := HandlerConfig{}
+
+	// Act
+	WithDeferredListeners()(&cfg)
+
+	// Assert
+	assert.True(t, cfg.deferListeners)
+}
+
 func TestWithStatusChangeListenerConfig(t *testing.T) {
 	// Arrange
 	cfg := checkerConfig{}
@@ -162,6 +228,229 @@ func TestWithStatusChangeListenerConfig(t *testing.T) {
 	// Not possible in Go to compare functions.
 }
 
+func TestWithAggregatorConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithAggregator(func(map[string]CheckState) AvailabilityStatus { return StatusDegraded })(&cfg)
+
+	// Assert
+	require.NotNil(t, cfg.aggregator)
+	assert.Equal(t, StatusDegraded, cfg.aggregator(nil))
+}
+
+func TestWithMinUptimeConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithMinUptime(time.Minute)(&cfg)
+
+	// Assert
+	assert.Equal(t, time.Minute, cfg.minUptime)
+}
+
+func TestWithWorkerPoolConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithWorkerPool(8)(&cfg)
+
+	// Assert
+	assert.Equal(t, 8, cfg.workerPoolSize)
+}
+
+func TestWithStatsInResultConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithStatsInResult()(&cfg)
+
+	// Assert
+	assert.True(t, cfg.statsInResult)
+}
+
+func TestWithSelfCheckConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithSelfCheck()(&cfg)
+
+	// Assert
+	assert.True(t, cfg.selfCheckEnabled)
+}
+
+func TestWithResultValidatorConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+	validator := func(state CheckState) (CheckState, error) { return state, nil }
+
+	// Act
+	WithResultValidator(validator)(&cfg)
+
+	// Assert
+	assert.NotNil(t, cfg.resultValidator)
+}
+
+func TestWithReadinessExpressionConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithReadinessExpression("db AND cache")(&cfg)
+
+	// Assert
+	assert.Equal(t, "db AND cache", cfg.readinessExpression)
+}
+
+func TestWithListenerCoolDownConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithListenerCoolDown(5 * time.Second)(&cfg)
+
+	// Assert
+	assert.Equal(t, 5*time.Second, cfg.listenerCoolDown)
+}
+
+func TestWithClockConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+	clock := systemClock{}
+
+	// Act
+	WithClock(clock)(&cfg)
+
+	// Assert
+	assert.Equal(t, clock, cfg.clock)
+}
+
+func TestWithActiveWindowCheckOption(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{checks: map[string]*Check{}}
+	window := ActiveWindow{Start: 8 * time.Hour, End: 18 * time.Hour}
+
+	// Act
+	WithCheck(Check{Name: "test"}, WithActiveWindow(window))(&cfg)
+
+	// Assert
+	require.NotNil(t, cfg.checks["test"].activeWindow)
+	assert.Equal(t, window, *cfg.checks["test"].activeWindow)
+}
+
+func TestWithThresholdsCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{Name: "test"}
+
+	// Act
+	WithThresholds(1, 2)(&check)
+
+	// Assert
+	require.NotNil(t, check.thresholds)
+	assert.InDelta(t, 1.0, check.thresholds.warn, 0)
+	assert.InDelta(t, 2.0, check.thresholds.crit, 0)
+}
+
+func TestWithSoftDependsOnCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{Name: "test"}
+
+	// Act
+	WithSoftDependsOn("db")(&check)
+	WithSoftDependsOn("cache", "queue")(&check)
+
+	// Assert
+	assert.Equal(t, []string{"db", "cache", "queue"}, check.softDependsOn)
+}
+
+func TestWithExpectedStatusCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{Name: "test"}
+
+	// Act
+	WithExpectedStatus(StatusUp)(&check)
+
+	// Assert
+	assert.Equal(t, StatusUp, check.expectedStatus)
+}
+
+func TestWithRetryBackoffCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{Name: "test"}
+
+	// Act
+	WithRetryBackoff(ConstantBackoff(time.Second))(&check)
+
+	// Assert
+	require.NotNil(t, check.retry)
+	assert.Equal(t, uint(1), check.retry.maxAttempts)
+	assert.Equal(t, time.Second, check.retry.delay(1))
+}
+
+func TestWithObservabilityTagsCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{Name: "test"}
+	tags := map[string]string{"team": "payments"}
+
+	// Act
+	WithObservabilityTags(tags)(&check)
+	tags["team"] = "modified"
+
+	// Assert
+	assert.Equal(t, map[string]string{"team": "payments"}, check.tags)
+}
+
+func TestWithHistoryConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithHistory(100)(&cfg)
+
+	// Assert
+	assert.Equal(t, 100, cfg.historySize)
+}
+
+func TestWithGroupBudgetConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{checks: map[string]*Check{}}
+
+	// Act
+	WithGroupBudget("db", time.Second)(&cfg)
+	WithCheck(Check{Name: "test"}, WithGroup("db"))(&cfg)
+
+	// Assert
+	assert.Equal(t, map[string]time.Duration{"db": time.Second}, cfg.groupBudgets)
+	assert.Equal(t, "db", cfg.checks["test"].group)
+}
+
+func TestWithWindowedAggregationConfig(t *testing.T) {
+	// Arrange + Act
+	checker := NewChecker(WithDisabledAutostart(), WithWindowedAggregation(time.Minute))
+
+	// Assert
+	ckr, _ := checker.(*defaultChecker)
+	assert.Equal(t, time.Minute, ckr.cfg.aggregationWindow)
+	assert.Equal(t, defaultWindowedAggregationHistorySize, ckr.cfg.historySize)
+}
+
+func TestWithIDGeneratorConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithIDGenerator(func() string { return "id" })(&cfg)
+
+	// Assert
+	require.NotNil(t, cfg.idGenerator)
+	assert.Equal(t, "id", cfg.idGenerator())
+}
+
 func TestNewWithDefaults(t *testing.T) {
 	// Arrange
 	configApplied := false
@@ -210,7 +499,7 @@ func TestCheckerAutostartDisabledConfig(t *testing.T) {
 
 func TestWithChecks(t *testing.T) {
 	// Arrange
-	check := Check{Name: "test"}
+	check := Check{Name: "test", Check: func(ctx context.Context) error { return nil }}
 
 	// Act
 	checker := NewChecker(WithChecks(check))
@@ -220,3 +509,287 @@ func TestWithChecks(t *testing.T) {
 	assert.Len(t, ckr.cfg.checks, 1)
 	assert.Contains(t, ckr.cfg.checks, check.Name)
 }
+
+func TestWithTimeoutFractionCheckOption(t *testing.T) {
+	tests := []struct {
+		name            string
+		check           Check
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "DerivedFromInterval",
+			check:           Check{Name: "test"},
+			expectedTimeout: 8 * time.Second,
+		},
+		{
+			name:            "ExplicitTimeoutOverrides",
+			check:           Check{Name: "test", Timeout: time.Second},
+			expectedTimeout: time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			cfg := checkerConfig{checks: map[string]*Check{}}
+
+			// Act
+			WithPeriodicCheck(10*time.Second, 0, tc.check, WithTimeoutFraction(0.8))(&cfg)
+
+			// Assert
+			assert.InDelta(t, 0.8, cfg.checks["test"].timeoutFraction, 0)
+			assert.Equal(t, tc.expectedTimeout, cfg.checks["test"].effectiveTimeout())
+		})
+	}
+}
+
+func TestWithTimeoutFractionHasNoEffectOnSynchronousChecks(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{checks: map[string]*Check{}}
+
+	// Act
+	WithCheck(Check{Name: "test"}, WithTimeoutFraction(0.8))(&cfg)
+
+	// Assert
+	assert.Equal(t, time.Duration(0), cfg.checks["test"].effectiveTimeout())
+}
+
+func TestWithStatusPrecedenceConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+	order := []AvailabilityStatus{StatusDown, StatusUnknown, StatusDegraded, StatusUp}
+
+	// Act
+	WithStatusPrecedence(order)(&cfg)
+	order[0] = StatusUp
+
+	// Assert
+	assert.Equal(t, []AvailabilityStatus{StatusDown, StatusUnknown, StatusDegraded, StatusUp}, cfg.statusPrecedence)
+}
+
+func TestWithPerAttemptTimeoutCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{Name: "test"}
+
+	// Act
+	WithPerAttemptTimeout(time.Second)(&check)
+
+	// Assert
+	require.NotNil(t, check.retry)
+	assert.Equal(t, uint(1), check.retry.maxAttempts)
+	assert.Equal(t, time.Second, check.retry.attemptTimeout)
+}
+
+func TestWithMaxConcurrentHandlersConfig(t *testing.T) {
+	// Arrange
+	cfg := HandlerConfig{}
+
+	// Act
+	WithMaxConcurrentHandlers(4)(&cfg)
+
+	// Assert
+	assert.Equal(t, 4, cfg.maxConcurrent)
+}
+
+func TestWithHandlerQueueTimeoutConfig(t *testing.T) {
+	// Arrange
+	cfg := HandlerConfig{}
+
+	// Act
+	WithHandlerQueueTimeout(time.Second)(&cfg)
+
+	// Assert
+	assert.Equal(t, time.Second, cfg.queueTimeout)
+}
+
+func TestWithStateStoreConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+	store := NewFileStateStore("health.json")
+
+	// Act
+	WithStateStore(store)(&cfg)
+
+	// Assert
+	assert.Equal(t, store, cfg.stateStore)
+}
+
+func TestWithErrorRateThresholdCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{Name: "test"}
+
+	// Act
+	WithErrorRateThreshold(time.Minute, 0.25, 10)(&check)
+
+	// Assert
+	assert.Equal(t, &errorRatePolicy{window: time.Minute, maxRate: 0.25, minEvaluations: 10}, check.errorRate)
+}
+
+func TestWithProbeHintsConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+	hints := ProbeHints{Interval: 10 * time.Second, LivenessPath: "/livez"}
+
+	// Act
+	WithProbeHints(hints)(&cfg)
+
+	// Assert
+	assert.Equal(t, &hints, cfg.probeHints)
+}
+
+func TestWithCheckPartitionConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithCheckPartition(4, 1)(&cfg)
+
+	// Assert
+	assert.Equal(t, &checkPartition{total: 4, index: 1}, cfg.partition)
+}
+
+func TestWithPanicQuarantineConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithPanicQuarantine(3)(&cfg)
+
+	// Assert
+	assert.Equal(t, uint(3), cfg.panicQuarantine)
+}
+
+func TestWithResponseTimingConfig(t *testing.T) {
+	// Arrange
+	cfg := HandlerConfig{}
+
+	// Act
+	WithResponseTiming()(&cfg)
+
+	// Assert
+	assert.True(t, cfg.responseTiming)
+}
+
+func TestWithTargetCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{}
+
+	// Act
+	WithTarget("db:5432")(&check)
+
+	// Assert
+	assert.Equal(t, "db:5432", check.target)
+}
+
+func TestWithTargetDeduplicationConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithTargetDeduplication()(&cfg)
+
+	// Assert
+	assert.True(t, cfg.targetDeduplication)
+}
+
+func TestWithCanaryCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{}
+
+	// Act
+	WithCanary()(&check)
+
+	// Assert
+	assert.True(t, check.canary)
+}
+
+func TestWithEventHookConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithEventHook(func(ctx context.Context, event Event) {})(&cfg)
+
+	// Assert
+	assert.NotNil(t, cfg.eventHook)
+}
+
+func TestWithDependencyConcurrencyConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithDependencyConcurrency("postgres", 2)(&cfg)
+	WithDependencyConcurrency("redis", 1)(&cfg)
+
+	// Assert
+	assert.Equal(t, map[string]int{"postgres": 2, "redis": 1}, cfg.dependencyLimits)
+}
+
+func TestWithOnReadyConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithOnReady(func(time.Duration) {})(&cfg)
+
+	// Assert
+	assert.NotNil(t, cfg.onReady)
+}
+
+func TestWithNilCheckFuncsAsUpConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithNilCheckFuncsAsUp()(&cfg)
+
+	// Assert
+	assert.True(t, cfg.nilCheckFuncsAsUp)
+}
+
+func TestWithPipelineCheckOption(t *testing.T) {
+	// Arrange
+	check := Check{}
+	stage := func(next CheckStageFunc) CheckStageFunc { return next }
+
+	// Act
+	WithPipeline(stage, stage)(&check)
+	WithPipeline(stage)(&check)
+
+	// Assert
+	assert.Len(t, check.pipeline, 3)
+}
+
+func TestWithUnknownTimeoutConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithUnknownTimeout(time.Minute)(&cfg)
+
+	// Assert
+	assert.Equal(t, time.Minute, cfg.unknownTimeout)
+}
+
+func TestWithBatchedTransitionsConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithBatchedTransitions(func(context.Context, []CheckTransition) {})(&cfg)
+
+	// Assert
+	assert.NotNil(t, cfg.transitionsListener)
+}
+
+func TestWithTransitionBatchWindowConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithTransitionBatchWindow(time.Minute)(&cfg)
+
+	// Assert
+	assert.Equal(t, time.Minute, cfg.transitionWindow)
+}
@@ -0,0 +1,33 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrContainerNotRunning is reported by a ContainerCheck if the target container is not running.
+var ErrContainerNotRunning = errors.New("container not running")
+
+// ContainerCheck creates a check for a container (e.g., a sidecar managed by Docker or containerd). The inspector
+// reports whether the target container is running, which keeps the dependency to the container runtime out of
+// this package (e.g., by calling ContainerInspect of the Docker client). The check succeeds only if the container
+// is running. Otherwise, it fails with ErrContainerNotRunning (see ReasonUnavailable). Errors of the inspector
+// are reported as check errors.
+func ContainerCheck(name string, inspector func(ctx context.Context) (running bool, err error)) Check {
+	return Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			running, err := inspector(ctx)
+			if err != nil {
+				return fmt.Errorf("cannot inspect container: %w", err)
+			}
+
+			if !running {
+				return ErrorWithReason(ReasonUnavailable, ErrContainerNotRunning)
+			}
+
+			return nil
+		},
+	}
+}
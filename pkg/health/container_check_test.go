@@ -0,0 +1,49 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestContainerCheck(t *testing.T) {
+	errDaemon := errors.New("cannot connect to the Docker daemon")
+
+	tests := []struct {
+		name           string
+		running        bool
+		inspectErr     error
+		expectedErr    error
+		expectedReason string
+	}{
+		{name: "running", running: true},
+		{name: "stopped", expectedErr: health.ErrContainerNotRunning, expectedReason: health.ReasonUnavailable},
+		{name: "inspection failed", inspectErr: errDaemon, expectedErr: errDaemon, expectedReason: health.ReasonError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			check := health.ContainerCheck("sidecar", func(ctx context.Context) (bool, error) {
+				return tt.running, tt.inspectErr
+			})
+
+			// Act
+			err := check.Check(t.Context())
+
+			// Assert
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedReason, health.ReasonOf(err))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+
+	slogctx "github.com/veqryn/slog-context"
+)
+
+// DatadogServiceCheckStatus is the status of a Datadog service check.
+type DatadogServiceCheckStatus int
+
+const (
+	// DatadogOK is the service check status of checks with status StatusUp.
+	DatadogOK DatadogServiceCheckStatus = 0
+	// DatadogWarning is the service check status of checks with status StatusDegraded.
+	DatadogWarning DatadogServiceCheckStatus = 1
+	// DatadogCritical is the service check status of checks with status StatusDown.
+	DatadogCritical DatadogServiceCheckStatus = 2
+	// DatadogUnknown is the service check status of checks with status StatusUnknown (or any other status).
+	DatadogUnknown DatadogServiceCheckStatus = 3
+)
+
+// ServiceCheckSubmitter is the minimal interface of a Datadog client that is required by the DatadogInterceptor.
+// Its signature follows the ServiceCheck call of DogStatsD clients, so that the Datadog client library can be
+// wrapped in a small adapter.
+type ServiceCheckSubmitter interface {
+	// SubmitServiceCheck submits a single service check with the given name, status, message and tags
+	// (in the "key:value" format).
+	SubmitServiceCheck(name string, status DatadogServiceCheckStatus, message string, tags []string) error
+}
+
+// DatadogInterceptor creates an Interceptor that submits a Datadog service check "health.check.<name>" for each
+// check evaluation. The status of the check is mapped to the service check status (see DatadogServiceCheckStatus)
+// and the error of the check (if any) is used as message. Characters in the check name that are not allowed
+// in metric names are replaced by underscores. The observability tags of the check (see WithObservabilityTags)
+// are submitted in the "key:value" format.
+func DatadogInterceptor(submitter ServiceCheckSubmitter) Interceptor {
+	return func(next InterceptorFunc) InterceptorFunc {
+		return func(ctx context.Context, checkName string, state CheckState) CheckState {
+			result := next(ctx, checkName, state)
+
+			name := "health.check." + sanitizeStatsDName(checkName)
+			message := ""
+			if result.Result != nil {
+				message = result.Result.Error()
+			}
+
+			err := submitter.SubmitServiceCheck(name, datadogStatus(result.Status), message,
+				datadogTags(ObservabilityTagsFromContext(ctx)))
+			if err != nil {
+				slogctx.Error(ctx, "Failed to submit Datadog service check", "serviceCheck", name, "error", err)
+			}
+
+			return result
+		}
+	}
+}
+
+func datadogStatus(status AvailabilityStatus) DatadogServiceCheckStatus {
+	switch status {
+	case StatusUp:
+		return DatadogOK
+	case StatusDegraded:
+		return DatadogWarning
+	case StatusDown:
+		return DatadogCritical
+	default:
+		return DatadogUnknown
+	}
+}
+
+// datadogTags formats the tags in the "key:value" format in ascending order of their keys.
+// It returns nil if there are no tags.
+func datadogTags(tags map[string]string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for _, key := range sortedTagKeys(tags) {
+		pairs = append(pairs, sanitizeStatsDTag(key)+":"+sanitizeStatsDTag(tags[key]))
+	}
+
+	return pairs
+}
@@ -0,0 +1,142 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type serviceCheck struct {
+	name    string
+	status  health.DatadogServiceCheckStatus
+	message string
+	tags    []string
+}
+
+type fakeServiceCheckSubmitter struct {
+	mtx    sync.Mutex
+	checks map[string]serviceCheck
+	err    error
+}
+
+func (s *fakeServiceCheckSubmitter) SubmitServiceCheck(
+	name string, status health.DatadogServiceCheckStatus, message string, tags []string,
+) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.checks == nil {
+		s.checks = map[string]serviceCheck{}
+	}
+	s.checks[name] = serviceCheck{name: name, status: status, message: message, tags: tags}
+
+	return s.err
+}
+
+func TestDatadogInterceptor(t *testing.T) {
+	// Arrange
+	submitter := &fakeServiceCheckSubmitter{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.DatadogInterceptor(submitter)),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+		health.WithCheck(health.Check{
+			Name:  "replica",
+			Check: func(ctx context.Context) error { return fmt.Errorf("lagging: %w", health.ErrDegraded) },
+		}),
+		health.WithCheck(health.Check{
+			Name:  "GRPC Server",
+			Check: func(ctx context.Context) error { return errors.New("unavailable") },
+		}),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Len(t, submitter.checks, 3)
+	assert.Equal(t, serviceCheck{name: "health.check.database", status: health.DatadogOK},
+		submitter.checks["health.check.database"])
+	assert.Equal(t, serviceCheck{name: "health.check.replica", status: health.DatadogWarning, message: "lagging: degraded"},
+		submitter.checks["health.check.replica"])
+	assert.Equal(t, serviceCheck{name: "health.check.GRPC_Server", status: health.DatadogCritical, message: "unavailable"},
+		submitter.checks["health.check.GRPC_Server"])
+}
+
+func TestDatadogInterceptorWithObservabilityTags(t *testing.T) {
+	// Arrange
+	submitter := &fakeServiceCheckSubmitter{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.DatadogInterceptor(submitter)),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}, health.WithObservabilityTags(map[string]string{"tier": "1", "team": "pay,ments"})),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Contains(t, submitter.checks, "health.check.database")
+	assert.Equal(t, []string{"team:pay_ments", "tier:1"}, submitter.checks["health.check.database"].tags)
+}
+
+func TestDatadogInterceptorWithUnknownStatus(t *testing.T) {
+	// Arrange
+	submitter := &fakeServiceCheckSubmitter{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(
+			health.DatadogInterceptor(submitter),
+			func(next health.InterceptorFunc) health.InterceptorFunc {
+				return func(ctx context.Context, checkName string, state health.CheckState) health.CheckState {
+					state.Status = health.StatusUnknown
+					return state
+				}
+			},
+		),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Contains(t, submitter.checks, "health.check.database")
+	assert.Equal(t, health.DatadogUnknown, submitter.checks["health.check.database"].status)
+}
+
+func TestDatadogInterceptorIgnoresSubmitterErrors(t *testing.T) {
+	// Arrange
+	submitter := &fakeServiceCheckSubmitter{err: errors.New("agent unreachable")}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.DatadogInterceptor(submitter)),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Len(t, submitter.checks, 1)
+}
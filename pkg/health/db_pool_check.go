@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// dbPoolSubResultName is the name of the sub-result that holds the pool statistics of a DBPoolCheck.
+const dbPoolSubResultName = "pool"
+
+// DBPoolCheck creates a check for the connection pool of a database (in contrast to a ping, see
+// WithDatabaseChecker, which may succeed while the pool is exhausted). The stats function is called with
+// each evaluation, e.g., (*sql.DB).Stats. The check is degraded if the share of connections in use reaches
+// maxInUsePct percent (e.g., 80) of the maximum number of open connections, and it is down if all connections
+// are in use. The pool statistics are always reported in the check details (as the message of the sub-result
+// "pool", see SubResult.Message). Pools without a limit of open connections are always considered available.
+// The percentage must be greater than 0 and at most 100, otherwise BuildChecker fails with ErrInvalidThreshold
+// (and NewChecker panics).
+func DBPoolCheck(name string, stats func() sql.DBStats, maxInUsePct float64) Check {
+	check := Check{
+		Name: name,
+		SubChecks: func(context.Context) []SubResult {
+			s := stats()
+			summary := dbPoolSummary(s)
+
+			return []SubResult{{Name: dbPoolSubResultName, Error: dbPoolError(s, maxInUsePct, summary), Message: summary}}
+		},
+	}
+
+	if maxInUsePct <= 0 || maxInUsePct > 100 {
+		check.configErr = fmt.Errorf("%w: maximum in-use percentage %v of check %s must be in (0, 100]",
+			ErrInvalidThreshold, maxInUsePct, name)
+	}
+
+	return check
+}
+
+func dbPoolSummary(s sql.DBStats) string {
+	if s.MaxOpenConnections <= 0 {
+		return fmt.Sprintf("%d connections in use (no limit), %d idle, %d waits for a connection",
+			s.InUse, s.Idle, s.WaitCount)
+	}
+
+	inUsePct := float64(s.InUse) / float64(s.MaxOpenConnections) * 100
+
+	return fmt.Sprintf("%d of %d connections in use (%.1f%%), %d idle, %d waits for a connection",
+		s.InUse, s.MaxOpenConnections, inUsePct, s.Idle, s.WaitCount)
+}
+
+func dbPoolError(s sql.DBStats, maxInUsePct float64, summary string) error {
+	if s.MaxOpenConnections <= 0 {
+		return nil
+	}
+
+	inUsePct := float64(s.InUse) / float64(s.MaxOpenConnections) * 100
+
+	switch {
+	case s.InUse >= s.MaxOpenConnections:
+		return ErrorWithReason(ReasonThresholdExceeded, fmt.Errorf("connection pool exhausted: %s", summary))
+	case inUsePct >= maxInUsePct:
+		return ErrorWithReason(ReasonThresholdExceeded,
+			fmt.Errorf("connection pool utilization exceeds %.1f%%: %s: %w", maxInUsePct, summary, ErrDegraded))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+package health_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestDBPoolCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		stats           sql.DBStats
+		expectedStatus  health.AvailabilityStatus
+		expectedError   string
+		expectedMessage string
+	}{
+		{
+			name:            "below threshold",
+			stats:           sql.DBStats{MaxOpenConnections: 10, InUse: 7, Idle: 3},
+			expectedStatus:  health.StatusUp,
+			expectedMessage: "7 of 10 connections in use (70.0%), 3 idle, 0 waits for a connection",
+		},
+		{
+			name:            "near threshold",
+			stats:           sql.DBStats{MaxOpenConnections: 10, InUse: 8, Idle: 2, WaitCount: 5},
+			expectedStatus:  health.StatusDegraded,
+			expectedError:   "pool: connection pool utilization exceeds 80.0%: 8 of 10 connections in use (80.0%), 2 idle, 5 waits for a connection: degraded",
+			expectedMessage: "8 of 10 connections in use (80.0%), 2 idle, 5 waits for a connection",
+		},
+		{
+			name:            "exhausted",
+			stats:           sql.DBStats{MaxOpenConnections: 10, InUse: 10, WaitCount: 42},
+			expectedStatus:  health.StatusDown,
+			expectedError:   "pool: connection pool exhausted: 10 of 10 connections in use (100.0%), 0 idle, 42 waits for a connection",
+			expectedMessage: "10 of 10 connections in use (100.0%), 0 idle, 42 waits for a connection",
+		},
+		{
+			name:            "unlimited",
+			stats:           sql.DBStats{InUse: 1000},
+			expectedStatus:  health.StatusUp,
+			expectedMessage: "1000 connections in use (no limit), 0 idle, 0 waits for a connection",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithCheck(health.DBPoolCheck("db-pool", func() sql.DBStats { return tt.stats }, 80)),
+			)
+
+			// Act
+			result := ckr.Check(t.Context())
+
+			// Assert
+			details := result.Details["db-pool"]
+			assert.Equal(t, tt.expectedStatus, details.Status)
+			assert.Equal(t, tt.expectedMessage, details.SubResults["pool"].Message)
+
+			if tt.expectedError == "" {
+				assert.NoError(t, details.Error)
+			} else {
+				require.Error(t, details.Error)
+				assert.Equal(t, tt.expectedError, details.Error.Error())
+				assert.Equal(t, health.ReasonThresholdExceeded, details.Reason)
+			}
+		})
+	}
+}
+
+func TestDBPoolCheckRejectsInvalidPercentage(t *testing.T) {
+	for _, pct := range []float64{0, -10, 100.5} {
+		// Act
+		_, err := health.BuildChecker(
+			health.WithDisabledAutostart(),
+			health.WithCheck(health.DBPoolCheck("db-pool", func() sql.DBStats { return sql.DBStats{} }, pct)),
+		)
+
+		// Assert
+		require.ErrorIs(t, err, health.ErrInvalidThreshold, pct)
+	}
+}
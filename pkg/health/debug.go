@@ -0,0 +1,215 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+)
+
+type (
+	// DebugResult is a verbose record of a single evaluation of a check (see CheckDebugger.DebugCheck).
+	DebugResult struct {
+		// Name is the name of the check.
+		Name string `json:"name"`
+		// Status is the status of the evaluation. It is derived from the error of the last attempt only,
+		// i.e., without applying the thresholds of the check (see Check.MaxContiguousFails).
+		Status AvailabilityStatus `json:"status"`
+		// Reason is the reason code of the error of the last attempt (see ReasonOf).
+		Reason string `json:"reason,omitempty"`
+		// Error is the untruncated error message of the last attempt.
+		Error string `json:"error,omitempty"`
+		// Timeout is the timeout that was applied to the evaluation, i.e., the shorter one of the timeout of the
+		// check and the global timeout (0 if there is none).
+		Timeout time.Duration `json:"timeout"`
+		// StartedAt holds the time of when the evaluation started.
+		StartedAt time.Time `json:"startedAt"`
+		// FinishedAt holds the time of when the evaluation finished.
+		FinishedAt time.Time `json:"finishedAt"`
+		// Duration is the time the evaluation took, including all retries.
+		Duration time.Duration `json:"duration"`
+		// Attempts holds all attempts of the evaluation in the order of their execution (see WithRetry).
+		Attempts []DebugAttempt `json:"attempts"`
+		// SubResults holds the results of the sub-checks of the last attempt (see Check.SubChecks).
+		SubResults map[string]CheckResult `json:"details,omitempty"`
+		// Tags holds the observability tags of the check (see WithObservabilityTags).
+		Tags map[string]string `json:"tags,omitempty"`
+	}
+
+	// DebugAttempt describes a single attempt of a debugged evaluation (see DebugResult).
+	DebugAttempt struct {
+		// Attempt is the number of the attempt, starting at 1.
+		Attempt int `json:"attempt"`
+		// StartedAt holds the time of when the attempt started.
+		StartedAt time.Time `json:"startedAt"`
+		// Duration is the time the attempt took.
+		Duration time.Duration `json:"duration"`
+		// Error is the untruncated error message of the attempt.
+		Error string `json:"error,omitempty"`
+		// Reason is the reason code of the error of the attempt (see ReasonOf).
+		Reason string `json:"reason,omitempty"`
+		// PanicStack holds the stack trace, if the check function panicked.
+		PanicStack string `json:"panicStack,omitempty"`
+	}
+
+	// debugRecorder records the attempts of a debugged evaluation.
+	debugRecorder struct {
+		clock    Clock
+		attempts []DebugAttempt
+	}
+)
+
+// MarshalJSON provides a custom marshaller for the DebugResult type that represents durations as strings.
+func (r DebugResult) MarshalJSON() ([]byte, error) {
+	type alias DebugResult
+
+	return json.Marshal(struct {
+		alias
+		Timeout  string `json:"timeout"`
+		Duration string `json:"duration"`
+	}{alias: alias(r), Timeout: r.Timeout.String(), Duration: r.Duration.String()})
+}
+
+// MarshalJSON provides a custom marshaller for the DebugAttempt type that represents durations as strings.
+func (a DebugAttempt) MarshalJSON() ([]byte, error) {
+	type alias DebugAttempt
+
+	return json.Marshal(struct {
+		alias
+		Duration string `json:"duration"`
+	}{alias: alias(a), Duration: a.Duration.String()})
+}
+
+// DebugCheck implements CheckDebugger.DebugCheck. Please refer to CheckDebugger.DebugCheck for more information.
+func (ck *defaultChecker) DebugCheck(ctx context.Context, name string) (DebugResult, error) {
+	check, ok := ck.cfg.checks[name]
+	if !ok {
+		return DebugResult{}, fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+	}
+
+	// Like Checker.Check, the evaluation adheres to the global timeout (see WithTimeout).
+	timeout := ck.cfg.timeout
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, ErrGlobalTimeout)
+		defer cancel()
+	}
+
+	if checkTimeout := check.effectiveTimeout(); checkTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+
+		if timeout <= 0 || checkTimeout < timeout {
+			timeout = checkTimeout
+		}
+	}
+
+	recorder := &debugRecorder{clock: ck.cfg.clock}
+	ctx = withObservabilityTags(ctx, check.tags)
+
+	// Panics are always recovered, so that a debug request cannot crash the process.
+	debugged := *check
+	debugged.DisablePanicRecovery = false
+
+	startedAt := ck.cfg.clock.Now().UTC()
+	outcome := executeCheckAttempts(ctx, &debugged, recorder.executeAttempt)
+	finishedAt := ck.cfg.clock.Now().UTC()
+
+	return DebugResult{
+		Name:       name,
+		Status:     subResultStatus(outcome.err),
+		Reason:     ReasonOf(outcome.err),
+		Error:      errorMessage(outcome.err),
+		Timeout:    timeout,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		Duration:   finishedAt.Sub(startedAt),
+		Attempts:   recorder.attempts,
+		SubResults: newSubCheckResults(outcome.subResults, finishedAt, 0),
+		Tags:       check.tags,
+	}, nil
+}
+
+// executeAttempt executes the check function once and records the attempt.
+func (dr *debugRecorder) executeAttempt(ctx context.Context, check *Check) checkOutcome {
+	startedAt := dr.clock.Now().UTC()
+	outcome := executeCheckFuncWithAttemptTimeout(ctx, check)
+
+	dr.attempts = append(dr.attempts, DebugAttempt{
+		Attempt:    len(dr.attempts) + 1,
+		StartedAt:  startedAt,
+		Duration:   dr.clock.Now().UTC().Sub(startedAt),
+		Error:      errorMessage(outcome.err),
+		Reason:     ReasonOf(outcome.err),
+		PanicStack: string(outcome.panicStack),
+	})
+
+	return outcome
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+// NewDebugHandler creates an http.Handler that evaluates a single check on demand with maximum verbosity
+// (see CheckDebugger.DebugCheck) and responds with the DebugResult in JSON format. It is meant to investigate
+// flaky checks and expects to be mounted with a pattern that holds the name of the check as a wildcard:
+//
+//	mux.Handle("POST /health/debug/{name}", health.NewDebugHandler(checker, authorizer))
+//
+// If the request does not hold a "name" path value, the last element of the URL path is used. Only POST
+// requests are accepted. Requests for which the authorizer returns false are rejected with 403 Forbidden.
+// If the authorizer is nil, all requests are rejected. If the checker does not implement CheckDebugger, all
+// requests are answered with 501 Not Implemented.
+func NewDebugHandler(checker Checker, authorizer func(r *http.Request) bool) http.HandlerFunc {
+	debugger, ok := checker.(CheckDebugger)
+	if !ok {
+		return notImplementedHandler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if authorizer == nil || !authorizer(r) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		name := r.PathValue("name")
+		if name == "" {
+			name = path.Base(r.URL.Path)
+		}
+
+		result, err := debugger.DebugCheck(r.Context(), name)
+		if errors.Is(err, ErrCheckNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, "cannot debug check: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		body, err := json.Marshal(result)
+		if err != nil {
+			http.Error(w, "cannot marshal debug result: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		disableResponseCache(w)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+}
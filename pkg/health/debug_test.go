@@ -0,0 +1,211 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func allowAll(*http.Request) bool { return true }
+
+func newDebugServer(checker health.Checker, authorizer func(r *http.Request) bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("POST /health/debug/{name}", health.NewDebugHandler(checker, authorizer))
+
+	return mux
+}
+
+func TestDebugHandlerReturnsVerbosePayload(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCacheDuration(time.Hour),
+		health.WithCheck(failingCheck("db", 2, errors.New("connection reset"), &calls),
+			health.WithRetry(3, time.Millisecond),
+			health.WithObservabilityTags(map[string]string{"team": "payments"})),
+	)
+	ckr.Check(t.Context())
+	cachedState, _ := ckr.(health.StateReader).LastCheckState("db")
+	calls.Store(0)
+
+	req := httptest.NewRequest(http.MethodPost, "/health/debug/db", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	newDebugServer(ckr, allowAll).ServeHTTP(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, int32(3), calls.Load(), "the debug evaluation must bypass the cache")
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+	assert.Equal(t, "db", payload["name"])
+	assert.Equal(t, "up", payload["status"])
+	assert.Equal(t, map[string]any{"team": "payments"}, payload["tags"])
+	assert.NotEmpty(t, payload["startedAt"])
+	assert.NotEmpty(t, payload["duration"])
+
+	attempts, ok := payload["attempts"].([]any)
+	require.True(t, ok)
+	require.Len(t, attempts, 3)
+
+	first, ok := attempts[0].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 1, first["attempt"], 0)
+	assert.Equal(t, "connection reset", first["error"])
+	assert.Equal(t, health.ReasonError, first["reason"])
+	assert.NotEmpty(t, first["duration"])
+
+	last, ok := attempts[2].(map[string]any)
+	require.True(t, ok)
+	assert.NotContains(t, last, "error")
+
+	state, _ := ckr.(health.StateReader).LastCheckState("db")
+	assert.Equal(t, cachedState, state, "the debug evaluation must not affect the state of the check")
+}
+
+func TestDebugCheckRecordsPanicStack(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:    "panicking",
+			Timeout: time.Second,
+			Check: func(ctx context.Context) error {
+				panic("boom")
+			},
+		}),
+	)
+
+	// Act
+	result, err := ckr.(health.CheckDebugger).DebugCheck(t.Context(), "panicking")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, health.ReasonPanic, result.Reason)
+	assert.Equal(t, "boom", result.Error)
+	assert.Equal(t, time.Second, result.Timeout)
+	require.Len(t, result.Attempts, 1)
+	assert.Contains(t, result.Attempts[0].PanicStack, "debug_test.go")
+}
+
+func TestDebugCheckAppliesGlobalTimeout(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithTimeout(20*time.Millisecond),
+		health.WithCheck(sleepingCheck("hanging", time.Hour)),
+	)
+
+	// Act
+	result, err := ckr.(health.CheckDebugger).DebugCheck(t.Context(), "hanging")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, 20*time.Millisecond, result.Timeout)
+	assert.Less(t, result.Duration, time.Second)
+}
+
+func TestDebugCheckRecoversPanicsOfChecksWithoutPanicRecovery(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:                 "panicking",
+			DisablePanicRecovery: true,
+			Check: func(ctx context.Context) error {
+				panic("boom")
+			},
+		}),
+	)
+
+	// Act
+	result, err := ckr.(health.CheckDebugger).DebugCheck(t.Context(), "panicking")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, health.ReasonPanic, result.Reason)
+	assert.Equal(t, "boom", result.Error)
+}
+
+func TestDebugCheckUnknownCheck(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+
+	// Act
+	_, err := ckr.(health.CheckDebugger).DebugCheck(t.Context(), "unknown")
+
+	// Assert
+	assert.ErrorIs(t, err, health.ErrCheckNotFound)
+}
+
+func TestDebugHandlerRejectedRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		authorizer     func(r *http.Request) bool
+		expectedStatus int
+	}{
+		{
+			name:           "Unauthorized",
+			method:         http.MethodPost,
+			path:           "/health/debug/db",
+			authorizer:     func(*http.Request) bool { return false },
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "NoAuthorizer",
+			method:         http.MethodPost,
+			path:           "/health/debug/db",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "UnknownCheck",
+			method:         http.MethodPost,
+			path:           "/health/debug/unknown",
+			authorizer:     allowAll,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "MethodNotAllowed",
+			method:         http.MethodGet,
+			path:           "/debug/db",
+			authorizer:     allowAll,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithCheck(health.Check{Name: "db", Check: func(ctx context.Context) error { return nil }}),
+			)
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			health.NewDebugHandler(ckr, tt.authorizer).ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"sync"
+)
+
+type (
+	deferredListenersContextKey struct{}
+
+	// deferredListeners collects the listener notifications of a handler request, so that they can be
+	// delivered after the response was written (see WithDeferredListeners).
+	deferredListeners struct {
+		mtx           sync.Mutex
+		notifications []deferredNotification
+	}
+
+	deferredNotification struct {
+		ctx    context.Context
+		notify func(ctx context.Context)
+	}
+)
+
+func withDeferredListeners(ctx context.Context, listeners *deferredListeners) context.Context {
+	return context.WithValue(ctx, deferredListenersContextKey{}, listeners)
+}
+
+// listenersDeferred returns true, if the context belongs to a handler request with deferred listeners.
+func listenersDeferred(ctx context.Context) bool {
+	_, ok := ctx.Value(deferredListenersContextKey{}).(*deferredListeners)
+	return ok
+}
+
+// notifyListener notifies a listener right away or, if the context belongs to a handler request with deferred
+// listeners, adds the notification to the listeners that are notified after the response was written.
+func notifyListener(ctx context.Context, notify func(ctx context.Context)) {
+	listeners, _ := ctx.Value(deferredListenersContextKey{}).(*deferredListeners)
+	if listeners == nil {
+		notify(ctx)
+		return
+	}
+
+	listeners.mtx.Lock()
+	defer listeners.mtx.Unlock()
+
+	// The context of the evaluation is cancelled before the notification is delivered.
+	listeners.notifications = append(listeners.notifications, deferredNotification{
+		ctx:    context.WithoutCancel(ctx),
+		notify: notify,
+	})
+}
+
+// notifyInBackground flushes the response and then delivers all deferred notifications in a background goroutine.
+func (l *deferredListeners) notifyInBackground(w http.ResponseWriter) {
+	l.mtx.Lock()
+	notifications := l.notifications
+	l.notifications = nil
+	l.mtx.Unlock()
+
+	if len(notifications) == 0 {
+		return
+	}
+
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	go func() {
+		for _, notification := range notifications {
+			notification.notify(notification.ctx)
+		}
+	}()
+}
+
+// copyState returns a copy of the state that is not affected by later state changes.
+func copyState(state State) State {
+	state.CheckState = maps.Clone(state.CheckState)
+	return state
+}
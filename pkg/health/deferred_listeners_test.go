@@ -0,0 +1,95 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestDeferredListeners(t *testing.T) {
+	// Arrange
+	var (
+		release       = make(chan struct{})
+		listenerDone  = make(chan health.State, 1)
+		checkNotified = make(chan health.CheckState, 1)
+	)
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusListener(func(ctx context.Context, state health.State) {
+			<-release
+			listenerDone <- state
+		}),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return errors.New("unavailable") },
+			StatusListener: func(ctx context.Context, name string, state health.CheckState) {
+				checkNotified <- state
+			},
+		}),
+	)
+
+	handler := health.NewHandler(ckr, health.WithDeferredListeners())
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"down"`)
+	assert.True(t, w.Flushed)
+
+	select {
+	case <-listenerDone:
+		require.Fail(t, "listener completed before the response was written")
+	default:
+	}
+
+	close(release)
+
+	select {
+	case state := <-listenerDone:
+		assert.Equal(t, health.StatusDown, state.Status)
+		assert.Equal(t, health.StatusDown, state.CheckState["database"].Status)
+	case <-time.After(time.Second):
+		require.Fail(t, "listener was not notified")
+	}
+
+	select {
+	case state := <-checkNotified:
+		assert.Equal(t, health.StatusDown, state.Status)
+	case <-time.After(time.Second):
+		require.Fail(t, "check listener was not notified")
+	}
+}
+
+func TestListenersAreNotDeferredByDefault(t *testing.T) {
+	// Arrange
+	var notified atomic.Bool
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusListener(func(ctx context.Context, state health.State) {
+			notified.Store(true)
+		}),
+		health.WithCheck(health.Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	handler := health.NewHandler(ckr)
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.True(t, notified.Load())
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithDependencyConcurrency limits the number of concurrent evaluations of the checks of the given dependency
+// (see Check.Dependency), e.g., to not overload a database that is probed by several checks. In contrast to the
+// shared worker pool (see WithWorkerPool), each dependency has its own limit, so checks of different dependencies
+// do not contend with each other. Evaluations that exceed the limit wait until another evaluation of the same
+// dependency completes. The waiting time counts towards the timeout of the check. By default, there is no limit.
+// BuildChecker returns ErrInvalidDependencyLimit for an empty dependency name or a limit that is not positive.
+// Once a limit is configured, BuildChecker returns ErrUnknownDependency for checks whose dependency has no limit,
+// so that a misspelled Check.Dependency is detected right away.
+func WithDependencyConcurrency(dependency string, limit int) Option {
+	return func(cfg *checkerConfig) {
+		if cfg.dependencyLimits == nil {
+			cfg.dependencyLimits = map[string]int{}
+		}
+
+		cfg.dependencyLimits[dependency] = limit
+	}
+}
+
+// validateDependencies checks the limits of the dependencies (see WithDependencyConcurrency) and that each
+// dependency of a check has a limit, if any limit is configured.
+func (cfg *checkerConfig) validateDependencies() error {
+	for dependency, limit := range cfg.dependencyLimits {
+		if dependency == "" || limit <= 0 {
+			return fmt.Errorf("%w: %q: %d", ErrInvalidDependencyLimit, dependency, limit)
+		}
+	}
+
+	if len(cfg.dependencyLimits) == 0 {
+		return nil
+	}
+
+	for _, check := range cfg.checks {
+		if _, ok := cfg.dependencyLimits[check.Dependency]; check.Dependency != "" && !ok {
+			return fmt.Errorf("%w: %q of check %s", ErrUnknownDependency, check.Dependency, check.Name)
+		}
+	}
+
+	return nil
+}
+
+// newDependencySemaphores creates a semaphore for each dependency (see validateDependencies).
+func newDependencySemaphores(limits map[string]int) map[string]chan struct{} {
+	semaphores := make(map[string]chan struct{}, len(limits))
+	for dependency, limit := range limits {
+		semaphores[dependency] = make(chan struct{}, limit)
+	}
+
+	return semaphores
+}
+
+// runWithDependencyLimit executes f once the limit of the dependency of the check permits it (see
+// WithDependencyConcurrency). If the context is done before, f is not executed.
+func (ck *defaultChecker) runWithDependencyLimit(ctx context.Context, check *Check, f func() checkOutcome) checkOutcome {
+	semaphore, ok := ck.semaphores[check.Dependency]
+	if !ok {
+		return f()
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return checkOutcome{err: cancellationError(ctx)}
+	}
+
+	defer func() { <-semaphore }()
+
+	return f()
+}
@@ -0,0 +1,164 @@
+package health_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// concurrencyTracker records the peak number of concurrent calls.
+type concurrencyTracker struct {
+	current atomic.Int32
+	peak    atomic.Int32
+}
+
+func (c *concurrencyTracker) enter() {
+	n := c.current.Add(1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			return
+		}
+	}
+}
+
+func (c *concurrencyTracker) leave() {
+	c.current.Add(-1)
+}
+
+// trackedChecks returns n checks of the given dependency that record their concurrency in the trackers.
+func trackedChecks(dependency string, n int, trackers ...*concurrencyTracker) []health.Check {
+	checks := make([]health.Check, 0, n)
+	for i := range n {
+		checks = append(checks, health.Check{
+			Name:       fmt.Sprintf("%s-%d", dependency, i),
+			Dependency: dependency,
+			Check: func(ctx context.Context) error {
+				for _, tracker := range trackers {
+					tracker.enter()
+					defer tracker.leave()
+				}
+
+				time.Sleep(20 * time.Millisecond)
+
+				return nil
+			},
+		})
+	}
+
+	return checks
+}
+
+func TestDependencyConcurrency(t *testing.T) {
+	// Arrange
+	var postgres, redis, unlimited, all concurrencyTracker
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDependencyConcurrency("postgres", 1),
+		health.WithDependencyConcurrency("redis", 2),
+		health.WithChecks(trackedChecks("postgres", 3, &postgres, &all)...),
+		health.WithChecks(trackedChecks("redis", 4, &redis, &all)...),
+		health.WithChecks(trackedChecks("", 2, &unlimited, &all)...),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, int32(1), postgres.peak.Load())
+	assert.Equal(t, int32(2), redis.peak.Load())
+	assert.Equal(t, int32(2), unlimited.peak.Load(), "checks without a dependency must not be limited")
+	assert.Equal(t, int32(5), all.peak.Load(), "the limits of the dependencies must be independent")
+}
+
+func TestDependencyConcurrencyWaitCountsTowardsTimeout(t *testing.T) {
+	// Arrange
+	var entered atomic.Int32
+	block := make(chan struct{})
+	defer close(block)
+
+	blocking := func(ctx context.Context) error {
+		entered.Add(1)
+		<-block
+
+		return nil
+	}
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithTimeout(50*time.Millisecond),
+		health.WithDependencyConcurrency("postgres", 1),
+		health.WithCheck(health.Check{Name: "first", Dependency: "postgres", Check: blocking}),
+		health.WithCheck(health.Check{Name: "second", Dependency: "postgres", Check: blocking}),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, int32(1), entered.Load(), "the waiting check must not be executed")
+	assert.Equal(t, health.ReasonGlobalTimeout, result.Details["first"].Reason)
+	assert.Equal(t, health.ReasonGlobalTimeout, result.Details["second"].Reason)
+}
+
+func TestDependencyConcurrencyValidation(t *testing.T) {
+	check := health.Check{Name: "orders-db", Dependency: "postgres", Check: func(context.Context) error { return nil }}
+
+	tests := []struct {
+		name    string
+		options []health.Option
+		wantErr error
+	}{
+		{
+			name:    "zero limit",
+			options: []health.Option{health.WithDependencyConcurrency("postgres", 0)},
+			wantErr: health.ErrInvalidDependencyLimit,
+		},
+		{
+			name:    "negative limit",
+			options: []health.Option{health.WithDependencyConcurrency("postgres", -1)},
+			wantErr: health.ErrInvalidDependencyLimit,
+		},
+		{
+			name:    "empty dependency",
+			options: []health.Option{health.WithDependencyConcurrency("", 1)},
+			wantErr: health.ErrInvalidDependencyLimit,
+		},
+		{
+			name:    "misspelled dependency of check",
+			options: []health.Option{health.WithDependencyConcurrency("postgress", 1), health.WithCheck(check)},
+			wantErr: health.ErrUnknownDependency,
+		},
+		{
+			name:    "dependency of check without any limits",
+			options: []health.Option{health.WithCheck(check)},
+		},
+		{
+			name:    "dependency of check with limit",
+			options: []health.Option{health.WithDependencyConcurrency("postgres", 1), health.WithCheck(check)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := health.BuildChecker(append(tt.options, health.WithDisabledAutostart())...)
+
+			// Assert
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
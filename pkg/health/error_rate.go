@@ -0,0 +1,88 @@
+package health
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// errorRatePolicy configures when an otherwise available check is downgraded to degraded
+	// because of intermittent errors (see WithErrorRateThreshold).
+	errorRatePolicy struct {
+		window         time.Duration
+		maxRate        float64
+		minEvaluations uint
+	}
+
+	// errorRateWindow retains the outcomes of the evaluations of a check within the window of its errorRatePolicy.
+	errorRateWindow struct {
+		mtx     sync.Mutex
+		samples []errorRateSample
+	}
+
+	errorRateSample struct {
+		at     time.Time
+		failed bool
+	}
+)
+
+// WithErrorRateThreshold downgrades a check that is up to degraded if the share of its evaluations that
+// failed within the given rolling window reaches maxRate (between 0 and 1), e.g., if a check is mostly up
+// but fails intermittently. Failed evaluations that do not make the check down (see Check.MaxContiguousFails
+// and Check.MaxTimeInError) count as well. The downgrade requires at least minEvaluations evaluations within
+// the window, so that a single failure right after the start does not degrade the check. A downgraded check
+// reports ReasonErrorRate. The check returns to up as soon as the error rate drops below maxRate.
+func WithErrorRateThreshold(window time.Duration, maxRate float64, minEvaluations uint) CheckOption {
+	return func(check *Check) {
+		check.errorRate = &errorRatePolicy{window: window, maxRate: maxRate, minEvaluations: minEvaluations}
+	}
+}
+
+// record adds the outcome of an evaluation and returns the number of evaluations and of failed evaluations
+// within the window.
+func (w *errorRateWindow) record(window time.Duration, now time.Time, failed bool) (evaluations, failures int) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.samples = append(w.samples, errorRateSample{at: now, failed: failed})
+
+	expired := 0
+	for expired < len(w.samples) && !w.samples[expired].at.After(now.Add(-window)) {
+		expired++
+	}
+	w.samples = w.samples[expired:]
+
+	for _, sample := range w.samples {
+		if sample.failed {
+			failures++
+		}
+	}
+
+	return len(w.samples), failures
+}
+
+// applyErrorRate downgrades the state to degraded if the check is up, but its error rate within the window
+// reached the maximum rate (see WithErrorRateThreshold).
+func applyErrorRate(state CheckState, policy *errorRatePolicy, window *errorRateWindow, now time.Time) CheckState {
+	evaluations, failures := window.record(policy.window, now, state.Result != nil)
+
+	if state.Status != StatusUp || evaluations < int(policy.minEvaluations) {
+		return state
+	}
+
+	rate := float64(failures) / float64(evaluations)
+	if rate < policy.maxRate {
+		return state
+	}
+
+	if state.Result == nil {
+		state.Result = ErrorWithReason(ReasonErrorRate, fmt.Errorf("%d of %d evaluations within %v failed: %w",
+			failures, evaluations, policy.window, ErrDegraded))
+	}
+
+	state.Status = StatusDegraded
+	state.Reason = ReasonErrorRate
+
+	return state
+}
@@ -0,0 +1,108 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// scriptedCheck returns a check that fails if the outcome at the position of the current call is true.
+// After the script ends, the check succeeds.
+func scriptedCheck(name string, failures []bool, calls *atomic.Int32) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			if i := int(calls.Add(1)) - 1; i < len(failures) && failures[i] {
+				return errors.New("connection reset")
+			}
+			return nil
+		},
+	}
+}
+
+func TestErrorRateThresholdDowngradesToDegraded(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	clock := newFakeClock(time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC))
+	check := scriptedCheck("api", []bool{false, true, false, false, true, false}, &calls)
+	check.MaxContiguousFails = 3
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithCheck(check, health.WithErrorRateThreshold(time.Minute, 0.3, 4)),
+	)
+
+	// Act
+	var statuses []health.AvailabilityStatus
+	for range 6 {
+		clock.Advance(time.Second)
+		statuses = append(statuses, ckr.Check(t.Context()).Details["api"].Status)
+	}
+	state, _ := ckr.(health.StateReader).LastCheckState("api")
+
+	// Assert
+	up, degraded := health.StatusUp, health.StatusDegraded
+	// The fourth evaluation does not downgrade the check, since only 1 of 4 evaluations failed.
+	assert.Equal(t, []health.AvailabilityStatus{up, up, up, up, degraded, degraded}, statuses)
+	assert.Equal(t, health.ReasonErrorRate, state.Reason)
+	require.Error(t, state.Result)
+	assert.ErrorIs(t, state.Result, health.ErrDegraded)
+	assert.Contains(t, state.Result.Error(), "2 of 6 evaluations within 1m0s failed")
+}
+
+func TestErrorRateThresholdRecoversOutsideWindow(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	clock := newFakeClock(time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC))
+	check := scriptedCheck("api", []bool{true, false, true, false}, &calls)
+	check.MaxContiguousFails = 3
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithCheck(check, health.WithErrorRateThreshold(time.Minute, 0.5, 2)),
+	)
+
+	for range 4 {
+		clock.Advance(time.Second)
+		ckr.Check(t.Context())
+	}
+	degradedState, _ := ckr.(health.StateReader).LastCheckState("api")
+
+	// Act
+	clock.Advance(2 * time.Minute)
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDegraded, degradedState.Status)
+	assert.Equal(t, health.StatusUp, res.Details["api"].Status)
+	assert.Empty(t, res.Details["api"].Reason)
+}
+
+func TestErrorRateThresholdDoesNotAffectDownChecks(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	clock := newFakeClock(time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithCheck(scriptedCheck("api", []bool{true, true}, &calls), health.WithErrorRateThreshold(time.Minute, 0.1, 1)),
+	)
+
+	// Act
+	clock.Advance(time.Second)
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, res.Details["api"].Status)
+	assert.Equal(t, health.ReasonError, res.Details["api"].Reason)
+}
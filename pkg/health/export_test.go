@@ -8,11 +8,11 @@ import (
 )
 
 func AggregateStatus(results map[string]CheckState) AvailabilityStatus {
-	return aggregateStatus(results)
+	return WorstStatusAggregator(results)
 }
 
 func EvaluateCheckStatus(state *CheckState, maxTimeInError time.Duration, maxFails uint) AvailabilityStatus {
-	return evaluateCheckStatus(state, maxTimeInError, maxFails)
+	return evaluateCheckStatus(state, maxTimeInError, maxFails, time.Now())
 }
 
 type resultWriterMock struct {
@@ -23,3 +23,10 @@ func (ck *resultWriterMock) Write(result *Result, statusCode int, w http.Respons
 	err, _ := ck.Called(result, statusCode, w, r).Get(0).(error)
 	return err
 }
+
+func UnregisterCheckType(name string) {
+	checkTypes.mtx.Lock()
+	defer checkTypes.mtx.Unlock()
+
+	delete(checkTypes.factories, name)
+}
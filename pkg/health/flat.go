@@ -0,0 +1,153 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// flatKeyReplacer replaces the characters that would break the structure of a flat key.
+var flatKeyReplacer = strings.NewReplacer(".", "_", "=", "_", " ", "_", "\n", "_", "\r", "_")
+
+// flatValueReplacer replaces line breaks, so that each key/value pair remains on a single line.
+var flatValueReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// FlatResultWriter writes a Result as flat, dot-delimited key/value pairs into an http.ResponseWriter, one
+// pair per line and sorted by key (see FlattenResult). Example:
+//
+//	health.checks.database.status=down
+//	health.status=down
+//
+// This format is useful for systems that ingest flat key/value pairs, such as some APM tools.
+type FlatResultWriter struct{}
+
+// NewFlatResultWriter creates a new instance of a FlatResultWriter.
+func NewFlatResultWriter() *FlatResultWriter {
+	return &FlatResultWriter{}
+}
+
+// Write implements ResultWriter.Write.
+func (rw *FlatResultWriter) Write(result *Result, statusCode int, w http.ResponseWriter, _ *http.Request) error {
+	flat := FlattenResult(result)
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var body strings.Builder
+	for _, key := range keys {
+		body.WriteString(key)
+		body.WriteByte('=')
+		body.WriteString(flatValueReplacer.Replace(flat[key]))
+		body.WriteByte('\n')
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, err := w.Write([]byte(body.String()))
+
+	return err
+}
+
+// FlattenResult converts a Result into a flat map with dot-delimited keys, such as "health.status" and
+// "health.checks.<name>.status". Sub-results are nested below "health.checks.<name>.details.<name>" and
+// info entries below "health.info.<key>". Dots, equal signs and whitespace in check names and info keys are
+// replaced by underscores. Empty values (e.g., the error of a successful check) are omitted.
+func FlattenResult(result *Result) map[string]string {
+	flat := map[string]string{
+		"health.status": string(result.Status),
+	}
+
+	putFlat(flat, "health.cycleId", result.CycleID)
+	putFlat(flat, "health.incidentId", result.IncidentID)
+
+	if result.Draining {
+		flat["health.draining"] = "true"
+	}
+
+	if result.WarmingUp {
+		flat["health.warmingUp"] = "true"
+	}
+
+	if result.DownSince != nil {
+		flat["health.downSince"] = result.DownSince.Format(time.RFC3339Nano)
+	}
+
+	if result.Forced != nil {
+		flat["health.forced.status"] = string(result.Forced.Status)
+		putFlat(flat, "health.forced.reason", result.Forced.Reason)
+	}
+
+	if result.Counts != nil {
+		flat["health.counts.total"] = strconv.Itoa(result.Counts.Total)
+		flat["health.counts.up"] = strconv.Itoa(result.Counts.Up)
+		flat["health.counts.degraded"] = strconv.Itoa(result.Counts.Degraded)
+		flat["health.counts.down"] = strconv.Itoa(result.Counts.Down)
+		flat["health.counts.unknown"] = strconv.Itoa(result.Counts.Unknown)
+	}
+
+	if result.ProbeHints != nil {
+		if result.ProbeHints.Interval > 0 {
+			flat["health.probeHints.interval"] = result.ProbeHints.Interval.String()
+		}
+
+		if result.ProbeHints.Timeout > 0 {
+			flat["health.probeHints.timeout"] = result.ProbeHints.Timeout.String()
+		}
+
+		putFlat(flat, "health.probeHints.livenessPath", result.ProbeHints.LivenessPath)
+		putFlat(flat, "health.probeHints.readinessPath", result.ProbeHints.ReadinessPath)
+	}
+
+	for key, value := range result.Info {
+		flat["health.info."+flatKeyReplacer.Replace(key)] = fmt.Sprint(value)
+	}
+
+	flattenCheckResults(flat, "health.checks", result.Details)
+
+	return flat
+}
+
+func flattenCheckResults(flat map[string]string, prefix string, results map[string]CheckResult) {
+	for name, result := range results {
+		key := prefix + "." + flatKeyReplacer.Replace(name)
+
+		flat[key+".status"] = string(result.Status)
+		putFlat(flat, key+".reason", result.Reason)
+
+		if !result.Timestamp.IsZero() {
+			flat[key+".timestamp"] = result.Timestamp.Format(time.RFC3339Nano)
+		}
+
+		if result.Error != nil {
+			flat[key+".error"] = result.Error.Error()
+		}
+
+		if !result.StartedAt.IsZero() {
+			flat[key+".startedAt"] = result.StartedAt.Format(time.RFC3339Nano)
+			flat[key+".finishedAt"] = result.FinishedAt.Format(time.RFC3339Nano)
+			flat[key+".duration"] = result.Duration.String()
+		}
+
+		if result.Paused {
+			flat[key+".paused"] = "true"
+		}
+
+		if result.Deviating {
+			flat[key+".deviating"] = "true"
+		}
+
+		flattenCheckResults(flat, key+".details", result.SubResults)
+	}
+}
+
+func putFlat(flat map[string]string, key, value string) {
+	if value != "" {
+		flat[key] = value
+	}
+}
@@ -0,0 +1,82 @@
+package health_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func sampleResult() *health.Result {
+	timestamp := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	return &health.Result{
+		Status:    health.StatusDown,
+		CycleID:   "cycle-1",
+		DownSince: &timestamp,
+		Info:      map[string]interface{}{"version": "1.2.3", "build.id": 42},
+		Details: map[string]health.CheckResult{
+			"database": {
+				Status:    health.StatusDown,
+				Timestamp: timestamp,
+				Error:     errors.New("connection refused\nretry later"),
+				Reason:    health.ReasonConnRefused,
+				SubResults: map[string]health.CheckResult{
+					"read": {Status: health.StatusUp, Timestamp: timestamp},
+				},
+			},
+			"cache.redis": {Status: health.StatusUp, Timestamp: timestamp},
+		},
+	}
+}
+
+func TestFlattenResult(t *testing.T) {
+	// Act
+	flat := health.FlattenResult(sampleResult())
+
+	// Assert
+	assert.Equal(t, map[string]string{
+		"health.status":                                 "down",
+		"health.cycleId":                                "cycle-1",
+		"health.downSince":                              "2025-03-01T12:00:00Z",
+		"health.info.version":                           "1.2.3",
+		"health.info.build_id":                          "42",
+		"health.checks.database.status":                 "down",
+		"health.checks.database.timestamp":              "2025-03-01T12:00:00Z",
+		"health.checks.database.error":                  "connection refused\nretry later",
+		"health.checks.database.reason":                 health.ReasonConnRefused,
+		"health.checks.database.details.read.status":    "up",
+		"health.checks.database.details.read.timestamp": "2025-03-01T12:00:00Z",
+		"health.checks.cache_redis.status":              "up",
+		"health.checks.cache_redis.timestamp":           "2025-03-01T12:00:00Z",
+	}, flat)
+}
+
+func TestFlatResultWriter(t *testing.T) {
+	// Arrange
+	writer := health.NewFlatResultWriter()
+	result := &health.Result{
+		Status: health.StatusDown,
+		Details: map[string]health.CheckResult{
+			"database": {Status: health.StatusDown, Error: errors.New("line 1\nline 2")},
+		},
+	}
+	w := httptest.NewRecorder()
+
+	// Act
+	err := writer.Write(result, http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "health.checks.database.error=line 1 line 2\n"+
+		"health.checks.database.status=down\n"+
+		"health.status=down\n", w.Body.String())
+}
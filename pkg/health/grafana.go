@@ -0,0 +1,135 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// GrafanaAggregateTarget is the name of the Grafana target that holds the aggregated health status
+// (see NewGrafanaHandler). All other targets are named after the checks.
+const GrafanaAggregateTarget = "aggregate"
+
+type (
+	grafanaQueryRequest struct {
+		Range struct {
+			From time.Time `json:"from"`
+			To   time.Time `json:"to"`
+		} `json:"range"`
+		Targets []struct {
+			Target string `json:"target"`
+		} `json:"targets"`
+		MaxDataPoints int `json:"maxDataPoints"`
+	}
+
+	grafanaTimeSeries struct {
+		Target     string       `json:"target"`
+		Datapoints [][2]float64 `json:"datapoints"`
+	}
+)
+
+// NewGrafanaHandler creates an http.Handler that implements the contract of the Grafana
+// simple JSON data source based on the retained history of the Checker (see WithHistory):
+//   - "GET /" responds with 200 OK, so that Grafana can test the connection,
+//   - "POST /search" returns the names of all available targets (see GrafanaAggregateTarget),
+//   - "POST /query" returns a time series of the availability status for each requested target,
+//     where up is mapped to 1, degraded to 0.5, down to 0 and unknown to -1.
+//
+// The handler expects to be mounted at the root of its path (e.g., by using http.StripPrefix). If the checker
+// does not implement HistoryProvider, all requests are answered with 501 Not Implemented.
+func NewGrafanaHandler(checker Checker) http.Handler {
+	provider, ok := checker.(HistoryProvider)
+	if !ok {
+		return http.HandlerFunc(notImplementedHandler)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("POST /search", func(w http.ResponseWriter, _ *http.Request) {
+		history := provider.History()
+
+		targets := make([]string, 0, len(history.Checks)+1)
+		for name := range history.Checks {
+			targets = append(targets, name)
+		}
+		sort.Strings(targets)
+
+		writeGrafanaResponse(w, append([]string{GrafanaAggregateTarget}, targets...))
+	})
+
+	mux.HandleFunc("POST /query", func(w http.ResponseWriter, r *http.Request) {
+		var query grafanaQueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			http.Error(w, "cannot decode query: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		history := provider.History()
+
+		series := make([]grafanaTimeSeries, 0, len(query.Targets))
+		for _, target := range query.Targets {
+			entries := history.Aggregate
+			if target.Target != GrafanaAggregateTarget {
+				entries = history.Checks[target.Target]
+			}
+
+			series = append(series, grafanaTimeSeries{
+				Target:     target.Target,
+				Datapoints: toGrafanaDatapoints(entries, query.Range.From, query.Range.To, query.MaxDataPoints),
+			})
+		}
+
+		writeGrafanaResponse(w, series)
+	})
+
+	return mux
+}
+
+func toGrafanaDatapoints(entries []HistoryEntry, from, to time.Time, maxDataPoints int) [][2]float64 {
+	datapoints := make([][2]float64, 0, len(entries))
+
+	for _, entry := range entries {
+		if (!from.IsZero() && entry.Timestamp.Before(from)) || (!to.IsZero() && entry.Timestamp.After(to)) {
+			continue
+		}
+
+		datapoints = append(datapoints, [2]float64{statusValue(entry.Status), float64(entry.Timestamp.UnixMilli())})
+	}
+
+	if maxDataPoints > 0 && len(datapoints) > maxDataPoints {
+		datapoints = datapoints[len(datapoints)-maxDataPoints:]
+	}
+
+	return datapoints
+}
+
+func writeGrafanaResponse(w http.ResponseWriter, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "cannot marshal response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// statusValue maps an availability status to a numeric value for time series.
+func statusValue(status AvailabilityStatus) float64 {
+	switch status {
+	case StatusUp:
+		return 1
+	case StatusDegraded:
+		return 0.5
+	case StatusDown:
+		return 0
+	default:
+		return -1
+	}
+}
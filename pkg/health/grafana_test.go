@@ -0,0 +1,110 @@
+package health_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func newGrafanaTestChecker(t *testing.T, start time.Time) health.Checker {
+	t.Helper()
+
+	var fail atomic.Bool
+	clock := newFakeClock(start)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithHistory(10),
+		health.WithCheck(toggledCheck("database", &fail)),
+	)
+
+	for _, failing := range []bool{false, true, false} {
+		fail.Store(failing)
+		ckr.Check(t.Context())
+		clock.Advance(time.Minute)
+	}
+
+	return ckr
+}
+
+func TestGrafanaHandlerConnectionTest(t *testing.T) {
+	// Arrange
+	handler := health.NewGrafanaHandler(health.NewChecker(health.WithDisabledAutostart()))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGrafanaHandlerSearch(t *testing.T) {
+	// Arrange
+	handler := health.NewGrafanaHandler(newGrafanaTestChecker(t, time.Now()))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"target":""}`)))
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `["aggregate","database"]`, w.Body.String())
+}
+
+func TestGrafanaHandlerQuery(t *testing.T) {
+	// Arrange
+	start := time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC)
+	handler := health.NewGrafanaHandler(newGrafanaTestChecker(t, start))
+	query := `{
+		"range": {"from": "2025-06-02T12:00:30Z", "to": "2025-06-02T13:00:00Z"},
+		"targets": [{"target": "database", "type": "timeserie"}, {"target": "aggregate", "type": "timeserie"}],
+		"maxDataPoints": 100
+	}`
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(query)))
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	var series []struct {
+		Target     string       `json:"target"`
+		Datapoints [][2]float64 `json:"datapoints"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &series))
+	require.Len(t, series, 2)
+
+	expectedDatapoints := [][2]float64{
+		{0, float64(start.Add(1 * time.Minute).UnixMilli())},
+		{1, float64(start.Add(2 * time.Minute).UnixMilli())},
+	}
+	assert.Equal(t, "database", series[0].Target)
+	assert.Equal(t, expectedDatapoints, series[0].Datapoints)
+	assert.Equal(t, "aggregate", series[1].Target)
+	assert.Equal(t, expectedDatapoints, series[1].Datapoints)
+}
+
+func TestGrafanaHandlerQueryRejectsInvalidPayload(t *testing.T) {
+	// Arrange
+	handler := health.NewGrafanaHandler(health.NewChecker(health.WithDisabledAutostart()))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("{")))
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
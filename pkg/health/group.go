@@ -0,0 +1,49 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// groupContexts lazily creates one context per check group for an evaluation cycle. All checks of a group
+// share the context of their group, so that they collectively adhere to the budget of the group
+// (see WithGroupBudget).
+type groupContexts struct {
+	parent   context.Context
+	budgets  map[string]time.Duration
+	contexts map[string]context.Context
+	cancels  []context.CancelFunc
+}
+
+func newGroupContexts(parent context.Context, budgets map[string]time.Duration) *groupContexts {
+	return &groupContexts{
+		parent:   parent,
+		budgets:  budgets,
+		contexts: map[string]context.Context{},
+	}
+}
+
+// get returns the context for the given group. If the group has no budget, the parent context is returned.
+// get must not be called concurrently.
+func (gc *groupContexts) get(group string) context.Context {
+	budget, ok := gc.budgets[group]
+	if group == "" || !ok {
+		return gc.parent
+	}
+
+	ctx, ok := gc.contexts[group]
+	if !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(gc.parent, budget)
+		gc.contexts[group] = ctx
+		gc.cancels = append(gc.cancels, cancel)
+	}
+
+	return ctx
+}
+
+func (gc *groupContexts) cancel() {
+	for _, cancel := range gc.cancels {
+		cancel()
+	}
+}
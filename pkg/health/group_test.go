@@ -0,0 +1,75 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func sleepingCheck(name string, d time.Duration) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			select {
+			case <-time.After(d):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+func TestGroupBudgetLimitsCombinedTime(t *testing.T) {
+	// Arrange
+	budget := 100 * time.Millisecond
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithGroupBudget("db", budget),
+		health.WithCheck(sleepingCheck("db-read", 30*time.Millisecond), health.WithGroup("db")),
+		health.WithCheck(sleepingCheck("db-write", time.Second), health.WithGroup("db")),
+		health.WithCheck(sleepingCheck("db-replica", time.Second), health.WithGroup("db")),
+		health.WithCheck(sleepingCheck("cache", 150*time.Millisecond)),
+	)
+
+	// Act
+	start := time.Now()
+	res := ckr.Check(t.Context())
+	elapsed := time.Since(start)
+
+	// Assert
+	assert.Less(t, elapsed, time.Second)
+	assert.Equal(t, health.StatusUp, res.Details["db-read"].Status)
+	for _, name := range []string{"db-write", "db-replica"} {
+		require.ErrorIs(t, res.Details[name].Error, health.ErrCheckTimeout)
+		assert.Equal(t, health.ReasonTimeout, res.Details[name].Reason)
+	}
+	// Checks outside the group are not affected by the budget.
+	assert.Equal(t, health.StatusUp, res.Details["cache"].Status)
+}
+
+func TestGroupBudgetDoesNotApplyToPeriodicChecks(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithGroupBudget("db", 20*time.Millisecond),
+		health.WithPeriodicCheck(time.Hour, 0, sleepingCheck("db-periodic", 100*time.Millisecond), health.WithGroup("db")),
+		health.WithCheck(sleepingCheck("db-sync", time.Second), health.WithGroup("db")),
+	)
+	defer ckr.Stop()
+
+	// Act
+	var res health.Result
+	require.Eventually(t, func() bool {
+		res = ckr.Check(t.Context())
+		return res.Details["db-periodic"].Status != health.StatusUnknown
+	}, time.Second, 10*time.Millisecond)
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Details["db-periodic"].Status)
+	assert.Equal(t, health.ReasonTimeout, res.Details["db-sync"].Reason)
+}
@@ -44,12 +44,38 @@ func CheckGRPCServerHealth(ctx context.Context, grpcCfg *commoncfg.GRPCClient) e
 		return err
 	}
 	if grpcServerHealthResp.GetStatus() != healthgrpc.HealthCheckResponse_SERVING {
-		return fmt.Errorf("GRPC Server serving on address %s is not in servig state", grpcCfg.Address)
+		return ErrorWithReason(ReasonNotServing,
+			fmt.Errorf("GRPC Server serving on address %s is not in servig state", grpcCfg.Address))
 	}
 
 	return nil
 }
 
+// GRPCHealthCheck creates a check that calls the Check RPC of the standard gRPC health service
+// (grpc.health.v1) of a remote server via the given connection. The check succeeds only if the
+// remote server reports the status SERVING for the given service. An empty service name
+// refers to the overall health of the remote server.
+func GRPCHealthCheck(name string, conn *grpc.ClientConn, service string) Check {
+	client := healthgrpc.NewHealthClient(conn)
+
+	return Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			resp, err := client.Check(ctx, &healthgrpc.HealthCheckRequest{Service: service})
+			if err != nil {
+				return fmt.Errorf("gRPC health check of service %q failed: %w", service, err)
+			}
+
+			if resp.GetStatus() != healthgrpc.HealthCheckResponse_SERVING {
+				return ErrorWithReason(ReasonNotServing,
+					fmt.Errorf("gRPC service %q is not serving: %s", service, resp.GetStatus()))
+			}
+
+			return nil
+		},
+	}
+}
+
 // NewGRPCHealthClient create a grpc client connect to health check server
 func NewGRPCHealthClient(grpcClientCfg *commoncfg.GRPCClient, dialOptions ...grpc.DialOption) (GRPCHealthClientService, error) {
 	dialOptions = append(dialOptions,
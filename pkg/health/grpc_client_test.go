@@ -4,8 +4,12 @@ import (
 	"net"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
+	grpchealth "google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/openkcm/common-sdk/pkg/commoncfg"
@@ -67,3 +71,72 @@ func TestCheckGRPCServerHealth(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCHealthCheck(t *testing.T) {
+	// Arrange
+	healthServer := grpchealth.NewServer()
+	healthServer.SetServingStatus("serving", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("not-serving", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("service-unknown", healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	tests := []struct {
+		name           string
+		service        string
+		expectedStatus health.AvailabilityStatus
+		expectedReason string
+	}{
+		{
+			name:           "ServingThenUp",
+			service:        "serving",
+			expectedStatus: health.StatusUp,
+		},
+		{
+			name:           "NotServingThenDown",
+			service:        "not-serving",
+			expectedStatus: health.StatusDown,
+			expectedReason: health.ReasonNotServing,
+		},
+		{
+			name:           "ServiceUnknownThenDown",
+			service:        "service-unknown",
+			expectedStatus: health.StatusDown,
+			expectedReason: health.ReasonNotServing,
+		},
+		{
+			name:           "UnregisteredServiceThenDown",
+			service:        "unregistered",
+			expectedStatus: health.StatusDown,
+			expectedReason: health.ReasonError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithCheck(health.GRPCHealthCheck("remote", conn, tc.service)),
+			)
+
+			// Act
+			res := ckr.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, res.Details["remote"].Status)
+			assert.Equal(t, tc.expectedReason, res.Details["remote"].Reason)
+		})
+	}
+}
@@ -1,17 +1,27 @@
 package health
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync/atomic"
+	"time"
+
+	slogctx "github.com/veqryn/slog-context"
 )
 
 type (
 	HandlerConfig struct {
-		statusCodeUp   int
-		statusCodeDown int
-		middleware     []Middleware
-		resultWriter   ResultWriter
+		statusCodeUp     int
+		statusCodeDown   int
+		middleware       []Middleware
+		resultWriter     ResultWriter
+		authorizer       func(r *http.Request) bool
+		signingKey       []byte
+		responseCacheTTL time.Duration
+		deferListeners   bool
+		maxConcurrent    int
+		queueTimeout     time.Duration
+		responseTiming   bool
 	}
 
 	// Middleware is factory function that allows creating new instances of
@@ -43,12 +53,23 @@ type (
 
 	// JSONResultWriter writes a Result in JSON format into an
 	// http.ResponseWriter. This ResultWriter is set by default.
-	JSONResultWriter struct{}
+	// Info values that cannot be serialized (e.g., channels) are replaced
+	// with UnserializableValue, so that the rest of the Result is still
+	// written (see JSONResultWriter.LastSerializationFailure).
+	JSONResultWriter struct {
+		lastFailure atomic.Pointer[SerializationFailure]
+	}
 )
 
 // Write implements ResultWriter.Write.
 func (rw *JSONResultWriter) Write(result *Result, statusCode int, w http.ResponseWriter, r *http.Request) error {
-	jsonResp, err := json.Marshal(result)
+	jsonResp, failure, err := marshalResult(result)
+	if failure != nil {
+		rw.lastFailure.Store(failure)
+		slogctx.Warn(r.Context(), "Health result contains values that cannot be serialized",
+			"error", failure.Err, "values", failure.Values)
+	}
+
 	if err != nil {
 		return fmt.Errorf("cannot marshal response: %w", err)
 	}
@@ -58,6 +79,12 @@ func (rw *JSONResultWriter) Write(result *Result, statusCode int, w http.Respons
 	return err
 }
 
+// LastSerializationFailure returns the last failure to serialize a Result as a whole, or nil if there was none.
+// This allows to find the info values that were replaced with UnserializableValue (see JSONResultWriter).
+func (rw *JSONResultWriter) LastSerializationFailure() *SerializationFailure {
+	return rw.lastFailure.Load()
+}
+
 // NewJSONResultWriter creates a new instance of a JSONResultWriter.
 func NewJSONResultWriter() *JSONResultWriter {
 	return &JSONResultWriter{}
@@ -66,21 +93,165 @@ func NewJSONResultWriter() *JSONResultWriter {
 // NewHandler creates a new health check http.Handler.
 func NewHandler(checker Checker, options ...HandlerOption) http.HandlerFunc {
 	cfg := createConfig(options)
-	return func(w http.ResponseWriter, r *http.Request) {
+
+	serve := func(w http.ResponseWriter, r *http.Request, detailed bool) error {
 		// Do the check (with configured middleware)
+		aggregationStartedAt := time.Now()
 		result := withMiddleware(cfg.middleware, func(r *http.Request) Result {
 			return checker.Check(r.Context())
 		})(r)
+		aggregation := time.Since(aggregationStartedAt)
+
+		if !detailed {
+			result = Result{Status: result.Status}
+		}
 
 		// Write HTTP response
 		disableResponseCache(w)
 		statusCode := mapHTTPStatusCode(result.Status, cfg.statusCodeUp, cfg.statusCodeDown)
 
+		var sw *signingResponseWriter
+		if cfg.signingKey != nil {
+			sw = &signingResponseWriter{ResponseWriter: w, key: cfg.signingKey}
+			w = sw
+		}
+
+		var tw *timingResponseWriter
+		if cfg.responseTiming {
+			tw = &timingResponseWriter{ResponseWriter: w}
+			w = tw
+		}
+
+		serializationStartedAt := time.Now()
 		err := cfg.resultWriter.Write(&result, statusCode, w, r)
 		if err != nil {
+			return err
+		}
+
+		if tw != nil {
+			if err := tw.flush(aggregation, serializationStartedAt); err != nil {
+				return err
+			}
+		}
+
+		if sw != nil {
+			return sw.flush()
+		}
+
+		return nil
+	}
+
+	// Only rendering a response counts against the limit, so that responses from the cache are always served.
+	if limiter := newHandlerLimiter(cfg.maxConcurrent, cfg.queueTimeout); limiter != nil {
+		serve = limiter.wrap(serve)
+	}
+
+	var cache *responseCache
+	if cfg.responseCacheTTL > 0 {
+		cache = newResponseCache(cfg.responseCacheTTL, clockOf(checker))
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		detailed := cfg.authorizer == nil || cfg.authorizer(r)
+
+		if cfg.deferListeners {
+			listeners := &deferredListeners{}
+			r = r.WithContext(withDeferredListeners(r.Context(), listeners))
+
+			defer listeners.notifyInBackground(w)
+		}
+
+		if cache != nil {
+			if cache.serve(w, r, detailed, serve) {
+				recordResponseCacheHit(r.Context(), checker)
+			}
+
 			return
 		}
+
+		_ = serve(w, r, detailed)
+	}
+}
+
+// NewReadinessHandler creates a health check http.Handler for readiness probes. It works like NewHandler, but
+// reports the status of the readiness expression (see WithReadinessExpression), and StatusDown while the checker is in draining mode (see Drainer.Drain) or warming up (see WithMinUptime),
+// unless the status is forced (see StatusForcer.ForceStatus). Handlers created with NewHandler (e.g., for liveness
+// probes) report the aggregated status and are not affected by the draining mode and the warm-up, so that the
+// service is not restarted meanwhile.
+func NewReadinessHandler(checker Checker, options ...HandlerOption) http.HandlerFunc {
+	drainer, _ := checker.(Drainer)
+
+	// The gate is the outermost middleware, so that it is not overridden by other middleware.
+	gate := func(next MiddlewareFunc) MiddlewareFunc {
+		return func(r *http.Request) Result {
+			result := next(r)
+			if result.Forced == nil && (result.WarmingUp || drainer != nil && drainer.IsDraining()) {
+				result.Status = StatusDown
+			}
+
+			return result
+		}
+	}
+
+	// The readiness expression is the innermost middleware, so that other middleware sees the readiness status.
+	expression := func(next MiddlewareFunc) MiddlewareFunc {
+		return func(r *http.Request) Result {
+			result := next(r)
+			if result.Forced == nil && result.readiness != "" {
+				result.Status = result.readiness
+			}
+
+			return result
+		}
+	}
+
+	options = append(append([]HandlerOption{WithMiddleware(gate)}, options...), WithMiddleware(expression))
+
+	return NewHandler(checker, options...)
+}
+
+// NewDrainHandler creates an http.Handler that puts the checker into draining mode (see Drainer.Drain)
+// on POST requests. This allows to drain traffic from a service by script, e.g. with "POST /health/drain".
+// Draining only affects the readiness handlers of the checker (see NewReadinessHandler). If the checker does
+// not implement Drainer, all requests are answered with 501 Not Implemented.
+func NewDrainHandler(checker Checker) http.HandlerFunc {
+	drainer, ok := checker.(Drainer)
+	if !ok {
+		return notImplementedHandler
 	}
+
+	return newStateToggleHandler(drainer.Drain)
+}
+
+// NewUndrainHandler creates an http.Handler that ends the draining mode of the checker (see Drainer.Undrain)
+// on POST requests, e.g. with "POST /health/undrain". Like NewDrainHandler, it requires a Drainer.
+func NewUndrainHandler(checker Checker) http.HandlerFunc {
+	drainer, ok := checker.(Drainer)
+	if !ok {
+		return notImplementedHandler
+	}
+
+	return newStateToggleHandler(drainer.Undrain)
+}
+
+func newStateToggleHandler(toggle func()) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		toggle()
+
+		disableResponseCache(w)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// notImplementedHandler answers requests for a capability that the Checker does not implement (see Drainer).
+func notImplementedHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
 func disableResponseCache(w http.ResponseWriter) {
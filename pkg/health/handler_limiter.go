@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// errRequestRejected is returned by a limited render function for a request that was rejected (see handlerLimiter.wrap).
+var errRequestRejected = errors.New("request rejected")
+
+// handlerLimiter bounds the number of concurrent health check evaluations of a handler
+// (see WithMaxConcurrentHandlers).
+type handlerLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+func newHandlerLimiter(size int, queueTimeout time.Duration) *handlerLimiter {
+	if size <= 0 {
+		return nil
+	}
+
+	return &handlerLimiter{
+		slots:        make(chan struct{}, size),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// acquire occupies a slot. If all slots are occupied, it waits for a free slot for at most the queue timeout.
+// It returns false if no slot could be occupied. Otherwise, release must be called once the evaluation is done.
+func (l *handlerLimiter) acquire(ctx context.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if l.queueTimeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (l *handlerLimiter) release() {
+	<-l.slots
+}
+
+// wrap limits the concurrent calls of the render function. Excess requests are rejected (see rejectExcessRequest)
+// and the render function returns errRequestRejected.
+func (l *handlerLimiter) wrap(
+	render func(w http.ResponseWriter, r *http.Request, detailed bool) error,
+) func(w http.ResponseWriter, r *http.Request, detailed bool) error {
+	return func(w http.ResponseWriter, r *http.Request, detailed bool) error {
+		if !l.acquire(r.Context()) {
+			rejectExcessRequest(w)
+			return errRequestRejected
+		}
+
+		defer l.release()
+
+		return render(w, r, detailed)
+	}
+}
+
+// rejectExcessRequest responds with 503 Service Unavailable and asks the client to retry (see WithMaxConcurrentHandlers).
+func rejectExcessRequest(w http.ResponseWriter) {
+	disableResponseCache(w)
+	w.Header().Set("Retry-After", "1")
+	http.Error(w, "too many concurrent health check requests", http.StatusServiceUnavailable)
+}
@@ -0,0 +1,158 @@
+package health_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// blockingCheckerMock returns a checker whose Check calls block until release is closed.
+func blockingCheckerMock(entered *atomic.Int32, release <-chan struct{}) *checkerMock {
+	ckr := checkerMock{}
+	ckr.On("Check", mock.Anything).Run(func(mock.Arguments) {
+		entered.Add(1)
+		<-release
+	}).Return(health.Result{Status: health.StatusUp})
+
+	return &ckr
+}
+
+// serveConcurrently serves n requests in the background and returns a function that waits for their status codes.
+func serveConcurrently(handler http.Handler, n int) func() []int {
+	var wg sync.WaitGroup
+
+	codes := make([]int, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+			codes[i] = rec.Code
+		}()
+	}
+
+	return func() []int {
+		wg.Wait()
+		return codes
+	}
+}
+
+func TestMaxConcurrentHandlersRejectsExcessRequests(t *testing.T) {
+	// Arrange
+	var entered atomic.Int32
+	release := make(chan struct{})
+	handler := health.NewHandler(blockingCheckerMock(&entered, release), health.WithMaxConcurrentHandlers(2))
+
+	wait := serveConcurrently(handler, 2)
+	require.Eventually(t, func() bool { return entered.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Act
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	close(release)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, wait())
+	assert.Equal(t, int32(2), entered.Load())
+}
+
+func TestMaxConcurrentHandlersQueuesExcessRequests(t *testing.T) {
+	// Arrange
+	var entered atomic.Int32
+	release := make(chan struct{})
+	handler := health.NewHandler(blockingCheckerMock(&entered, release),
+		health.WithMaxConcurrentHandlers(1), health.WithHandlerQueueTimeout(5*time.Second))
+
+	waitFirst := serveConcurrently(handler, 1)
+	require.Eventually(t, func() bool { return entered.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	waitQueued := serveConcurrently(handler, 2)
+	time.Sleep(20 * time.Millisecond)
+	enteredWhileBlocked := entered.Load()
+	close(release)
+
+	// Assert
+	assert.Equal(t, int32(1), enteredWhileBlocked)
+	assert.Equal(t, []int{http.StatusOK}, waitFirst())
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, waitQueued())
+	assert.Equal(t, int32(3), entered.Load())
+}
+
+func TestMaxConcurrentHandlersRejectsAfterQueueTimeout(t *testing.T) {
+	// Arrange
+	var entered atomic.Int32
+	release := make(chan struct{})
+	handler := health.NewHandler(blockingCheckerMock(&entered, release),
+		health.WithMaxConcurrentHandlers(1), health.WithHandlerQueueTimeout(20*time.Millisecond))
+
+	wait := serveConcurrently(handler, 1)
+	require.Eventually(t, func() bool { return entered.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	elapsed := time.Since(start)
+	close(release)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.GreaterOrEqual(t, elapsed, 20*time.Millisecond)
+	assert.Equal(t, []int{http.StatusOK}, wait())
+}
+
+func TestMaxConcurrentHandlersServesCachedResponses(t *testing.T) {
+	// Arrange
+	var entered atomic.Int32
+	var block atomic.Bool
+	release := make(chan struct{})
+	ckr := checkerMock{}
+	ckr.On("Check", mock.Anything).Run(func(mock.Arguments) {
+		if block.Load() {
+			entered.Add(1)
+			<-release
+		}
+	}).Return(health.Result{Status: health.StatusUp})
+
+	handler := health.NewHandler(&ckr,
+		health.WithMaxConcurrentHandlers(1),
+		health.WithResponseCache(time.Hour),
+		health.WithDetailsAuthorizer(func(r *http.Request) bool { return r.URL.Query().Has("details") }),
+	)
+
+	// The response for callers without details is cached before the only slot is occupied.
+	warmUp := httptest.NewRecorder()
+	handler.ServeHTTP(warmUp, httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.Equal(t, http.StatusOK, warmUp.Code)
+
+	block.Store(true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health?details", nil))
+	}()
+	require.Eventually(t, func() bool { return entered.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	close(release)
+	<-done
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -118,3 +119,143 @@ func TestWhenChecksEmptyThenHandlerResultContainNoChecksMap(t *testing.T) {
 		t.Errorf("response does not contain the expected result")
 	}
 }
+
+func TestDrainAndUndrainHandlers(t *testing.T) {
+	// Arrange
+	check := health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}
+	ckr := health.NewChecker(health.WithCheck(check))
+
+	mux := http.NewServeMux()
+	mux.Handle("/health/ready", health.NewReadinessHandler(ckr))
+	mux.Handle("/health/live", health.NewHandler(ckr))
+	mux.Handle("/health/drain", health.NewDrainHandler(ckr))
+	mux.Handle("/health/undrain", health.NewUndrainHandler(ckr))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	// Act + Assert
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/health/ready").Code)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPost, "/health/drain").Code)
+	assert.True(t, ckr.(health.Drainer).IsDraining())
+
+	drained := serve(http.MethodGet, "/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, drained.Code)
+	assert.Contains(t, drained.Body.String(), `"draining":true`)
+
+	// Liveness is not affected by the draining mode of the same Checker.
+	live := serve(http.MethodGet, "/health/live")
+	assert.Equal(t, http.StatusOK, live.Code)
+	assert.Contains(t, live.Body.String(), `"draining":true`)
+	assert.Equal(t, health.StatusUp, ckr.Check(t.Context()).Status)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPost, "/health/undrain").Code)
+	assert.False(t, ckr.(health.Drainer).IsDraining())
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/health/ready").Code)
+}
+
+func TestReadinessHandlerRespectsForcedStatus(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker()
+	ckr.(health.Drainer).Drain()
+	ckr.(health.StatusForcer).ForceStatus(health.StatusUp, "maintenance")
+	w := httptest.NewRecorder()
+
+	// Act
+	health.NewReadinessHandler(ckr).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDrainHandlerRejectsNonPostRequests(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker()
+	w := httptest.NewRecorder()
+
+	// Act
+	health.NewDrainHandler(ckr).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/drain", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+	assert.False(t, ckr.(health.Drainer).IsDraining())
+}
+
+func TestHandlersRequireOptionalInterfaces(t *testing.T) {
+	ckr := &checkerMock{}
+
+	handlers := map[string]http.Handler{
+		"Drain":          health.NewDrainHandler(ckr),
+		"Undrain":        health.NewUndrainHandler(ckr),
+		"ConfigSnapshot": health.NewConfigSnapshotHandler(ckr),
+		"Debug":          health.NewDebugHandler(ckr, func(*http.Request) bool { return true }),
+		"Grafana":        health.NewGrafanaHandler(ckr),
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			// Assert
+			assert.Equal(t, http.StatusNotImplemented, w.Code)
+		})
+	}
+}
+
+func TestDetailsAuthorizer(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithInfo(map[string]interface{}{"version": "1.0.0"}),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := health.NewHandler(ckr, health.WithDetailsAuthorizer(func(r *http.Request) bool {
+		return strings.HasPrefix(r.RemoteAddr, "10.")
+	}))
+
+	tests := []struct {
+		name            string
+		remoteAddr      string
+		expectedDetails bool
+	}{
+		{
+			name:            "InternalCallerReceivesDetails",
+			remoteAddr:      "10.0.0.1:1234",
+			expectedDetails: true,
+		},
+		{
+			name:            "ExternalCallerReceivesStatusOnly",
+			remoteAddr:      "203.0.113.1:1234",
+			expectedDetails: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/health", nil)
+			r.RemoteAddr = tc.remoteAddr
+
+			// Act
+			handler.ServeHTTP(w, r)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			if tc.expectedDetails {
+				assert.Contains(t, w.Body.String(), `"details":{"check":`)
+				assert.Contains(t, w.Body.String(), `"info":{"version":"1.0.0"}`)
+			} else {
+				assert.JSONEq(t, `{"status":"up"}`, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Package healthtest provides test helpers for code that uses a health.Checker, such as assertions on the
+// status of checks and functions that wait for a status. The helpers report failures with testing.TB.Errorf
+// and return whether the assertion holds, so that a test can decide whether to continue.
+package healthtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// pollInterval is the interval in which the waiting helpers evaluate the checker.
+const pollInterval = 10 * time.Millisecond
+
+// AssertStatus evaluates the checker (see health.Checker.Check) and reports an error if its aggregated
+// status is not the expected status. It returns true if the status matches.
+func AssertStatus(t testing.TB, checker health.Checker, expected health.AvailabilityStatus) bool {
+	t.Helper()
+
+	result := checker.Check(t.Context())
+	if result.Status != expected {
+		t.Errorf("expected aggregated status %q, but got %q", expected, result.Status)
+		return false
+	}
+
+	return true
+}
+
+// AssertCheckUp evaluates the checker and reports an error if the check with the given name is not up.
+// It returns true if the check is up.
+func AssertCheckUp(t testing.TB, checker health.Checker, name string) bool {
+	t.Helper()
+
+	return AssertCheckStatus(t, checker, name, health.StatusUp)
+}
+
+// AssertCheckDown evaluates the checker and reports an error if the check with the given name is not down.
+// It returns true if the check is down.
+func AssertCheckDown(t testing.TB, checker health.Checker, name string) bool {
+	t.Helper()
+
+	return AssertCheckStatus(t, checker, name, health.StatusDown)
+}
+
+// AssertCheckStatus evaluates the checker and reports an error if the check with the given name does not have
+// the expected status. The status is taken from the check details, so the details must not be disabled (see
+// health.WithDisabledDetails). It returns true if the status matches.
+func AssertCheckStatus(t testing.TB, checker health.Checker, name string, expected health.AvailabilityStatus) bool {
+	t.Helper()
+
+	result, ok := checker.Check(t.Context()).Details[name]
+	if !ok {
+		t.Errorf("check %q not found in the check details", name)
+		return false
+	}
+
+	if result.Status != expected {
+		t.Errorf("expected status %q of check %q, but got %q (error: %v)", expected, name, result.Status, result.Error)
+		return false
+	}
+
+	return true
+}
+
+// WaitForStatus evaluates the checker repeatedly until its aggregated status is the expected status, e.g.,
+// to wait for periodic checks. It reports an error if the status does not match within the timeout.
+// It returns true if the status matches.
+func WaitForStatus(t testing.TB, checker health.Checker, expected health.AvailabilityStatus, timeout time.Duration) bool {
+	t.Helper()
+
+	var actual health.AvailabilityStatus
+
+	matched := poll(t, timeout, func() bool {
+		actual = checker.Check(t.Context()).Status
+		return actual == expected
+	})
+
+	if !matched {
+		t.Errorf("expected aggregated status %q within %v, but got %q", expected, timeout, actual)
+	}
+
+	return matched
+}
+
+// WaitForCheckStatus evaluates the checker repeatedly until the check with the given name has the expected
+// status. It reports an error if the status does not match within the timeout. It returns true if the
+// status matches.
+func WaitForCheckStatus(
+	t testing.TB,
+	checker health.Checker,
+	name string,
+	expected health.AvailabilityStatus,
+	timeout time.Duration,
+) bool {
+	t.Helper()
+
+	var (
+		actual health.CheckResult
+		found  bool
+	)
+
+	matched := poll(t, timeout, func() bool {
+		actual, found = checker.Check(t.Context()).Details[name]
+		return found && actual.Status == expected
+	})
+
+	switch {
+	case matched:
+	case !found:
+		t.Errorf("check %q not found in the check details within %v", name, timeout)
+	default:
+		t.Errorf("expected status %q of check %q within %v, but got %q (error: %v)",
+			expected, name, timeout, actual.Status, actual.Error)
+	}
+
+	return matched
+}
+
+// poll calls condition in the poll interval until it returns true, the timeout elapsed or the test ended.
+func poll(t testing.TB, timeout time.Duration, condition func() bool) bool {
+	t.Helper()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if condition() {
+			return true
+		}
+
+		select {
+		case <-deadline.C:
+			return condition()
+		case <-t.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
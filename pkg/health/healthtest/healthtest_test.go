@@ -0,0 +1,168 @@
+package healthtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+	"github.com/openkcm/common-sdk/pkg/health/healthtest"
+)
+
+// recordingTB records the errors reported by the helpers instead of failing the test.
+type recordingTB struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newToggledChecker(failing *atomic.Bool, options ...health.Option) health.Checker {
+	return health.NewChecker(append([]health.Option{
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if failing.Load() {
+					return errors.New("unavailable")
+				}
+				return nil
+			},
+		}),
+	}, options...)...)
+}
+
+func TestAssertCheckUp(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := newToggledChecker(&failing)
+	tb := &recordingTB{TB: t}
+
+	// Act
+	up := healthtest.AssertCheckUp(tb, ckr, "database")
+	failing.Store(true)
+	down := healthtest.AssertCheckUp(tb, ckr, "database")
+
+	// Assert
+	assert.True(t, up)
+	assert.False(t, down)
+	assert.Equal(t, []string{`expected status "up" of check "database", but got "down" (error: unavailable)`}, tb.errors)
+}
+
+func TestAssertCheckDown(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := newToggledChecker(&failing)
+	tb := &recordingTB{TB: t}
+
+	// Act
+	up := healthtest.AssertCheckDown(tb, ckr, "database")
+	failing.Store(true)
+	down := healthtest.AssertCheckDown(tb, ckr, "database")
+
+	// Assert
+	assert.False(t, up)
+	assert.True(t, down)
+	assert.Len(t, tb.errors, 1)
+}
+
+func TestAssertCheckStatusWithUnknownCheck(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := newToggledChecker(&failing)
+	tb := &recordingTB{TB: t}
+
+	// Act
+	ok := healthtest.AssertCheckStatus(tb, ckr, "cache", health.StatusUp)
+
+	// Assert
+	assert.False(t, ok)
+	assert.Equal(t, []string{`check "cache" not found in the check details`}, tb.errors)
+}
+
+func TestAssertStatus(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := newToggledChecker(&failing)
+	tb := &recordingTB{TB: t}
+
+	// Act
+	up := healthtest.AssertStatus(tb, ckr, health.StatusUp)
+	down := healthtest.AssertStatus(tb, ckr, health.StatusDown)
+
+	// Assert
+	assert.True(t, up)
+	assert.False(t, down)
+	assert.Equal(t, []string{`expected aggregated status "down", but got "up"`}, tb.errors)
+}
+
+func TestWaitForStatus(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := newToggledChecker(&failing)
+	tb := &recordingTB{TB: t}
+
+	time.AfterFunc(30*time.Millisecond, func() { failing.Store(true) })
+
+	// Act
+	ok := healthtest.WaitForStatus(tb, ckr, health.StatusDown, time.Second)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Empty(t, tb.errors)
+}
+
+func TestWaitForStatusTimesOut(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := newToggledChecker(&failing)
+	tb := &recordingTB{TB: t}
+
+	// Act
+	ok := healthtest.WaitForStatus(tb, ckr, health.StatusDown, 30*time.Millisecond)
+
+	// Assert
+	assert.False(t, ok)
+	assert.Equal(t, []string{`expected aggregated status "down" within 30ms, but got "up"`}, tb.errors)
+}
+
+func TestWaitForCheckStatus(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	failing.Store(true)
+
+	ckr := newToggledChecker(&failing)
+	tb := &recordingTB{TB: t}
+
+	time.AfterFunc(30*time.Millisecond, func() { failing.Store(false) })
+
+	// Act
+	ok := healthtest.WaitForCheckStatus(tb, ckr, "database", health.StatusUp, time.Second)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Empty(t, tb.errors)
+}
+
+func TestWaitForCheckStatusTimesOut(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	ckr := newToggledChecker(&failing, health.WithDisabledDetails())
+	tb := &recordingTB{TB: t}
+
+	// Act
+	ok := healthtest.WaitForCheckStatus(tb, ckr, "database", health.StatusUp, 30*time.Millisecond)
+
+	// Assert
+	assert.False(t, ok)
+	assert.Equal(t, []string{`check "database" not found in the check details within 30ms`}, tb.errors)
+}
@@ -0,0 +1,135 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultWindowedAggregationHistorySize is the number of retained entries per check, if windowed
+// aggregation is enabled without configuring a history size (see WithWindowedAggregation).
+const defaultWindowedAggregationHistorySize = 100
+
+type (
+	// History holds the retained results of a Checker (see WithHistory).
+	History struct {
+		// Aggregate holds the history of the aggregated health status.
+		Aggregate []HistoryEntry
+		// Checks holds the history of each check, keyed by the check name.
+		Checks map[string][]HistoryEntry
+	}
+
+	// HistoryEntry is a single retained result.
+	HistoryEntry struct {
+		// Timestamp holds the time of when the result was recorded.
+		Timestamp time.Time
+		// Status holds the availability status at that time.
+		Status AvailabilityStatus
+	}
+
+	// historyBuffer retains the last results of all checks and the aggregated status.
+	// It is synchronized independently of the Checker, so that reading the history
+	// is not blocked by running checks.
+	historyBuffer struct {
+		size      int
+		mtx       sync.RWMutex
+		aggregate []HistoryEntry
+		checks    map[string][]HistoryEntry
+	}
+)
+
+func newHistoryBuffer(size int) *historyBuffer {
+	return &historyBuffer{
+		size:   size,
+		checks: map[string][]HistoryEntry{},
+	}
+}
+
+func (hb *historyBuffer) enabled() bool {
+	return hb.size > 0
+}
+
+func (hb *historyBuffer) recordCheck(checkName string, entry HistoryEntry) {
+	if !hb.enabled() {
+		return
+	}
+
+	hb.mtx.Lock()
+	defer hb.mtx.Unlock()
+
+	hb.checks[checkName] = appendBounded(hb.checks[checkName], entry, hb.size)
+}
+
+func (hb *historyBuffer) recordAggregate(entry HistoryEntry) {
+	if !hb.enabled() {
+		return
+	}
+
+	hb.mtx.Lock()
+	defer hb.mtx.Unlock()
+
+	hb.aggregate = appendBounded(hb.aggregate, entry, hb.size)
+}
+
+func (hb *historyBuffer) snapshot() History {
+	hb.mtx.RLock()
+	defer hb.mtx.RUnlock()
+
+	checks := make(map[string][]HistoryEntry, len(hb.checks))
+	for name, entries := range hb.checks {
+		checks[name] = append([]HistoryEntry(nil), entries...)
+	}
+
+	return History{
+		Aggregate: append([]HistoryEntry(nil), hb.aggregate...),
+		Checks:    checks,
+	}
+}
+
+func appendBounded(entries []HistoryEntry, entry HistoryEntry, size int) []HistoryEntry {
+	entries = append(entries, entry)
+	if len(entries) > size {
+		// Copy to release the memory of the dropped entries.
+		entries = append([]HistoryEntry(nil), entries[len(entries)-size:]...)
+	}
+
+	return entries
+}
+
+// windowedStatus determines the status of a check based on its history entries since the given time:
+// the check is only considered down, if it was down for the majority of these entries. Otherwise, a check
+// that is currently down is considered to have the most frequent of its other statuses in the window
+// (the more critical one on a tie). Any other latest status is returned as is.
+func windowedStatus(entries []HistoryEntry, latest AvailabilityStatus, since time.Time) AvailabilityStatus {
+	counts := map[AvailabilityStatus]int{}
+	total := 0
+
+	for _, entry := range entries {
+		if entry.Timestamp.Before(since) {
+			continue
+		}
+
+		counts[entry.Status]++
+		total++
+	}
+
+	if total == 0 {
+		return latest
+	}
+
+	if counts[StatusDown]*2 > total {
+		return StatusDown
+	}
+
+	if latest != StatusDown {
+		return latest
+	}
+
+	status, maxCount := StatusUp, 0
+	for _, candidate := range []AvailabilityStatus{StatusDegraded, StatusUnknown, StatusUp} {
+		if counts[candidate] > maxCount {
+			status, maxCount = candidate, counts[candidate]
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,134 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// toggledCheck returns a check that fails while fail is set.
+func toggledCheck(name string, fail *atomic.Bool) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			if fail.Load() {
+				return errors.New("unavailable")
+			}
+			return nil
+		},
+	}
+}
+
+func TestHistoryRetainsLastEntries(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	clock := newFakeClock(time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithHistory(3),
+		health.WithCheck(toggledCheck("check", &fail)),
+	)
+
+	// Act
+	for _, failing := range []bool{false, true, false, true} {
+		fail.Store(failing)
+		ckr.Check(t.Context())
+		clock.Advance(time.Minute)
+	}
+	history := ckr.(health.HistoryProvider).History()
+
+	// Assert
+	require.Len(t, history.Checks["check"], 3)
+	assert.Equal(t, []health.HistoryEntry{
+		{Timestamp: time.Date(2025, time.June, 2, 12, 1, 0, 0, time.UTC), Status: health.StatusDown},
+		{Timestamp: time.Date(2025, time.June, 2, 12, 2, 0, 0, time.UTC), Status: health.StatusUp},
+		{Timestamp: time.Date(2025, time.June, 2, 12, 3, 0, 0, time.UTC), Status: health.StatusDown},
+	}, history.Checks["check"])
+	assert.Equal(t, history.Checks["check"], history.Aggregate)
+}
+
+func TestHistoryDisabledByDefault(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+	history := ckr.(health.HistoryProvider).History()
+
+	// Assert
+	assert.Empty(t, history.Aggregate)
+	assert.Empty(t, history.Checks)
+}
+
+func TestWindowedAggregation(t *testing.T) {
+	tests := []struct {
+		name           string
+		results        []bool // true means the check fails
+		expectedStatus health.AvailabilityStatus
+	}{
+		{
+			name:           "TransientFailureIsSmoothed",
+			results:        []bool{false, false, false, true},
+			expectedStatus: health.StatusUp,
+		},
+		{
+			name:           "MajorityDownThenDown",
+			results:        []bool{false, true, true, true},
+			expectedStatus: health.StatusDown,
+		},
+		{
+			name:           "MajorityDownWithRecentSuccessThenDown",
+			results:        []bool{true, true, true, false},
+			expectedStatus: health.StatusDown,
+		},
+		{
+			name:           "ResultsOutsideWindowAreIgnored",
+			results:        []bool{false, false, false, false, false, false, true, true, true},
+			expectedStatus: health.StatusDown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var fail atomic.Bool
+			clock := newFakeClock(time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC))
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithDisabledCache(),
+				health.WithClock(clock),
+				health.WithWindowedAggregation(4*time.Minute),
+				health.WithCheck(toggledCheck("flappy", &fail)),
+			)
+
+			// Act
+			var res health.Result
+			for _, failing := range tc.results {
+				clock.Advance(time.Minute)
+				fail.Store(failing)
+				res = ckr.Check(t.Context())
+			}
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			// The check details still report the latest result.
+			expectedCheckStatus := health.StatusUp
+			if tc.results[len(tc.results)-1] {
+				expectedCheckStatus = health.StatusDown
+			}
+			assert.Equal(t, expectedCheckStatus, res.Details["flappy"].Status)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPCheck creates a check that sends a GET request to the given URL. The check succeeds if the
+// server responds with a status code below 400. Responses with status code 503 (Service Unavailable)
+// are reported with the reason ReasonUnavailable. The request is bound to the context of the check
+// evaluation, so it adheres to the check timeout.
+func HTTPCheck(name, url string) Check {
+	return Check{
+		Name:   name,
+		target: url,
+		Check: func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return fmt.Errorf("cannot create request: %w", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			// The body is drained, so that the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+
+			switch {
+			case resp.StatusCode == http.StatusServiceUnavailable:
+				return ErrorWithReason(ReasonUnavailable, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+			case resp.StatusCode >= http.StatusBadRequest:
+				return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+			default:
+				return nil
+			}
+		},
+	}
+}
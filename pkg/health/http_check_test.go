@@ -0,0 +1,62 @@
+package health_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestHTTPCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		expectedErr    bool
+		expectedReason string
+	}{
+		{
+			name:       "OK",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:           "ServiceUnavailable",
+			statusCode:     http.StatusServiceUnavailable,
+			expectedErr:    true,
+			expectedReason: health.ReasonUnavailable,
+		},
+		{
+			name:           "InternalServerError",
+			statusCode:     http.StatusInternalServerError,
+			expectedErr:    true,
+			expectedReason: health.ReasonError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			check := health.HTTPCheck("service", server.URL)
+
+			// Act
+			err := check.Check(t.Context())
+
+			// Assert
+			if !tc.expectedErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedReason, health.ReasonOf(err))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package health
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type cycleIDContextKey struct{}
+
+// CycleIDFromContext returns the ID of the evaluation cycle that a check function, interceptor or
+// listener is executed in. Each call of Checker.Check that executes at least one check and each
+// evaluation of a periodic check is a separate cycle. It returns an empty string if the context
+// does not belong to an evaluation cycle.
+func CycleIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(cycleIDContextKey{}).(string)
+	return id
+}
+
+func withCycleID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, cycleIDContextKey{}, id)
+}
+
+func newRandomID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,108 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func sequentialIDs() func() string {
+	var (
+		mtx sync.Mutex
+		n   int
+	)
+
+	return func() string {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		n++
+
+		return fmt.Sprintf("id-%d", n)
+	}
+}
+
+func TestIDGeneratorForCycles(t *testing.T) {
+	// Arrange
+	var (
+		mtx             sync.Mutex
+		observedIDs     []string
+		listenerCycleID string
+	)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithIDGenerator(sequentialIDs()),
+		health.WithStatusListener(func(ctx context.Context, state health.State) {
+			listenerCycleID = health.CycleIDFromContext(ctx)
+		}),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				mtx.Lock()
+				defer mtx.Unlock()
+
+				observedIDs = append(observedIDs, health.CycleIDFromContext(ctx))
+
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	first := ckr.Check(t.Context())
+	second := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, "id-1", first.CycleID)
+	assert.Equal(t, "id-2", second.CycleID)
+	assert.Equal(t, []string{"id-1", "id-2"}, observedIDs)
+	assert.Equal(t, "id-1", listenerCycleID)
+
+	data, err := json.Marshal(second)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"cycleId":"id-2"`)
+}
+
+func TestNoCycleWithoutCheckExecution(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCacheDuration(time.Hour),
+		health.WithIDGenerator(sequentialIDs()),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	first := ckr.Check(t.Context())
+	cached := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, "id-1", first.CycleID)
+	assert.Equal(t, "id-1", cached.CycleID)
+}
+
+func TestDefaultIDGenerator(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	first := ckr.Check(t.Context())
+	second := ckr.Check(t.Context())
+
+	// Assert
+	assert.Regexp(t, "^[0-9a-f]{32}$", first.CycleID)
+	assert.NotEqual(t, first.CycleID, second.CycleID)
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	slogctx "github.com/veqryn/slog-context"
+)
+
+type (
+	// StatusWriter is the minimal interface that is required by WithK8sStatusWriter to write the health state
+	// into the status subresource of a Kubernetes custom resource. It is typically implemented by patching the
+	// status of the resource with client-go or the status client of controller-runtime.
+	StatusWriter interface {
+		// WriteStatus writes the status into the status subresource of the custom resource.
+		WriteStatus(ctx context.Context, status ResourceHealthStatus) error
+	}
+
+	// ResourceHealthStatus is the health state of the Checker in a form that is suitable for the status
+	// subresource of a Kubernetes custom resource (see WithK8sStatusWriter).
+	ResourceHealthStatus struct {
+		// Status is the aggregated health status.
+		Status AvailabilityStatus `json:"status"`
+		// LastTransitionTime holds the time of when the aggregated status last changed (see State.LastStatusChangeAt).
+		LastTransitionTime time.Time `json:"lastTransitionTime"`
+		// FailingChecks holds the names of all checks which are not up, sorted by name.
+		FailingChecks []string `json:"failingChecks,omitempty"`
+		// Message is a human-readable summary of the health state.
+		Message string `json:"message,omitempty"`
+		// IncidentID holds the ID of the current incident (see State.IncidentID).
+		IncidentID string `json:"incidentId,omitempty"`
+	}
+)
+
+// WithK8sStatusWriter writes the health state of the Checker into the status subresource of a Kubernetes
+// custom resource whenever the aggregated health status changes (e.g. from "up" to "down"), so that the
+// cluster reflects the health of the application (e.g., for operators). The status is written in the background,
+// so a slow API server does not delay the health checks, and Checker.Stop waits for pending writes. A status that
+// cannot be written is logged and not retried, so the resource is updated again with the next status change.
+func WithK8sStatusWriter(writer StatusWriter) Option {
+	return func(cfg *checkerConfig) {
+		cfg.transitionPublishers = append(cfg.transitionPublishers, func(ctx context.Context, state State) {
+			if err := writer.WriteStatus(ctx, newResourceHealthStatus(state)); err != nil {
+				slogctx.Error(ctx, "Failed to write health status to Kubernetes resource", "error", err)
+			}
+		})
+	}
+}
+
+func newResourceHealthStatus(state State) ResourceHealthStatus {
+	status := ResourceHealthStatus{
+		Status:             state.Status,
+		LastTransitionTime: state.LastStatusChangeAt,
+		IncidentID:         state.IncidentID,
+	}
+
+	for name, checkState := range state.CheckState {
+		if checkState.Status != StatusUp {
+			status.FailingChecks = append(status.FailingChecks, name)
+		}
+	}
+	sort.Strings(status.FailingChecks)
+
+	if len(status.FailingChecks) > 0 {
+		status.Message = fmt.Sprintf("%d of %d checks not up: %s",
+			len(status.FailingChecks), len(state.CheckState), strings.Join(status.FailingChecks, ", "))
+	} else {
+		status.Message = fmt.Sprintf("all %d checks up", len(state.CheckState))
+	}
+
+	return status
+}
@@ -0,0 +1,101 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type fakeStatusWriter struct {
+	mtx      sync.Mutex
+	statuses []health.ResourceHealthStatus
+	err      error
+}
+
+func (w *fakeStatusWriter) WriteStatus(_ context.Context, status health.ResourceHealthStatus) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.statuses = append(w.statuses, status)
+
+	return w.err
+}
+
+// awaitStatuses waits until n statuses were written, since they are written in the background.
+func (w *fakeStatusWriter) awaitStatuses(t *testing.T, n int) []health.ResourceHealthStatus {
+	t.Helper()
+
+	get := func() []health.ResourceHealthStatus {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+
+		return append([]health.ResourceHealthStatus{}, w.statuses...)
+	}
+	require.Eventually(t, func() bool { return len(get()) >= n }, time.Second, time.Millisecond)
+
+	return get()
+}
+
+func TestK8sStatusWriter(t *testing.T) {
+	// Arrange
+	writer := &fakeStatusWriter{}
+
+	var failing atomic.Bool
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithK8sStatusWriter(writer),
+		health.WithCheck(toggledCheck("database", &failing)),
+		health.WithCheck(health.Check{Name: "cache", Check: func(context.Context) error { return nil }}),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+	clock.Advance(time.Minute)
+	ckr.Check(t.Context())
+	clock.Advance(time.Minute)
+	failing.Store(true)
+	ckr.Check(t.Context())
+
+	// Assert
+	statuses := writer.awaitStatuses(t, 2)
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, health.StatusUp, statuses[0].Status)
+	assert.Empty(t, statuses[0].FailingChecks)
+	assert.Equal(t, "all 2 checks up", statuses[0].Message)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), statuses[0].LastTransitionTime)
+
+	assert.Equal(t, health.StatusDown, statuses[1].Status)
+	assert.Equal(t, []string{"database"}, statuses[1].FailingChecks)
+	assert.Equal(t, "1 of 2 checks not up: database", statuses[1].Message)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 2, 0, 0, time.UTC), statuses[1].LastTransitionTime)
+}
+
+func TestK8sStatusWriterFailureDoesNotAffectChecker(t *testing.T) {
+	// Arrange
+	writer := &fakeStatusWriter{err: errors.New("forbidden")}
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithK8sStatusWriter(writer),
+		health.WithCheck(health.Check{Name: "cache", Check: func(context.Context) error { return nil }}),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Len(t, writer.awaitStatuses(t, 1), 1)
+}
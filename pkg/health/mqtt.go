@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	slogctx "github.com/veqryn/slog-context"
+)
+
+type (
+	// MQTTClient is the minimal interface of an MQTT client that is required by WithMQTTPublisher.
+	// Quality of service and retention are left to the client, e.g., to retain the last state for new subscribers.
+	MQTTClient interface {
+		// Publish publishes the payload to the given topic.
+		Publish(topic string, payload []byte) error
+	}
+
+	mqttStatePayload struct {
+		Status     AvailabilityStatus     `json:"status"`
+		DownSince  *time.Time             `json:"downSince,omitempty"`
+		CycleID    string                 `json:"cycleId,omitempty"`
+		IncidentID string                 `json:"incidentId,omitempty"`
+		Checks     map[string]CheckResult `json:"checks,omitempty"`
+	}
+)
+
+// WithMQTTPublisher publishes the State of the Checker to the given MQTT topic whenever the aggregated
+// health status changes (e.g. from "up" to "down"). The State is serialized to JSON in the same format
+// as the check details of a Result. Example:
+// { "status":"down", "downSince":"...", "checks":{ "database":{ "status":"down", "error":"..." } } }.
+// Payloads are published by a background goroutine in the order of the status changes, so a slow or
+// disconnected broker does not block the Checker. Publishing errors are logged with the topic.
+func WithMQTTPublisher(client MQTTClient, topic string) Option {
+	return func(cfg *checkerConfig) {
+		cfg.transitionPublishers = append(cfg.transitionPublishers, func(ctx context.Context, state State) {
+			payload, err := json.Marshal(newMQTTStatePayload(state))
+			if err != nil {
+				slogctx.Error(ctx, "Failed to marshal health state for MQTT", "topic", topic, "error", err)
+				return
+			}
+
+			if err := client.Publish(topic, payload); err != nil {
+				slogctx.Error(ctx, "Failed to publish health state to MQTT", "topic", topic, "error", err)
+			}
+		})
+	}
+}
+
+func newMQTTStatePayload(state State) mqttStatePayload {
+	payload := mqttStatePayload{
+		Status:     state.Status,
+		CycleID:    state.CycleID,
+		IncidentID: state.IncidentID,
+		Checks:     make(map[string]CheckResult, len(state.CheckState)),
+	}
+
+	if !state.DownSince.IsZero() {
+		downSince := state.DownSince
+		payload.DownSince = &downSince
+	}
+
+	for name, checkState := range state.CheckState {
+		payload.Checks[name] = CheckResult{
+			Status:     checkState.Status,
+			Timestamp:  checkState.LastCheckedAt,
+			Error:      checkState.Result,
+			Reason:     checkState.Reason,
+			SubResults: checkState.SubResults,
+		}
+	}
+
+	return payload
+}
@@ -0,0 +1,156 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type (
+	fakeMQTTClient struct {
+		mtx      sync.Mutex
+		messages []mqttMessage
+		err      error
+	}
+
+	mqttMessage struct {
+		topic   string
+		payload []byte
+	}
+)
+
+func (c *fakeMQTTClient) Publish(topic string, payload []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.messages = append(c.messages, mqttMessage{topic: topic, payload: payload})
+
+	return c.err
+}
+
+// awaitMessages waits until n messages were published, since they are published in the background.
+func (c *fakeMQTTClient) awaitMessages(t *testing.T, n int) []mqttMessage {
+	t.Helper()
+
+	get := func() []mqttMessage {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		return append([]mqttMessage{}, c.messages...)
+	}
+	require.Eventually(t, func() bool { return len(get()) >= n }, time.Second, time.Millisecond)
+
+	return get()
+}
+
+func TestMQTTPublisher(t *testing.T) {
+	// Arrange
+	client := &fakeMQTTClient{}
+
+	var failing atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithMQTTPublisher(client, "gateway/health"),
+		health.WithCheck(toggledCheck("broker", &failing)),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+	ckr.Check(t.Context())
+	failing.Store(true)
+	ckr.Check(t.Context())
+
+	// Assert
+	messages := client.awaitMessages(t, 2)
+	require.Len(t, messages, 2)
+
+	var payload struct {
+		Status  health.AvailabilityStatus     `json:"status"`
+		CycleID string                        `json:"cycleId"`
+		Checks  map[string]health.CheckResult `json:"checks"`
+	}
+
+	assert.Equal(t, "gateway/health", messages[0].topic)
+	require.NoError(t, json.Unmarshal(messages[0].payload, &payload))
+	assert.Equal(t, health.StatusUp, payload.Status)
+	assert.NotEmpty(t, payload.CycleID)
+	assert.Equal(t, health.StatusUp, payload.Checks["broker"].Status)
+
+	assert.Equal(t, "gateway/health", messages[1].topic)
+	require.NoError(t, json.Unmarshal(messages[1].payload, &payload))
+	assert.Equal(t, health.StatusDown, payload.Status)
+	assert.Equal(t, health.StatusDown, payload.Checks["broker"].Status)
+	assert.Contains(t, string(messages[1].payload), `"downSince"`)
+	assert.Contains(t, string(messages[1].payload), `"error":`)
+}
+
+func TestMQTTPublisherFailureDoesNotAffectChecker(t *testing.T) {
+	// Arrange
+	client := &fakeMQTTClient{err: errors.New("not connected")}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithMQTTPublisher(client, "gateway/health"),
+		health.WithCheck(health.Check{
+			Name:  "broker",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Len(t, client.awaitMessages(t, 1), 1)
+}
+
+type blockingMQTTClient struct {
+	published chan struct{}
+	release   chan struct{}
+}
+
+func (c *blockingMQTTClient) Publish(string, []byte) error {
+	c.published <- struct{}{}
+	<-c.release
+
+	return nil
+}
+
+func TestMQTTPublisherDoesNotBlockChecker(t *testing.T) {
+	// Arrange
+	client := &blockingMQTTClient{published: make(chan struct{}, 2), release: make(chan struct{})}
+	defer close(client.release)
+
+	var failing atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithMQTTPublisher(client, "gateway/health"),
+		health.WithCheck(toggledCheck("broker", &failing)),
+	)
+	ckr.Check(t.Context())
+	<-client.published
+
+	// Act
+	failing.Store(true)
+	done := make(chan health.Result)
+	go func() { done <- ckr.Check(t.Context()) }()
+
+	// Assert
+	select {
+	case result := <-done:
+		assert.Equal(t, health.StatusDown, result.Status)
+	case <-time.After(time.Second):
+		t.Fatal("the Checker is blocked by the MQTT client")
+	}
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// OTelLogInterceptor creates an Interceptor that emits an OpenTelemetry log record for each check evaluation.
+// The severity of a record is derived from the status of the check: StatusUp is mapped to INFO, StatusDegraded
+// to WARN, StatusDown to ERROR and StatusUnknown to DEBUG. Each record holds the attributes "health.check.name",
+// "health.check.status" and "health.check.duration_ms" and, if the check failed, "health.check.error" and
+// "health.check.reason". The observability tags of the check (see WithObservabilityTags) are added as
+// attributes, too.
+func OTelLogInterceptor(logger otellog.Logger) Interceptor {
+	return func(next InterceptorFunc) InterceptorFunc {
+		return func(ctx context.Context, checkName string, state CheckState) CheckState {
+			start := time.Now()
+			result := next(ctx, checkName, state)
+			duration := time.Since(start)
+
+			severity, severityText := otelLogSeverity(result.Status)
+
+			var record otellog.Record
+			record.SetTimestamp(result.LastCheckedAt)
+			record.SetObservedTimestamp(time.Now())
+			record.SetSeverity(severity)
+			record.SetSeverityText(severityText)
+			record.SetBody(otellog.StringValue("health check evaluated"))
+			record.AddAttributes(
+				otellog.String("health.check.name", checkName),
+				otellog.String("health.check.status", string(result.Status)),
+				otellog.Int64("health.check.duration_ms", duration.Milliseconds()),
+			)
+
+			tags := ObservabilityTagsFromContext(ctx)
+			for _, key := range sortedTagKeys(tags) {
+				record.AddAttributes(otellog.String(key, tags[key]))
+			}
+
+			if result.Result != nil {
+				record.AddAttributes(
+					otellog.String("health.check.error", result.Result.Error()),
+					otellog.String("health.check.reason", result.Reason),
+				)
+			}
+
+			logger.Emit(ctx, record)
+
+			return result
+		}
+	}
+}
+
+func otelLogSeverity(status AvailabilityStatus) (otellog.Severity, string) {
+	switch status {
+	case StatusUp:
+		return otellog.SeverityInfo, "INFO"
+	case StatusDegraded:
+		return otellog.SeverityWarn, "WARN"
+	case StatusDown:
+		return otellog.SeverityError, "ERROR"
+	default:
+		return otellog.SeverityDebug, "DEBUG"
+	}
+}
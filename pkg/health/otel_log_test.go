@@ -0,0 +1,140 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type inMemoryLogExporter struct {
+	mtx     sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *inMemoryLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (e *inMemoryLogExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *inMemoryLogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func TestOTelLogInterceptor(t *testing.T) {
+	tests := []struct {
+		name             string
+		err              error
+		expectedSeverity otellog.Severity
+		expectedStatus   string
+		expectedReason   string
+	}{
+		{
+			name:             "Up",
+			expectedSeverity: otellog.SeverityInfo,
+			expectedStatus:   "up",
+		},
+		{
+			name:             "Degraded",
+			err:              fmt.Errorf("slow: %w", health.ErrDegraded),
+			expectedSeverity: otellog.SeverityWarn,
+			expectedStatus:   "degraded",
+			expectedReason:   health.ReasonDegraded,
+		},
+		{
+			name:             "Down",
+			err:              errors.New("unavailable"),
+			expectedSeverity: otellog.SeverityError,
+			expectedStatus:   "down",
+			expectedReason:   health.ReasonError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			exporter := &inMemoryLogExporter{}
+			provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithInterceptors(health.OTelLogInterceptor(provider.Logger("health"))),
+				health.WithCheck(health.Check{
+					Name:  "database",
+					Check: func(ctx context.Context) error { return tc.err },
+				}),
+			)
+
+			// Act
+			ckr.Check(t.Context())
+
+			// Assert
+			require.Len(t, exporter.records, 1)
+			record := exporter.records[0]
+			assert.Equal(t, tc.expectedSeverity, record.Severity())
+			assert.Equal(t, "health check evaluated", record.Body().AsString())
+
+			attributes := map[string]otellog.Value{}
+			record.WalkAttributes(func(kv otellog.KeyValue) bool {
+				attributes[kv.Key] = kv.Value
+				return true
+			})
+			assert.Equal(t, "database", attributes["health.check.name"].AsString())
+			assert.Equal(t, tc.expectedStatus, attributes["health.check.status"].AsString())
+			assert.Contains(t, attributes, "health.check.duration_ms")
+			assert.Equal(t, tc.expectedReason, attributes["health.check.reason"].AsString())
+
+			if tc.err != nil {
+				assert.Equal(t, tc.err.Error(), attributes["health.check.error"].AsString())
+			} else {
+				assert.NotContains(t, attributes, "health.check.error")
+			}
+		})
+	}
+}
+
+func TestOTelLogInterceptorWithObservabilityTags(t *testing.T) {
+	// Arrange
+	exporter := &inMemoryLogExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.OTelLogInterceptor(provider.Logger("health"))),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}, health.WithObservabilityTags(map[string]string{"team": "payments", "region": "eu"})),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Len(t, exporter.records, 1)
+
+	attributes := map[string]otellog.Value{}
+	exporter.records[0].WalkAttributes(func(kv otellog.KeyValue) bool {
+		attributes[kv.Key] = kv.Value
+		return true
+	})
+	assert.Equal(t, "payments", attributes["team"].AsString())
+	assert.Equal(t, "eu", attributes["region"].AsString())
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTelTraceInterceptor creates an Interceptor that records an OpenTelemetry span named "health.check <name>"
+// for each check evaluation. The span holds the attributes "health.check.name" and "health.check.status" and,
+// if the check failed, "health.check.reason". Failed evaluations are recorded as errors, and the status of
+// the span is set to error if the check is down. The context of the check function carries the span, so
+// that calls made by the check function (e.g., to a database) become child spans of it.
+func OTelTraceInterceptor(tracer trace.Tracer) Interceptor {
+	return func(next InterceptorFunc) InterceptorFunc {
+		return func(ctx context.Context, checkName string, state CheckState) CheckState {
+			attributes := []attribute.KeyValue{attribute.String("health.check.name", checkName)}
+
+			tags := ObservabilityTagsFromContext(ctx)
+			for _, key := range sortedTagKeys(tags) {
+				attributes = append(attributes, attribute.String(key, tags[key]))
+			}
+
+			ctx, span := tracer.Start(ctx, "health.check "+checkName,
+				trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attributes...))
+			defer span.End()
+
+			result := next(ctx, checkName, state)
+
+			span.SetAttributes(attribute.String("health.check.status", string(result.Status)))
+
+			if result.Result != nil {
+				span.SetAttributes(attribute.String("health.check.reason", result.Reason))
+				span.RecordError(result.Result)
+			}
+
+			if result.Status == StatusDown {
+				span.SetStatus(codes.Error, result.Reason)
+			}
+
+			return result
+		}
+	}
+}
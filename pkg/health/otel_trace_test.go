@@ -0,0 +1,84 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestOTelTraceInterceptor(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var checkSpanContext trace.SpanContext
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.OTelTraceInterceptor(provider.Tracer("health"))),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				checkSpanContext = trace.SpanContextFromContext(ctx)
+				return errors.New("unavailable")
+			},
+		}, health.WithObservabilityTags(map[string]string{"team": "payments", "tier": "1"})),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "health.check database", span.Name())
+	assert.Equal(t, span.SpanContext().SpanID(), checkSpanContext.SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, health.ReasonError, span.Status().Description)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("health.check.name", "database"),
+		attribute.String("team", "payments"),
+		attribute.String("tier", "1"),
+		attribute.String("health.check.status", "down"),
+		attribute.String("health.check.reason", health.ReasonError),
+	}, span.Attributes())
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "exception", span.Events()[0].Name)
+}
+
+func TestOTelTraceInterceptorWithSuccessfulCheck(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.OTelTraceInterceptor(provider.Tracer("health"))),
+		health.WithCheck(health.Check{Name: "cache", Check: func(context.Context) error { return nil }}),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Empty(t, spans[0].Events())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("health.check.name", "cache"),
+		attribute.String("health.check.status", "up"),
+	}, spans[0].Attributes())
+}
@@ -0,0 +1,53 @@
+package health
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// WithCheckPartition makes the Checker evaluate only the checks that are assigned to the partition with the
+// given index (starting at 0) out of total partitions (see CheckPartition). This allows to distribute expensive
+// checks across the instances of a large fleet, e.g., by using the ordinal of the instance as index, and to
+// combine their results by other means (e.g., by gossip, see State.MarshalBinary). Checks of other partitions
+// are treated like checks that are disabled by a FlagProvider: they are not evaluated, do not contribute to
+// the aggregated health status and are omitted from the check details. The SelfCheck is evaluated by all
+// partitions. BuildChecker fails with ErrInvalidPartition (and NewChecker panics) if total is not positive
+// or the index is not in [0, total).
+func WithCheckPartition(total, index int) Option {
+	return func(cfg *checkerConfig) {
+		cfg.partition = &checkPartition{total: total, index: index}
+	}
+}
+
+type checkPartition struct {
+	total int
+	index int
+}
+
+// CheckPartition returns the index of the partition (out of total partitions) to which the check with the
+// given name is assigned (see WithCheckPartition). The assignment is stable across instances and restarts,
+// since it is derived from the FNV-1a hash of the name. It returns 0 if total is not positive.
+func CheckPartition(name string, total int) int {
+	if total <= 0 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+
+	return int(h.Sum32() % uint32(total))
+}
+
+func (p *checkPartition) validate() error {
+	if p.total <= 0 || p.index < 0 || p.index >= p.total {
+		return fmt.Errorf("%w: index %d of %d partitions", ErrInvalidPartition, p.index, p.total)
+	}
+
+	return nil
+}
+
+// isAssignedToPartition returns true, if the check is evaluated by the partition of the Checker (see WithCheckPartition).
+func (ck *defaultChecker) isAssignedToPartition(check *Check) bool {
+	p := ck.cfg.partition
+	return p == nil || check.Name == SelfCheckName || CheckPartition(check.Name, p.total) == p.index
+}
@@ -0,0 +1,117 @@
+package health_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestCheckPartitionAssignsEachCheckToExactlyOneIndex(t *testing.T) {
+	// Arrange
+	const total = 3
+
+	calls := map[string]*atomic.Int32{}
+	checks := make([]health.Check, 0, 20)
+	for i := range 20 {
+		name := fmt.Sprintf("check-%d", i)
+		counter := &atomic.Int32{}
+		calls[name] = counter
+		checks = append(checks, health.Check{
+			Name: name,
+			Check: func(ctx context.Context) error {
+				counter.Add(1)
+				return nil
+			},
+		})
+	}
+
+	// Act
+	assignments := map[string][]int{}
+	for index := range total {
+		ckr, err := health.BuildChecker(
+			health.WithDisabledAutostart(),
+			health.WithCheckPartition(total, index),
+			health.WithChecks(checks...),
+		)
+		require.NoError(t, err)
+
+		for name := range ckr.Check(t.Context()).Details {
+			assignments[name] = append(assignments[name], index)
+		}
+	}
+
+	// Assert
+	require.Len(t, assignments, len(checks))
+	for name, indexes := range assignments {
+		assert.Equal(t, []int{health.CheckPartition(name, total)}, indexes, name)
+		assert.Equal(t, int32(1), calls[name].Load(), name)
+	}
+}
+
+func TestCheckPartitionExcludesOtherChecksFromAggregation(t *testing.T) {
+	// Arrange
+	down := statusCheck("down", health.StatusDown)
+	up := statusCheck("up", health.StatusUp)
+	index := health.CheckPartition(up.Name, 2)
+	require.NotEqual(t, index, health.CheckPartition(down.Name, 2), "the checks must be in different partitions")
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusCounts(),
+		health.WithCheckPartition(2, index),
+		health.WithChecks(up, down),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Equal(t, 1, res.Counts.Total)
+	assert.NotContains(t, res.Details, "down")
+}
+
+func TestCheckPartitionEvaluatesSelfCheck(t *testing.T) {
+	for index := range 2 {
+		// Arrange
+		ckr := health.NewChecker(
+			health.WithDisabledAutostart(),
+			health.WithSelfCheck(),
+			health.WithCheckPartition(2, index),
+		)
+
+		// Act
+		res := ckr.Check(t.Context())
+
+		// Assert
+		assert.Contains(t, res.Details, health.SelfCheckName)
+	}
+}
+
+func TestCheckPartitionValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		index int
+	}{
+		{name: "NoPartitions", total: 0, index: 0},
+		{name: "NegativeIndex", total: 2, index: -1},
+		{name: "IndexOutOfRange", total: 2, index: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := health.BuildChecker(health.WithDisabledAutostart(), health.WithCheckPartition(tt.total, tt.index))
+
+			// Assert
+			assert.ErrorIs(t, err, health.ErrInvalidPartition)
+		})
+	}
+}
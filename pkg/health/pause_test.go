@@ -0,0 +1,95 @@
+package health_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestPauseAndResumeCheck(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithPeriodicCheck(10*time.Millisecond, 0, health.Check{
+			Name: "dependency",
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				return nil
+			},
+		}),
+	)
+	ckr.Start()
+	defer ckr.Stop()
+
+	require.Eventually(t, func() bool { return calls.Load() > 0 }, time.Second, 5*time.Millisecond)
+
+	// Act
+	require.NoError(t, ckr.(health.CheckPauser).PauseCheck("dependency"))
+	time.Sleep(30 * time.Millisecond) // let an evaluation that is already running complete
+	pausedCalls := calls.Load()
+	time.Sleep(100 * time.Millisecond)
+	callsWhilePaused := calls.Load() - pausedCalls
+	pausedRes := ckr.Check(t.Context())
+
+	require.NoError(t, ckr.(health.CheckPauser).ResumeCheck("dependency"))
+
+	// Assert
+	assert.Equal(t, int32(0), callsWhilePaused)
+	assert.True(t, pausedRes.Details["dependency"].Paused)
+	assert.Equal(t, health.StatusUp, pausedRes.Status)
+	assert.Equal(t, 1, ckr.GetRunningPeriodicCheckCount())
+
+	require.Eventually(t, func() bool { return calls.Load() > pausedCalls+2 }, time.Second, 5*time.Millisecond)
+	assert.False(t, ckr.Check(t.Context()).Details["dependency"].Paused)
+}
+
+func TestResumeEvaluatesCheckRightAway(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithPeriodicCheck(time.Hour, 0, health.Check{
+			Name: "dependency",
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				return nil
+			},
+		}),
+	)
+	require.NoError(t, ckr.(health.CheckPauser).PauseCheck("dependency"))
+	ckr.Start()
+	defer ckr.Stop()
+	time.Sleep(20 * time.Millisecond)
+
+	callsBeforeResume := calls.Load()
+
+	// Act
+	require.NoError(t, ckr.(health.CheckPauser).ResumeCheck("dependency"))
+
+	// Assert
+	assert.Equal(t, int32(0), callsBeforeResume)
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestPauseCheckErrors(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{Name: "sync", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	notFoundErr := ckr.(health.CheckPauser).PauseCheck("unknown")
+	notPeriodicErr := ckr.(health.CheckPauser).ResumeCheck("sync")
+
+	// Assert
+	require.ErrorIs(t, notFoundErr, health.ErrCheckNotFound)
+	require.ErrorIs(t, notPeriodicErr, health.ErrCheckNotPeriodic)
+}
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"context"
+)
+
+type (
+	// CheckStage is a factory function that wraps the evaluation of a check in a pipeline (see WithPipeline).
+	// A CheckStage is expected to forward the call to the next CheckStageFunc (passed in parameter 'next'),
+	// unless it short-circuits the evaluation, e.g., because a circuit breaker is open or a cached result
+	// is still valid. If no stage calls 'next', the check function is never executed.
+	CheckStage func(next CheckStageFunc) CheckStageFunc
+
+	// CheckStageFunc evaluates a check (or the remainder of its pipeline). It returns the error of the
+	// check (see Check.Check).
+	CheckStageFunc func(ctx context.Context) error
+)
+
+// WithPipeline composes the given stages around the check function (or the value or sub-check function) of a
+// check, e.g., to apply a retry, a circuit breaker, a cache and a timeout in an order of choice. The stages are
+// executed in the order as they are passed to this function, i.e., the first stage is the outermost one and
+// the last stage wraps the check function directly. The pipeline is executed within each attempt of an
+// evaluation, i.e., within the panic recovery and the timeouts of the check and after its interceptors
+// (see Check.Interceptors and WithRetry).
+func WithPipeline(stages ...CheckStage) CheckOption {
+	return func(check *Check) {
+		check.pipeline = append(check.pipeline, stages...)
+	}
+}
+
+func withPipeline(stages []CheckStage, target CheckStageFunc) CheckStageFunc {
+	chain := target
+	for idx := len(stages) - 1; idx >= 0; idx-- {
+		chain = stages[idx](chain)
+	}
+
+	return chain
+}
@@ -0,0 +1,131 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// recordingStage returns a stage that records its invocation before and after the next stage.
+func recordingStage(name string, record func(string)) health.CheckStage {
+	return func(next health.CheckStageFunc) health.CheckStageFunc {
+		return func(ctx context.Context) error {
+			record(name + ":before")
+			err := next(ctx)
+			record(name + ":after")
+
+			return err
+		}
+	}
+}
+
+func TestPipelineExecutesStagesInDeclaredOrder(t *testing.T) {
+	// Arrange
+	var (
+		mtx   sync.Mutex
+		calls []string
+	)
+
+	record := func(call string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		calls = append(calls, call)
+	}
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(
+			health.Check{
+				Name: "db",
+				Check: func(ctx context.Context) error {
+					record("check")
+					return nil
+				},
+			},
+			health.WithPipeline(recordingStage("retry", record), recordingStage("breaker", record)),
+			health.WithPipeline(recordingStage("timeout", record)),
+		),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	assert.Equal(t, []string{
+		"retry:before", "breaker:before", "timeout:before",
+		"check",
+		"timeout:after", "breaker:after", "retry:after",
+	}, calls)
+}
+
+func TestPipelineStageCanShortCircuit(t *testing.T) {
+	// Arrange
+	errOpen := errors.New("circuit open")
+	called := false
+	breaker := func(next health.CheckStageFunc) health.CheckStageFunc {
+		return func(ctx context.Context) error {
+			return errOpen
+		}
+	}
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(
+			health.Check{
+				Name: "db",
+				Check: func(ctx context.Context) error {
+					called = true
+					return nil
+				},
+			},
+			health.WithPipeline(breaker),
+		),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	state, _ := ckr.(health.StateReader).LastCheckState("db")
+	assert.False(t, called, "the check function must not be executed")
+	assert.Equal(t, health.StatusDown, state.Status)
+	assert.ErrorIs(t, state.Result, errOpen)
+}
+
+func TestPipelineRetainsSubResults(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(
+			health.Check{
+				Name: "db",
+				SubChecks: func(ctx context.Context) []health.SubResult {
+					return []health.SubResult{{Name: "read"}, {Name: "write", Error: errors.New("read-only")}}
+				},
+			},
+			health.WithPipeline(func(next health.CheckStageFunc) health.CheckStageFunc { return next }),
+		),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	require.Contains(t, result.Details, "db")
+	details := result.Details["db"]
+	assert.Equal(t, health.StatusDown, details.Status)
+	assert.Equal(t, health.StatusUp, details.SubResults["read"].Status)
+	assert.Equal(t, health.StatusDown, details.SubResults["write"].Status)
+}
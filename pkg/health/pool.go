@@ -0,0 +1,32 @@
+package health
+
+import "sync"
+
+// evaluationScratch holds the scratch buffers of a synchronous evaluation cycle (see runSynchronousChecks).
+// The buffers are reused across cycles to reduce allocations for checkers with many checks. Values that are
+// retained beyond a cycle (such as check states or results) must never be taken from a scratch object.
+type evaluationScratch struct {
+	checks  []*Check
+	results []checkResult
+}
+
+var evaluationScratchPool = sync.Pool{
+	New: func() any {
+		return &evaluationScratch{}
+	},
+}
+
+func getEvaluationScratch() *evaluationScratch {
+	scratch, _ := evaluationScratchPool.Get().(*evaluationScratch)
+	return scratch
+}
+
+func putEvaluationScratch(scratch *evaluationScratch) {
+	// The buffers are cleared, so that pooled objects do not keep checks or errors alive.
+	clear(scratch.checks)
+	clear(scratch.results)
+	scratch.checks = scratch.checks[:0]
+	scratch.results = scratch.results[:0]
+
+	evaluationScratchPool.Put(scratch)
+}
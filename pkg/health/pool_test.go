@@ -0,0 +1,120 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func newBenchmarkChecker(numChecks int, failing bool, options ...health.Option) health.Checker {
+	var checkErr error
+	if failing {
+		checkErr = errors.New("unavailable")
+	}
+
+	for i := range numChecks {
+		options = append(options, health.WithCheck(health.Check{
+			Name:  fmt.Sprintf("check-%d", i),
+			Check: func(ctx context.Context) error { return checkErr },
+		}))
+	}
+
+	return health.NewChecker(append(options, health.WithDisabledAutostart())...)
+}
+
+func TestEvaluationScratchReuseDoesNotLeakBetweenCheckers(t *testing.T) {
+	// Arrange
+	upChecker := newBenchmarkChecker(20, false, health.WithDisabledCache())
+	downChecker := newBenchmarkChecker(5, true, health.WithDisabledCache())
+
+	// Act
+	var wg sync.WaitGroup
+	results := make(chan health.Result, 200)
+	for range 50 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			results <- upChecker.Check(t.Context())
+		}()
+		go func() {
+			defer wg.Done()
+			results <- downChecker.Check(t.Context())
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// Assert
+	for res := range results {
+		switch res.Status {
+		case health.StatusUp:
+			require.Len(t, res.Details, 20)
+		case health.StatusDown:
+			require.Len(t, res.Details, 5)
+		default:
+			require.Failf(t, "unexpected status", "status: %s", res.Status)
+		}
+
+		for name, detail := range res.Details {
+			assert.Equal(t, res.Status, detail.Status, name)
+		}
+	}
+}
+
+func TestEvaluationScratchReuseKeepsStatesIntact(t *testing.T) {
+	// Arrange
+	ckr := newBenchmarkChecker(10, false, health.WithDisabledCache())
+	first := ckr.Check(t.Context())
+
+	// Act
+	for range 100 {
+		ckr.Check(t.Context())
+	}
+
+	// Assert
+	for name, detail := range first.Details {
+		state, ok := ckr.(health.StateReader).LastCheckState(name)
+		require.True(t, ok)
+		assert.True(t, state.LastCheckedAt.After(detail.Timestamp) || state.LastCheckedAt.Equal(detail.Timestamp))
+		assert.Equal(t, health.StatusUp, state.Status)
+		assert.Equal(t, health.StatusUp, detail.Status)
+	}
+}
+
+func BenchmarkCheck(b *testing.B) {
+	for _, numChecks := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("Checks%d", numChecks), func(b *testing.B) {
+			ckr := newBenchmarkChecker(numChecks, false, health.WithDisabledCache())
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for range b.N {
+				ckr.Check(ctx)
+			}
+		})
+	}
+}
+
+func BenchmarkCheckCached(b *testing.B) {
+	// With a cache, no checks are executed and only the cached states are aggregated.
+	ckr := newBenchmarkChecker(100, false, health.WithCacheDuration(time.Hour))
+	ctx := context.Background()
+	ckr.Check(ctx)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		ckr.Check(ctx)
+	}
+}
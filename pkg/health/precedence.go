@@ -0,0 +1,51 @@
+package health
+
+import (
+	"fmt"
+)
+
+// newStatusPrecedenceAggregator creates an aggregator that reports the status with the highest precedence
+// among the statuses of all checks (see WithStatusPrecedence). It validates that the order ranks all statuses.
+func newStatusPrecedenceAggregator(order []AvailabilityStatus) (func(map[string]CheckState) AvailabilityStatus, error) {
+	statuses := []AvailabilityStatus{StatusDown, StatusDegraded, StatusUnknown, StatusUp}
+
+	rank := make(map[AvailabilityStatus]int, len(order))
+	for i, status := range order {
+		rank[status] = len(order) - i
+	}
+
+	if len(order) != len(statuses) || len(rank) != len(statuses) {
+		return nil, fmt.Errorf("%w: %v must contain each status exactly once", ErrInvalidStatusPrecedence, order)
+	}
+
+	for _, status := range statuses {
+		if _, ok := rank[status]; !ok {
+			return nil, fmt.Errorf("%w: %v does not contain %q", ErrInvalidStatusPrecedence, order, status)
+		}
+	}
+
+	return func(states map[string]CheckState) AvailabilityStatus {
+		if len(states) == 0 {
+			return StatusUp
+		}
+
+		var status AvailabilityStatus
+		for _, state := range states {
+			if status == "" || rank[state.Status] > rank[status] {
+				status = state.Status
+			}
+		}
+
+		return status
+	}, nil
+}
+
+// statusNames returns the given statuses as strings (e.g., for the configuration snapshot).
+func statusNames(statuses []AvailabilityStatus) []string {
+	names := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		names = append(names, string(status))
+	}
+
+	return names
+}
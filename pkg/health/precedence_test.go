@@ -0,0 +1,104 @@
+package health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestStatusPrecedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []health.Option
+		expected health.AvailabilityStatus
+	}{
+		{
+			name:     "DefaultPrefersDegradedOverUnknown",
+			expected: health.StatusDegraded,
+		},
+		{
+			name: "CustomPrefersUnknownOverDegraded",
+			options: []health.Option{health.WithStatusPrecedence([]health.AvailabilityStatus{
+				health.StatusDown, health.StatusUnknown, health.StatusDegraded, health.StatusUp,
+			})},
+			expected: health.StatusUnknown,
+		},
+		{
+			name: "CustomAggregatorTakesPrecedence",
+			options: []health.Option{
+				health.WithStatusPrecedence([]health.AvailabilityStatus{
+					health.StatusDown, health.StatusUnknown, health.StatusDegraded, health.StatusUp,
+				}),
+				health.WithAggregator(func(map[string]health.CheckState) health.AvailabilityStatus { return health.StatusUp }),
+			},
+			expected: health.StatusUp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			options := append([]health.Option{
+				health.WithDisabledAutostart(),
+				health.WithCheck(statusCheck("degraded", health.StatusDegraded)),
+				// The periodic check is never evaluated, because the checker is not started, so it stays unknown.
+				health.WithPeriodicCheck(time.Hour, 0, statusCheck("unknown", health.StatusUp)),
+			}, tt.options...)
+			ckr, err := health.BuildChecker(options...)
+			require.NoError(t, err)
+
+			// Act
+			result := ckr.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, health.StatusDegraded, result.Details["degraded"].Status)
+			assert.Equal(t, health.StatusUnknown, result.Details["unknown"].Status)
+			assert.Equal(t, tt.expected, result.Status)
+		})
+	}
+}
+
+func TestStatusPrecedenceDownStillWins(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusPrecedence([]health.AvailabilityStatus{
+			health.StatusUnknown, health.StatusDown, health.StatusDegraded, health.StatusUp,
+		}),
+		health.WithCheck(statusCheck("up", health.StatusUp)),
+		health.WithCheck(statusCheck("down", health.StatusDown)),
+		health.WithCheck(statusCheck("degraded", health.StatusDegraded)),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, result.Status)
+}
+
+func TestStatusPrecedenceValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []health.AvailabilityStatus
+	}{
+		{name: "Empty", order: nil},
+		{name: "MissingStatus", order: []health.AvailabilityStatus{health.StatusDown, health.StatusDegraded, health.StatusUp}},
+		{name: "DuplicateStatus", order: []health.AvailabilityStatus{health.StatusDown, health.StatusDown, health.StatusDegraded, health.StatusUp}},
+		{name: "InvalidStatus", order: []health.AvailabilityStatus{health.StatusDown, health.StatusDegraded, "broken", health.StatusUp}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := health.BuildChecker(health.WithDisabledAutostart(), health.WithStatusPrecedence(tt.order))
+
+			// Assert
+			assert.ErrorIs(t, err, health.ErrInvalidStatusPrecedence)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type (
+	// ProbeHints are machine-readable recommendations for orchestrators (e.g., Kubernetes) on how to
+	// probe the service (see WithProbeHints).
+	ProbeHints struct {
+		// Interval is the recommended time between two probes.
+		Interval time.Duration
+		// Timeout is the recommended timeout of a probe. If it is not set, the timeout of the
+		// Checker is used (see WithTimeout).
+		Timeout time.Duration
+		// LivenessPath is the HTTP path of the endpoint that should be used for liveness probes.
+		LivenessPath string
+		// ReadinessPath is the HTTP path of the endpoint that should be used for readiness probes.
+		ReadinessPath string
+	}
+
+	jsonProbeHints struct {
+		Interval      string `json:"interval,omitempty"`
+		Timeout       string `json:"timeout,omitempty"`
+		LivenessPath  string `json:"livenessPath,omitempty"`
+		ReadinessPath string `json:"readinessPath,omitempty"`
+	}
+)
+
+// WithProbeHints adds the given probe hints to each Result (see Result.ProbeHints), so that orchestrators
+// can derive their probe configuration from the service itself. Example:
+// { "status":"up", "probeHints":{ "interval":"10s", "timeout":"2s", "livenessPath":"/livez", "readinessPath":"/readyz" } }.
+// By default, no probe hints are included.
+func WithProbeHints(hints ProbeHints) Option {
+	return func(cfg *checkerConfig) {
+		cfg.probeHints = &hints
+	}
+}
+
+// effectiveProbeHints returns the configured probe hints with the default timeout applied, or nil if there are none.
+func (cfg *checkerConfig) effectiveProbeHints() *ProbeHints {
+	if cfg.probeHints == nil {
+		return nil
+	}
+
+	hints := *cfg.probeHints
+	if hints.Timeout == 0 {
+		hints.Timeout = cfg.timeout
+	}
+
+	return &hints
+}
+
+// MarshalJSON provides a custom marshaller for the ProbeHints type that represents durations as strings.
+func (h ProbeHints) MarshalJSON() ([]byte, error) {
+	hints := jsonProbeHints{
+		LivenessPath:  h.LivenessPath,
+		ReadinessPath: h.ReadinessPath,
+	}
+
+	if h.Interval > 0 {
+		hints.Interval = h.Interval.String()
+	}
+
+	if h.Timeout > 0 {
+		hints.Timeout = h.Timeout.String()
+	}
+
+	return json.Marshal(hints)
+}
+
+// UnmarshalJSON provides a custom unmarshaller for the ProbeHints type.
+func (h *ProbeHints) UnmarshalJSON(data []byte) error {
+	var hints jsonProbeHints
+	if err := json.Unmarshal(data, &hints); err != nil {
+		return err
+	}
+
+	*h = ProbeHints{LivenessPath: hints.LivenessPath, ReadinessPath: hints.ReadinessPath}
+
+	for _, d := range []struct {
+		value  string
+		target *time.Duration
+	}{{hints.Interval, &h.Interval}, {hints.Timeout, &h.Timeout}} {
+		if d.value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+
+		*d.target = duration
+	}
+
+	return nil
+}
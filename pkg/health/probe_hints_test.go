@@ -0,0 +1,101 @@
+package health_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestProbeHintsInResponse(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithProbeHints(health.ProbeHints{
+			Interval:      10 * time.Second,
+			Timeout:       2 * time.Second,
+			LivenessPath:  "/livez",
+			ReadinessPath: "/readyz",
+		}),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+	)
+	rec := httptest.NewRecorder()
+
+	// Act
+	health.NewHandler(ckr).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	// Assert
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]any{
+		"interval":      "10s",
+		"timeout":       "2s",
+		"livenessPath":  "/livez",
+		"readinessPath": "/readyz",
+	}, body["probeHints"])
+
+	var result health.Result
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, &health.ProbeHints{
+		Interval:      10 * time.Second,
+		Timeout:       2 * time.Second,
+		LivenessPath:  "/livez",
+		ReadinessPath: "/readyz",
+	}, result.ProbeHints)
+}
+
+func TestProbeHintsDefaultToCheckerTimeout(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithTimeout(3*time.Second),
+		health.WithProbeHints(health.ProbeHints{Interval: 15 * time.Second, ReadinessPath: "/readyz"}),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	require.NotNil(t, res.ProbeHints)
+	assert.Equal(t, 15*time.Second, res.ProbeHints.Interval)
+	assert.Equal(t, 3*time.Second, res.ProbeHints.Timeout)
+	assert.Equal(t, "/readyz", res.ProbeHints.ReadinessPath)
+	assert.Empty(t, res.ProbeHints.LivenessPath)
+}
+
+func TestProbeHintsAreOmittedByDefault(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+
+	// Act
+	res := ckr.Check(t.Context())
+	body, err := json.Marshal(res)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, res.ProbeHints)
+	assert.NotContains(t, string(body), "probeHints")
+}
+
+func TestProbeHintsInFlatResult(t *testing.T) {
+	// Arrange
+	result := health.Result{
+		Status:     health.StatusUp,
+		ProbeHints: &health.ProbeHints{Interval: 10 * time.Second, Timeout: time.Second, LivenessPath: "/livez"},
+	}
+
+	// Act
+	flat := health.FlattenResult(&result)
+
+	// Assert
+	assert.Equal(t, "10s", flat["health.probeHints.interval"])
+	assert.Equal(t, "1s", flat["health.probeHints.timeout"])
+	assert.Equal(t, "/livez", flat["health.probeHints.livenessPath"])
+	assert.NotContains(t, flat, "health.probeHints.readinessPath")
+}
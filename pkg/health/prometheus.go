@@ -0,0 +1,88 @@
+package health
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	prometheusStatusDesc = prometheus.NewDesc("health_status",
+		"Aggregated health status (1 = up, 0.5 = degraded, 0 = down, -1 = unknown).", nil, nil)
+	prometheusUpSinceDesc = prometheus.NewDesc("health_up_since_timestamp_seconds",
+		"Time of when the aggregated health status became up (0 while it is not up).", nil, nil)
+	prometheusLastChangeDesc = prometheus.NewDesc("health_last_change_timestamp_seconds",
+		"Time of when the aggregated health status last changed.", nil, nil)
+	prometheusCheckStatusDesc = prometheus.NewDesc("health_check_status",
+		"Health status of a check (1 = up, 0.5 = degraded, 0 = down, -1 = unknown).", []string{"check"}, nil)
+	prometheusCheckLastChangeDesc = prometheus.NewDesc("health_check_last_change_timestamp_seconds",
+		"Time of when the health status of a check last changed.", []string{"check"}, nil)
+)
+
+// prometheusCollector implements prometheus.Collector for a Checker (see NewPrometheusCollector).
+type prometheusCollector struct {
+	reader StateReader
+}
+
+// NewPrometheusCollector creates a prometheus.Collector that exposes the State of the Checker (see StateReader.State)
+// with the following gauges:
+//   - "health_status" and "health_check_status{check}" hold the aggregated status and the status of each check,
+//     where up is mapped to 1, degraded to 0.5, down to 0 and unknown to -1,
+//   - "health_up_since_timestamp_seconds" holds the time of when the aggregated status became up (0 while it is
+//     not up), which allows to show the duration of incidents,
+//   - "health_last_change_timestamp_seconds" and "health_check_last_change_timestamp_seconds{check}" hold the time
+//     of the last status change of the aggregated status and of each check, which allows to show flap frequencies.
+//
+// The timestamps are omitted as long as the status did not change yet. The State is read from the snapshot of the
+// Checker on each scrape, so the collector never triggers check evaluations. If the Checker does not implement
+// StateReader, no metrics are collected.
+func NewPrometheusCollector(checker Checker) prometheus.Collector {
+	reader, _ := checker.(StateReader)
+	return &prometheusCollector{reader: reader}
+}
+
+// Describe implements prometheus.Collector.Describe.
+func (c *prometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- prometheusStatusDesc
+	ch <- prometheusUpSinceDesc
+	ch <- prometheusLastChangeDesc
+	ch <- prometheusCheckStatusDesc
+	ch <- prometheusCheckLastChangeDesc
+}
+
+// Collect implements prometheus.Collector.Collect.
+func (c *prometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.reader == nil {
+		return
+	}
+
+	state := c.reader.State()
+
+	ch <- prometheus.MustNewConstMetric(prometheusStatusDesc, prometheus.GaugeValue, statusValue(state.Status))
+
+	upSince := 0.0
+	if !state.UpSince.IsZero() {
+		upSince = unixSeconds(state.UpSince)
+	}
+	ch <- prometheus.MustNewConstMetric(prometheusUpSinceDesc, prometheus.GaugeValue, upSince)
+
+	if !state.LastStatusChangeAt.IsZero() {
+		ch <- prometheus.MustNewConstMetric(prometheusLastChangeDesc, prometheus.GaugeValue,
+			unixSeconds(state.LastStatusChangeAt))
+	}
+
+	for name, checkState := range state.CheckState {
+		ch <- prometheus.MustNewConstMetric(prometheusCheckStatusDesc, prometheus.GaugeValue,
+			statusValue(checkState.Status), name)
+
+		if !checkState.LastStatusChangeAt.IsZero() {
+			ch <- prometheus.MustNewConstMetric(prometheusCheckLastChangeDesc, prometheus.GaugeValue,
+				unixSeconds(checkState.LastStatusChangeAt), name)
+		}
+	}
+}
+
+// unixSeconds returns the time as Unix timestamp in seconds (with millisecond precision).
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}
@@ -0,0 +1,86 @@
+package health_test
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestPrometheusCollector(t *testing.T) {
+	// Arrange
+	clock := newFakeClock(time.Unix(1000, 0))
+
+	var failing atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithCheck(toggledCheck("database", &failing)),
+	)
+	collector := health.NewPrometheusCollector(ckr)
+
+	// Act & Assert: before the first evaluation, no status change happened yet.
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP health_check_status Health status of a check (1 = up, 0.5 = degraded, 0 = down, -1 = unknown).
+# TYPE health_check_status gauge
+health_check_status{check="database"} -1
+# HELP health_status Aggregated health status (1 = up, 0.5 = degraded, 0 = down, -1 = unknown).
+# TYPE health_status gauge
+health_status -1
+# HELP health_up_since_timestamp_seconds Time of when the aggregated health status became up (0 while it is not up).
+# TYPE health_up_since_timestamp_seconds gauge
+health_up_since_timestamp_seconds 0
+`)))
+
+	// Act & Assert: the transition to up is recorded.
+	ckr.Check(t.Context())
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP health_check_last_change_timestamp_seconds Time of when the health status of a check last changed.
+# TYPE health_check_last_change_timestamp_seconds gauge
+health_check_last_change_timestamp_seconds{check="database"} 1000
+# HELP health_last_change_timestamp_seconds Time of when the aggregated health status last changed.
+# TYPE health_last_change_timestamp_seconds gauge
+health_last_change_timestamp_seconds 1000
+# HELP health_up_since_timestamp_seconds Time of when the aggregated health status became up (0 while it is not up).
+# TYPE health_up_since_timestamp_seconds gauge
+health_up_since_timestamp_seconds 1000
+`), "health_check_last_change_timestamp_seconds", "health_last_change_timestamp_seconds",
+		"health_up_since_timestamp_seconds"))
+
+	// Act & Assert: evaluations without a transition do not change the timestamps.
+	clock.Advance(30 * time.Second)
+	ckr.Check(t.Context())
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP health_last_change_timestamp_seconds Time of when the aggregated health status last changed.
+# TYPE health_last_change_timestamp_seconds gauge
+health_last_change_timestamp_seconds 1000
+`), "health_last_change_timestamp_seconds"))
+
+	// Act & Assert: the transition to down is recorded.
+	clock.Advance(30 * time.Second)
+	failing.Store(true)
+	ckr.Check(t.Context())
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP health_check_last_change_timestamp_seconds Time of when the health status of a check last changed.
+# TYPE health_check_last_change_timestamp_seconds gauge
+health_check_last_change_timestamp_seconds{check="database"} 1060
+# HELP health_check_status Health status of a check (1 = up, 0.5 = degraded, 0 = down, -1 = unknown).
+# TYPE health_check_status gauge
+health_check_status{check="database"} 0
+# HELP health_last_change_timestamp_seconds Time of when the aggregated health status last changed.
+# TYPE health_last_change_timestamp_seconds gauge
+health_last_change_timestamp_seconds 1060
+# HELP health_status Aggregated health status (1 = up, 0.5 = degraded, 0 = down, -1 = unknown).
+# TYPE health_status gauge
+health_status 0
+# HELP health_up_since_timestamp_seconds Time of when the aggregated health status became up (0 while it is not up).
+# TYPE health_up_since_timestamp_seconds gauge
+health_up_since_timestamp_seconds 0
+`)))
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"fmt"
+)
+
+// WithPanicQuarantine quarantines periodic checks (see WithPeriodicCheck) whose check function panicked in the
+// given number of consecutive evaluations, so that a perpetually panicking check does not waste resources.
+// A quarantined check is no longer scheduled and reports StatusDown with ReasonQuarantined and an error that
+// wraps ErrCheckQuarantined, until the Checker is restarted. Retries of an evaluation (see WithRetry) count as
+// a single evaluation. Synchronous checks are not affected. By default, checks are never quarantined.
+func WithPanicQuarantine(consecutivePanics uint) Option {
+	return func(cfg *checkerConfig) {
+		cfg.panicQuarantine = consecutivePanics
+	}
+}
+
+// applyPanicQuarantine counts the consecutive panics of a periodic check and quarantines the check
+// once the limit is reached (see WithPanicQuarantine).
+func (ck *defaultChecker) applyPanicQuarantine(check *Check, state CheckState) CheckState {
+	limit := ck.cfg.panicQuarantine
+	if limit == 0 {
+		return state
+	}
+
+	pause := ck.pauseStates[check.Name]
+	if ReasonOf(state.Result) != ReasonPanic {
+		pause.contiguousPanics = 0
+		return state
+	}
+
+	pause.contiguousPanics++
+	if pause.contiguousPanics < limit {
+		return state
+	}
+
+	pause.quarantined.Store(true)
+
+	state.Result = ErrorWithReason(ReasonQuarantined,
+		fmt.Errorf("%w after %d consecutive panics: %w", ErrCheckQuarantined, pause.contiguousPanics, state.Result))
+	state.Reason = ReasonQuarantined
+	state.Status = StatusDown
+
+	return state
+}
@@ -0,0 +1,72 @@
+package health_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestPanicQuarantine(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithPanicQuarantine(3),
+		health.WithPeriodicCheck(5*time.Millisecond, 0, health.Check{
+			Name: "panicking",
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				panic("boom")
+			},
+		}),
+	)
+	defer ckr.Stop()
+
+	// Act
+	require.Eventually(t, func() bool {
+		state, _ := ckr.(health.StateReader).LastCheckState("panicking")
+		return state.Reason == health.ReasonQuarantined
+	}, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return ckr.GetRunningPeriodicCheckCount() == 0 }, time.Second, time.Millisecond)
+	callsAtQuarantine := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	// Assert
+	state, _ := ckr.(health.StateReader).LastCheckState("panicking")
+	assert.Equal(t, health.StatusDown, state.Status)
+	assert.ErrorIs(t, state.Result, health.ErrCheckQuarantined)
+	assert.Contains(t, state.Result.Error(), "after 3 consecutive panics: boom")
+	assert.Equal(t, int32(3), callsAtQuarantine)
+	assert.Equal(t, callsAtQuarantine, calls.Load(), "a quarantined check must not be scheduled")
+}
+
+func TestPanicQuarantineRequiresConsecutivePanics(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithPanicQuarantine(2),
+		health.WithPeriodicCheck(5*time.Millisecond, 0, health.Check{
+			Name: "flaky",
+			Check: func(ctx context.Context) error {
+				if calls.Add(1)%2 == 1 {
+					panic("boom")
+				}
+				return nil
+			},
+		}),
+	)
+	defer ckr.Stop()
+
+	// Act
+	require.Eventually(t, func() bool { return calls.Load() >= 6 }, time.Second, time.Millisecond)
+
+	// Assert
+	state, _ := ckr.(health.StateReader).LastCheckState("flaky")
+	assert.NotEqual(t, health.ReasonQuarantined, state.Reason)
+	assert.Equal(t, 1, ckr.GetRunningPeriodicCheckCount())
+}
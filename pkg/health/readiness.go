@@ -0,0 +1,235 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	// readinessExpr is a node of a parsed readiness expression (see WithReadinessExpression).
+	readinessExpr interface {
+		eval(states map[string]CheckState) AvailabilityStatus
+	}
+
+	checkNameExpr string
+	andExpr       []readinessExpr
+	orExpr        []readinessExpr
+
+	readinessParser struct {
+		tokens []string
+		pos    int
+		names  []string
+	}
+)
+
+func (e checkNameExpr) eval(states map[string]CheckState) AvailabilityStatus {
+	state, ok := states[string(e)]
+	if !ok {
+		// The check does not participate in the aggregation (e.g., because it is disabled by a FlagProvider).
+		return StatusUnknown
+	}
+
+	return state.Status
+}
+
+func (e andExpr) eval(states map[string]CheckState) AvailabilityStatus {
+	status := StatusUp
+
+	for _, operand := range e {
+		if s := operand.eval(states); s.criticality() > status.criticality() {
+			status = s
+		}
+	}
+
+	return status
+}
+
+func (e orExpr) eval(states map[string]CheckState) AvailabilityStatus {
+	status := StatusDown
+
+	for _, operand := range e {
+		if s := operand.eval(states); s.criticality() < status.criticality() {
+			status = s
+		}
+	}
+
+	return status
+}
+
+// WithReadinessExpression computes the status of the readiness handlers (see NewReadinessHandler) from a boolean
+// formula over the statuses of checks, e.g., "database AND (cacheA OR cacheB)". The formula consists of check names, the operators AND and OR
+// (case-insensitive; AND binds stronger than OR) and parentheses. Check names that contain whitespace or
+// parentheses, or that are named like an operator, must be enclosed in double quotes (e.g., `"GRPC Server" AND db`).
+// An AND term reports the most critical status of its operands, and an OR term reports the least critical status
+// of its operands (in the order down, degraded, unknown, up). For example, the formula above is degraded if the
+// database is up and cacheA is degraded while cacheB is down. Checks that are not referenced by the formula do not
+// affect the readiness. Referenced checks that do not participate in the aggregation (e.g., because they are
+// disabled by a FlagProvider) are considered unknown. The aggregated status of the Checker (e.g., of Checker.Check,
+// State and liveness handlers) is still computed from all checks, so the formula cannot be combined with
+// WithAggregator or WithStatusPrecedence (see ErrConflictingAggregation). The formula is validated when the
+// Checker is created: syntax errors, names of checks that do not exist and canaries (which must not affect the
+// readiness, see WithCanary) make BuildChecker fail (and NewChecker panic).
+func WithReadinessExpression(expr string) Option {
+	return func(cfg *checkerConfig) {
+		cfg.readinessExpression = expr
+	}
+}
+
+// compileReadinessExpression parses the expression and validates that all referenced checks exist and are
+// not canaries.
+func compileReadinessExpression(expr string, checks map[string]*Check) (func(map[string]CheckState) AvailabilityStatus, error) {
+	tokens, err := tokenizeReadinessExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid readiness expression %q: %w", expr, err)
+	}
+
+	parser := &readinessParser{tokens: tokens}
+
+	root, err := parser.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid readiness expression %q: %w", expr, err)
+	}
+
+	for _, name := range parser.names {
+		check, ok := checks[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid readiness expression %q: %w: %q", expr, ErrCheckNotFound, name)
+		}
+
+		if check.canary {
+			return nil, fmt.Errorf("invalid readiness expression %q: %w: %q", expr, ErrCanaryCheck, name)
+		}
+	}
+
+	return root.eval, nil
+}
+
+func tokenizeReadinessExpression(expr string) ([]string, error) {
+	var (
+		tokens []string
+		runes  = []rune(expr)
+	)
+
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, errors.New("unterminated quoted check name")
+			}
+
+			// Quoted names are marked with a leading quote, so that they are never mistaken for operators.
+			tokens = append(tokens, `"`+string(runes[i+1:end]))
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '(' && runes[end] != ')' &&
+				runes[end] != '"' {
+				end++
+			}
+
+			tokens = append(tokens, string(runes[i:end]))
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+func (p *readinessParser) parse() (readinessExpr, error) {
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	return expr, nil
+}
+
+func (p *readinessParser) parseOr() (readinessExpr, error) {
+	return p.parseOperation("OR", p.parseAnd, func(operands []readinessExpr) readinessExpr { return orExpr(operands) })
+}
+
+func (p *readinessParser) parseAnd() (readinessExpr, error) {
+	return p.parseOperation("AND", p.parsePrimary, func(operands []readinessExpr) readinessExpr { return andExpr(operands) })
+}
+
+// parseOperation parses a sequence of operands that are separated by the given operator.
+func (p *readinessParser) parseOperation(
+	operator string,
+	parseOperand func() (readinessExpr, error),
+	newExpr func([]readinessExpr) readinessExpr,
+) (readinessExpr, error) {
+	operand, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []readinessExpr{operand}
+
+	for p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], operator) {
+		p.pos++
+
+		operand, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, operand)
+	}
+
+	if len(operands) == 1 {
+		return operand, nil
+	}
+
+	return newExpr(operands), nil
+}
+
+func (p *readinessParser) parsePrimary() (readinessExpr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch {
+	case token == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+
+		return expr, nil
+	case token == ")", strings.EqualFold(token, "AND"), strings.EqualFold(token, "OR"):
+		return nil, fmt.Errorf("unexpected %q", token)
+	default:
+		name := strings.TrimPrefix(token, `"`)
+		p.names = append(p.names, name)
+
+		return checkNameExpr(name), nil
+	}
+}
@@ -0,0 +1,235 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// statusCheck returns a check that reports the given status.
+func statusCheck(name string, status health.AvailabilityStatus) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			switch status {
+			case health.StatusDown:
+				return errors.New("unavailable")
+			case health.StatusDegraded:
+				return fmt.Errorf("slow: %w", health.ErrDegraded)
+			default:
+				return nil
+			}
+		},
+	}
+}
+
+// readinessStatus returns the status that a readiness handler of the checker reports.
+func readinessStatus(t *testing.T, ckr health.Checker) health.AvailabilityStatus {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	health.NewReadinessHandler(ckr).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	var body struct {
+		Status health.AvailabilityStatus `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	return body.Status
+}
+
+func TestReadinessExpression(t *testing.T) {
+	up, degraded, down := health.StatusUp, health.StatusDegraded, health.StatusDown
+
+	tests := []struct {
+		name     string
+		expr     string
+		statuses map[string]health.AvailabilityStatus
+		expected health.AvailabilityStatus
+	}{
+		{
+			name:     "AND with all up",
+			expr:     "db AND cacheA",
+			statuses: map[string]health.AvailabilityStatus{"db": up, "cacheA": up, "cacheB": down},
+			expected: up,
+		},
+		{
+			name:     "AND with one down",
+			expr:     "db AND cacheA",
+			statuses: map[string]health.AvailabilityStatus{"db": up, "cacheA": down},
+			expected: down,
+		},
+		{
+			name:     "OR with one up",
+			expr:     "cacheA OR cacheB",
+			statuses: map[string]health.AvailabilityStatus{"cacheA": down, "cacheB": up},
+			expected: up,
+		},
+		{
+			name:     "OR with all down",
+			expr:     "cacheA or cacheB",
+			statuses: map[string]health.AvailabilityStatus{"cacheA": down, "cacheB": down},
+			expected: down,
+		},
+		{
+			name:     "nested with fallback cache",
+			expr:     "db AND (cacheA OR cacheB)",
+			statuses: map[string]health.AvailabilityStatus{"db": up, "cacheA": down, "cacheB": up},
+			expected: up,
+		},
+		{
+			name:     "nested with degraded cache",
+			expr:     "db AND (cacheA OR cacheB)",
+			statuses: map[string]health.AvailabilityStatus{"db": up, "cacheA": degraded, "cacheB": down},
+			expected: degraded,
+		},
+		{
+			name:     "nested with database down",
+			expr:     "db AND (cacheA OR cacheB)",
+			statuses: map[string]health.AvailabilityStatus{"db": down, "cacheA": up, "cacheB": up},
+			expected: down,
+		},
+		{
+			name:     "AND binds stronger than OR",
+			expr:     "db AND cacheA OR cacheB",
+			statuses: map[string]health.AvailabilityStatus{"db": down, "cacheA": up, "cacheB": up},
+			expected: up,
+		},
+		{
+			name:     "quoted names",
+			expr:     `"GRPC Server" AND "OR"`,
+			statuses: map[string]health.AvailabilityStatus{"GRPC Server": up, "OR": degraded},
+			expected: degraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			options := []health.Option{health.WithDisabledAutostart(), health.WithReadinessExpression(tt.expr)}
+			for name, status := range tt.statuses {
+				options = append(options, health.WithCheck(statusCheck(name, status)))
+			}
+
+			ckr, err := health.BuildChecker(options...)
+			require.NoError(t, err)
+
+			// Act
+			status := readinessStatus(t, ckr)
+
+			// Assert
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
+
+func TestReadinessExpressionDoesNotAffectAggregatedStatus(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithReadinessExpression("db"),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+		health.WithCheck(statusCheck("worker", health.StatusDown)),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+	readiness := readinessStatus(t, ckr)
+
+	// Assert
+	assert.Equal(t, health.StatusDown, result.Status, "checks that are not referenced must affect liveness")
+	assert.Equal(t, health.StatusDown, ckr.(health.StateReader).State().Status)
+	assert.Equal(t, health.StatusUp, readiness)
+}
+
+func TestReadinessExpressionConflictsWithAggregator(t *testing.T) {
+	options := map[string]health.Option{
+		"aggregator":        health.WithAggregator(health.QuorumAggregator(1)),
+		"status precedence": health.WithStatusPrecedence([]health.AvailabilityStatus{health.StatusDown, health.StatusDegraded, health.StatusUnknown, health.StatusUp}),
+	}
+
+	for name, option := range options {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := health.BuildChecker(
+				health.WithDisabledAutostart(),
+				health.WithCheck(statusCheck("db", health.StatusUp)),
+				health.WithReadinessExpression("db"),
+				option,
+			)
+
+			// Assert
+			require.ErrorIs(t, err, health.ErrConflictingAggregation)
+		})
+	}
+}
+
+func TestReadinessExpressionValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		expr        string
+		expectedErr string
+	}{
+		{name: "unknown check", expr: "db AND cache", expectedErr: `"cache"`},
+		{name: "missing operand", expr: "db AND", expectedErr: "unexpected end of expression"},
+		{name: "missing operator", expr: "db db", expectedErr: `unexpected "db"`},
+		{name: "leading operator", expr: "OR db", expectedErr: `unexpected "OR"`},
+		{name: "missing closing parenthesis", expr: "(db OR db", expectedErr: "missing closing parenthesis"},
+		{name: "unexpected closing parenthesis", expr: "db)", expectedErr: `unexpected ")"`},
+		{name: "unterminated quote", expr: `"db`, expectedErr: "unterminated quoted check name"},
+		{name: "empty", expr: "   ", expectedErr: "empty expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			ckr, err := health.BuildChecker(
+				health.WithDisabledAutostart(),
+				health.WithReadinessExpression(tt.expr),
+				health.WithCheck(statusCheck("db", health.StatusUp)),
+			)
+
+			// Assert
+			require.Error(t, err)
+			assert.Nil(t, ckr)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestReadinessExpressionUnknownCheckIsNotFound(t *testing.T) {
+	// Act
+	_, err := health.BuildChecker(health.WithDisabledAutostart(), health.WithReadinessExpression("db"))
+
+	// Assert
+	require.ErrorIs(t, err, health.ErrCheckNotFound)
+}
+
+func TestReadinessExpressionRejectsCanaries(t *testing.T) {
+	// Act
+	_, err := health.BuildChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+		health.WithCheck(statusCheck("new-check", health.StatusDown), health.WithCanary()),
+		health.WithReadinessExpression("db AND new-check"),
+	)
+
+	// Assert
+	require.ErrorIs(t, err, health.ErrCanaryCheck)
+}
+
+func TestNewCheckerPanicsOnInvalidReadinessExpression(t *testing.T) {
+	// Act & Assert
+	assert.Panics(t, func() {
+		health.NewChecker(health.WithDisabledAutostart(), health.WithReadinessExpression("db AND"))
+	})
+}
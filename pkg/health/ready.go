@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// WithOnReady sets a callback that is called once the aggregated status is StatusUp for the first time after the
+// Checker was started (see Checker.Start), e.g., to record the time to ready of deployments. The callback receives
+// the time that elapsed since the start and fires exactly once in the lifetime of the Checker, even if it is
+// restarted or its status changes later on. The time to ready is also exposed by StatsProvider.Stats (see
+// Stats.TimeToReady). The callback is notified like the status listeners (see WithDeferredListeners).
+func WithOnReady(callback func(timeToReady time.Duration)) Option {
+	return func(cfg *checkerConfig) {
+		cfg.onReady = callback
+	}
+}
+
+// recordReadiness records the time to ready, once the aggregated status is StatusUp for the first time after
+// the Checker was started, and notifies the callback (see WithOnReady). The caller must hold the mutex lock.
+func (ck *defaultChecker) recordReadiness(ctx context.Context, now time.Time) {
+	if ck.state.Status != StatusUp || ck.timeToReady.Load() != nil {
+		return
+	}
+
+	startedAt := ck.startedAt.Load()
+	if startedAt == nil {
+		return
+	}
+
+	timeToReady := now.Sub(*startedAt)
+	ck.timeToReady.Store(&timeToReady)
+
+	if callback := ck.cfg.onReady; callback != nil {
+		notifyListener(ctx, func(context.Context) { callback(timeToReady) })
+	}
+}
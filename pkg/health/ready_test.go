@@ -0,0 +1,93 @@
+package health_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestOnReadyFiresOnce(t *testing.T) {
+	// Arrange
+	var (
+		fail      atomic.Bool
+		mtx       sync.Mutex
+		durations []time.Duration
+	)
+
+	fail.Store(true)
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithOnReady(func(timeToReady time.Duration) {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			durations = append(durations, timeToReady)
+		}),
+		health.WithPeriodicCheck(5*time.Millisecond, 0, toggledCheck("db", &fail)),
+		health.WithCheck(toggledCheck("cache", &fail)),
+	)
+	defer ckr.Stop()
+
+	// Act
+	ckr.Start()
+	time.Sleep(30 * time.Millisecond)
+	fail.Store(false)
+
+	require.Eventually(t, func() bool { return ckr.Check(t.Context()).Status == health.StatusUp }, time.Second, time.Millisecond)
+
+	// The checker fails and recovers again, which must not fire the callback another time.
+	fail.Store(true)
+	require.Eventually(t, func() bool { return ckr.Check(t.Context()).Status == health.StatusDown }, time.Second, time.Millisecond)
+	fail.Store(false)
+	require.Eventually(t, func() bool { return ckr.Check(t.Context()).Status == health.StatusUp }, time.Second, time.Millisecond)
+
+	// Assert
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	require.Len(t, durations, 1)
+	assert.GreaterOrEqual(t, durations[0], 30*time.Millisecond)
+	assert.Less(t, durations[0], time.Second)
+	assert.Equal(t, durations[0], ckr.(health.StatsProvider).Stats().TimeToReady)
+}
+
+func TestTimeToReadyBeforeReady(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	fail.Store(true)
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(toggledCheck("db", &fail)),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	assert.Zero(t, ckr.(health.StatsProvider).Stats().TimeToReady)
+}
+
+func TestStatsJSONTimeToReady(t *testing.T) {
+	// Arrange
+	stats := health.Stats{TimeToReady: 1500 * time.Millisecond}
+
+	// Act
+	data, err := stats.MarshalJSON()
+	require.NoError(t, err)
+
+	var decoded health.Stats
+	require.NoError(t, decoded.UnmarshalJSON(data))
+
+	// Assert
+	assert.JSONEq(t, `{"scheduler":{},"droppedTickerStates":0,"timeToReady":"1.5s"}`, string(data))
+	assert.Equal(t, stats, decoded)
+}
@@ -0,0 +1,150 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reason codes are stable, machine-readable identifiers describing why a check failed.
+// They are set in CheckState.Reason and included in the check details of a Result.
+const (
+	// ReasonTimeout is set if a check did not complete in time.
+	ReasonTimeout = "TIMEOUT"
+	// ReasonConnRefused is set if the checked service refused the connection.
+	ReasonConnRefused = "CONN_REFUSED"
+	// ReasonConnectFailed is set if a connection to the checked service could not be established.
+	ReasonConnectFailed = "CONNECT_FAILED"
+	// ReasonUnavailable is set if the checked service reported that it is unavailable.
+	ReasonUnavailable = "UNAVAILABLE"
+	// ReasonNotServing is set if a gRPC health endpoint reported a status other than SERVING.
+	ReasonNotServing = "NOT_SERVING"
+	// ReasonDegraded is set if the check reported that the checked component is degraded (see ErrDegraded).
+	ReasonDegraded = "DEGRADED"
+	// ReasonThresholdExceeded is set if a value measured by a check exceeded a threshold (see WithThresholds).
+	ReasonThresholdExceeded = "THRESHOLD_EXCEEDED"
+	// ReasonPanic is set if the check function panicked.
+	ReasonPanic = "PANIC"
+	// ReasonQuarantined is set if a periodic check is no longer scheduled, because it panicked repeatedly
+	// (see WithPanicQuarantine).
+	ReasonQuarantined = "QUARANTINED"
+	// ReasonUnknownTimeout is set if a check did not produce a first result in time (see WithUnknownTimeout).
+	ReasonUnknownTimeout = "UNKNOWN_TIMEOUT"
+	// ReasonOutsideActiveWindow is set if a check failed outside its active window (see WithActiveWindow).
+	ReasonOutsideActiveWindow = "OUTSIDE_ACTIVE_WINDOW"
+	// ReasonDependencyDown is set if a check is degraded, because one of its soft dependencies is down
+	// (see WithSoftDependsOn).
+	ReasonDependencyDown = "DEPENDENCY_DOWN"
+	// ReasonErrorRate is set if a check is degraded, because too many of its recent evaluations failed
+	// (see WithErrorRateThreshold).
+	ReasonErrorRate = "ERROR_RATE"
+	// ReasonSchedulerStalled is set by the SelfCheck if the evaluations of periodic checks are stale.
+	ReasonSchedulerStalled = "SCHEDULER_STALLED"
+	// ReasonQueueOverflow is set by the SelfCheck if check evaluations or ticker states are queuing up.
+	ReasonQueueOverflow = "QUEUE_OVERFLOW"
+	// ReasonCanceled is set if the check evaluation was cancelled (see CheckCanceler.CancelCheck).
+	ReasonCanceled = "CANCELED"
+	// ReasonGlobalTimeout is set if the check evaluation exceeded the timeout of the Checker (see WithTimeout).
+	ReasonGlobalTimeout = "GLOBAL_TIMEOUT"
+	// ReasonRequestCanceled is set if the check evaluation was cancelled by the caller of Checker.Check
+	// (e.g., because the client of the health endpoint went away).
+	ReasonRequestCanceled = "REQUEST_CANCELED"
+	// ReasonShutdown is set if the check evaluation was interrupted by Checker.Stop.
+	ReasonShutdown = "SHUTDOWN"
+	// ReasonError is set for all errors that could not be classified otherwise.
+	ReasonError = "ERROR"
+)
+
+type reasonError struct {
+	reason string
+	err    error
+}
+
+func (e *reasonError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reasonError) Unwrap() error {
+	return e.err
+}
+
+// ErrorWithReason attaches a machine-readable reason code to an error. Check functions can use it
+// to report a reason code that takes precedence over the automatic classification (see ReasonOf).
+// It returns nil if err is nil.
+func ErrorWithReason(reason string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &reasonError{reason: reason, err: err}
+}
+
+// ReasonOf returns the reason code for a check error. An explicit reason attached with ErrorWithReason
+// takes precedence. Otherwise, the error is classified by its type (e.g., timeouts, refused connections
+// or gRPC status codes). It returns an empty string if err is nil and ReasonError if the error cannot
+// be classified.
+func ReasonOf(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var rErr *reasonError
+	if errors.As(err, &rErr) {
+		return rErr.reason
+	}
+
+	if errors.Is(err, ErrDegraded) {
+		return ReasonDegraded
+	}
+
+	if errors.Is(err, ErrCheckCanceled) {
+		return ReasonCanceled
+	}
+
+	if errors.Is(err, ErrGlobalTimeout) {
+		return ReasonGlobalTimeout
+	}
+
+	if errors.Is(err, ErrRequestCanceled) {
+		return ReasonRequestCanceled
+	}
+
+	if errors.Is(err, ErrCheckerStopped) {
+		return ReasonShutdown
+	}
+
+	if errors.Is(err, ErrCheckTimeout) || errors.Is(err, ErrAttemptTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return ReasonTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return ReasonConnRefused
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ReasonTimeout
+	}
+
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK && s.Code() != codes.Unknown {
+		switch s.Code() {
+		case codes.DeadlineExceeded:
+			return ReasonTimeout
+		case codes.Unavailable:
+			if strings.Contains(s.Message(), "connection refused") {
+				return ReasonConnRefused
+			}
+
+			return ReasonUnavailable
+		default:
+			return ReasonError
+		}
+	}
+
+	return ReasonError
+}
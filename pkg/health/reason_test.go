@@ -0,0 +1,190 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openkcm/common-sdk/pkg/commoncfg"
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestReasonOf(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedReason string
+	}{
+		{
+			name:           "NoErrorThenNoReason",
+			err:            nil,
+			expectedReason: "",
+		},
+		{
+			name:           "ExplicitReasonTakesPrecedence",
+			err:            health.ErrorWithReason("THRESHOLD_EXCEEDED", context.DeadlineExceeded),
+			expectedReason: "THRESHOLD_EXCEEDED",
+		},
+		{
+			name:           "CheckTimeout",
+			err:            health.ErrCheckTimeout,
+			expectedReason: health.ReasonTimeout,
+		},
+		{
+			name:           "GlobalTimeout",
+			err:            health.ErrGlobalTimeout,
+			expectedReason: health.ReasonGlobalTimeout,
+		},
+		{
+			name:           "RequestCanceled",
+			err:            health.ErrRequestCanceled,
+			expectedReason: health.ReasonRequestCanceled,
+		},
+		{
+			name:           "CheckerStopped",
+			err:            health.ErrCheckerStopped,
+			expectedReason: health.ReasonShutdown,
+		},
+		{
+			name:           "WrappedDeadlineExceeded",
+			err:            fmt.Errorf("ping failed: %w", context.DeadlineExceeded),
+			expectedReason: health.ReasonTimeout,
+		},
+		{
+			name:           "ConnectionRefused",
+			err:            &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			expectedReason: health.ReasonConnRefused,
+		},
+		{
+			name:           "GRPCDeadlineExceeded",
+			err:            status.Error(codes.DeadlineExceeded, "deadline exceeded"),
+			expectedReason: health.ReasonTimeout,
+		},
+		{
+			name:           "GRPCUnavailable",
+			err:            status.Error(codes.Unavailable, "server shutting down"),
+			expectedReason: health.ReasonUnavailable,
+		},
+		{
+			name:           "UnclassifiedError",
+			err:            errors.New("something went wrong"),
+			expectedReason: health.ReasonError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			reason := health.ReasonOf(tc.err)
+
+			// Assert
+			assert.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
+
+func TestErrorWithReasonKeepsErrorChain(t *testing.T) {
+	// Arrange
+	cause := errors.New("cause")
+
+	// Act
+	err := health.ErrorWithReason("CUSTOM", cause)
+
+	// Assert
+	require.ErrorIs(t, err, cause)
+	assert.Equal(t, "cause", err.Error())
+	assert.NoError(t, health.ErrorWithReason("CUSTOM", nil))
+}
+
+func TestCheckerSetsReasonCodes(t *testing.T) {
+	tests := []struct {
+		name           string
+		option         health.Option
+		checkName      string
+		expectedReason string
+	}{
+		{
+			name: "SuccessfulCheckHasNoReason",
+			option: health.WithCheck(health.Check{
+				Name:  "ok",
+				Check: func(ctx context.Context) error { return nil },
+			}),
+			checkName:      "ok",
+			expectedReason: "",
+		},
+		{
+			name: "TimedOutCheck",
+			option: health.WithCheck(health.Check{
+				Name:    "slow",
+				Timeout: 10 * time.Millisecond,
+				Check: func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				},
+			}),
+			checkName:      "slow",
+			expectedReason: health.ReasonTimeout,
+		},
+		{
+			name: "PanickingCheck",
+			option: health.WithCheck(health.Check{
+				Name:  "panic",
+				Check: func(ctx context.Context) error { panic("boom") },
+			}),
+			checkName:      "panic",
+			expectedReason: health.ReasonPanic,
+		},
+		{
+			name:           "DatabaseCheckerConnectFailure",
+			option:         health.WithDatabaseChecker("unknown-driver", "dsn"),
+			checkName:      "unknown-driver",
+			expectedReason: health.ReasonConnectFailed,
+		},
+		{
+			name:           "GRPCServerCheckerConnectionRefused",
+			option:         health.WithGRPCServerChecker(commoncfg.GRPCClient{Address: "localhost:9999"}),
+			checkName:      "GRPC Server",
+			expectedReason: health.ReasonConnRefused,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(health.WithDisabledAutostart(), health.WithTimeout(5*time.Second), tc.option)
+
+			// Act
+			res := ckr.Check(t.Context())
+
+			// Assert
+			require.Contains(t, res.Details, tc.checkName)
+			assert.Equal(t, tc.expectedReason, res.Details[tc.checkName].Reason)
+		})
+	}
+}
+
+func TestCheckResultJSONContainsReason(t *testing.T) {
+	// Arrange
+	cr := health.CheckResult{Status: health.StatusDown, Error: errors.New("refused"), Reason: health.ReasonConnRefused}
+
+	// Act
+	data, err := json.Marshal(cr)
+	require.NoError(t, err)
+
+	var decoded health.CheckResult
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// Assert
+	assert.Contains(t, string(data), `"reason":"CONN_REFUSED"`)
+	assert.Equal(t, health.ReasonConnRefused, decoded.Reason)
+}
@@ -0,0 +1,127 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// responseCache caches complete HTTP responses of a handler (see WithResponseCache). Each audience
+	// (i.e., callers with and without details, see WithDetailsAuthorizer) has its own cache entry.
+	responseCache struct {
+		ttl     time.Duration
+		clock   Clock
+		entries map[bool]*responseCacheEntry
+	}
+
+	responseCacheEntry struct {
+		mtx      sync.Mutex
+		response *cachedResponse
+	}
+
+	cachedResponse struct {
+		header     http.Header
+		statusCode int
+		body       []byte
+		expiresAt  time.Time
+	}
+
+	// bufferedResponseWriter records a response, so that it can be written later.
+	bufferedResponseWriter struct {
+		header     http.Header
+		statusCode int
+		body       bytes.Buffer
+	}
+)
+
+func newResponseCache(ttl time.Duration, clock Clock) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		clock:   clock,
+		entries: map[bool]*responseCacheEntry{false: {}, true: {}},
+	}
+}
+
+// serve writes the cached response for the given audience. If there is no such response or it expired,
+// a new response is rendered. The lock of the audience is held while rendering, so that concurrent requests
+// wait for the new response instead of rendering it as well. The response is rendered with a context that is
+// not cancelled with the request, because it is shared with the waiting requests. Failed responses are not cached.
+// serve returns true, if the response was served from the cache.
+func (rc *responseCache) serve(
+	w http.ResponseWriter,
+	r *http.Request,
+	detailed bool,
+	render func(w http.ResponseWriter, r *http.Request, detailed bool) error,
+) bool {
+	entry := rc.entries[detailed]
+	entry.mtx.Lock()
+
+	response := entry.response
+	hit := response != nil && rc.clock.Now().Before(response.expiresAt)
+
+	if !hit {
+		bw := &bufferedResponseWriter{header: http.Header{}}
+		if err := render(bw, r.WithContext(context.WithoutCancel(r.Context())), detailed); err != nil {
+			entry.mtx.Unlock()
+
+			// A response that was written before the failure (e.g., the rejection of an excess request,
+			// see WithMaxConcurrentHandlers) is passed on as is.
+			if bw.statusCode != 0 {
+				bw.writeTo(w)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+
+			return false
+		}
+
+		if bw.statusCode == 0 {
+			bw.statusCode = http.StatusOK
+		}
+
+		response = &cachedResponse{
+			header:     bw.header,
+			statusCode: bw.statusCode,
+			body:       bw.body.Bytes(),
+			expiresAt:  rc.clock.Now().Add(rc.ttl),
+		}
+		entry.response = response
+	}
+
+	entry.mtx.Unlock()
+
+	// The cached response is never modified, so it can be written without holding the lock.
+	maps.Copy(w.Header(), response.header.Clone())
+	w.WriteHeader(response.statusCode)
+	_, _ = w.Write(response.body)
+
+	return hit
+}
+
+func (w *bufferedResponseWriter) writeTo(rw http.ResponseWriter) {
+	maps.Copy(rw.Header(), w.header)
+	rw.WriteHeader(w.statusCode)
+	_, _ = rw.Write(w.body.Bytes())
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	return w.body.Write(data)
+}
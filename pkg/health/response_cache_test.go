@@ -0,0 +1,179 @@
+package health_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type countingResultWriter struct {
+	writes atomic.Int32
+	health.JSONResultWriter
+}
+
+func (rw *countingResultWriter) Write(result *health.Result, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	rw.writes.Add(1)
+	return rw.JSONResultWriter.Write(result, statusCode, w, r)
+}
+
+func TestResponseCache(t *testing.T) {
+	// Arrange
+	var checks atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				checks.Add(1)
+				return nil
+			},
+		}),
+	)
+	writer := &countingResultWriter{}
+	handler := health.NewHandler(ckr, health.WithResultWriter(writer), health.WithResponseCache(time.Hour))
+
+	// Act
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 100)
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i] = httptest.NewRecorder()
+			handler.ServeHTTP(responses[i], httptest.NewRequest(http.MethodGet, "/health", nil))
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), checks.Load())
+	assert.Equal(t, int32(1), writer.writes.Load())
+
+	for _, response := range responses {
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
+		assert.Equal(t, "no-cache", response.Header().Get("Cache-Control"))
+		assert.Equal(t, responses[0].Body.String(), response.Body.String())
+	}
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	// Arrange
+	writer := &countingResultWriter{}
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+	handler := health.NewHandler(ckr, health.WithResultWriter(writer), health.WithResponseCache(20*time.Millisecond))
+
+	// Act
+	for range 5 {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	}
+	time.Sleep(30 * time.Millisecond)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, int32(2), writer.writes.Load())
+}
+
+func TestResponseCacheSeparatesAudiences(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := health.NewHandler(ckr,
+		health.WithResponseCache(time.Hour),
+		health.WithDetailsAuthorizer(func(r *http.Request) bool { return r.Header.Get("X-Internal") == "true" }),
+	)
+
+	internalRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	internalRequest.Header.Set("X-Internal", "true")
+
+	// Act
+	external := httptest.NewRecorder()
+	handler.ServeHTTP(external, httptest.NewRequest(http.MethodGet, "/health", nil))
+	internal := httptest.NewRecorder()
+	handler.ServeHTTP(internal, internalRequest)
+
+	// Assert
+	require.Equal(t, http.StatusOK, external.Code)
+	assert.JSONEq(t, `{"status":"up"}`, external.Body.String())
+	assert.Contains(t, internal.Body.String(), `"details"`)
+}
+
+func TestResponseCacheExpiresWithClockOfChecker(t *testing.T) {
+	// Arrange
+	clock := newFakeClock(time.Now())
+	writer := &countingResultWriter{}
+	ckr := health.NewChecker(health.WithDisabledAutostart(), health.WithClock(clock))
+	handler := health.NewHandler(ckr, health.WithResultWriter(writer), health.WithResponseCache(time.Minute))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	clock.Advance(30 * time.Second)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	clock.Advance(time.Minute)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, int32(2), writer.writes.Load())
+}
+
+func TestResponseCacheIgnoresCancellationOfRequest(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{Name: "check", Check: func(ctx context.Context) error { return ctx.Err() }}),
+	)
+	handler := health.NewHandler(ckr, health.WithResponseCache(time.Hour))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil).WithContext(ctx))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+type failingResultWriter struct {
+	writes atomic.Int32
+}
+
+func (rw *failingResultWriter) Write(*health.Result, int, http.ResponseWriter, *http.Request) error {
+	rw.writes.Add(1)
+	return assert.AnError
+}
+
+func TestResponseCacheDoesNotCacheFailedResponses(t *testing.T) {
+	// Arrange
+	writer := &failingResultWriter{}
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+	handler := health.NewHandler(ckr, health.WithResultWriter(writer), health.WithResponseCache(time.Hour))
+
+	// Act
+	responses := make([]*httptest.ResponseRecorder, 2)
+	for i := range responses {
+		responses[i] = httptest.NewRecorder()
+		handler.ServeHTTP(responses[i], httptest.NewRequest(http.MethodGet, "/health", nil))
+	}
+
+	// Assert
+	assert.Equal(t, int32(2), writer.writes.Load())
+	for _, response := range responses {
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	}
+}
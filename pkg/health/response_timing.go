@@ -0,0 +1,64 @@
+package health
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ServerTimingHeader is the HTTP header that holds the timing breakdown of a health response (see WithResponseTiming).
+const ServerTimingHeader = "Server-Timing"
+
+// Names of the metrics in the ServerTimingHeader (see WithResponseTiming).
+const (
+	TimingAggregation   = "aggregation"
+	TimingSerialization = "serialization"
+)
+
+// timingResponseWriter buffers the response, so that the time it takes to serialize the result
+// can be added as a header before the response is written.
+type timingResponseWriter struct {
+	http.ResponseWriter
+
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *timingResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *timingResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+// flush writes the buffered response with the given timings in the ServerTimingHeader. The time of the
+// serialization is measured from the given start time until now.
+func (w *timingResponseWriter) flush(aggregation time.Duration, serializationStartedAt time.Time) error {
+	serialization := time.Since(serializationStartedAt)
+
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	metrics := []string{
+		formatServerTiming(TimingAggregation, aggregation),
+		formatServerTiming(TimingSerialization, serialization),
+	}
+
+	w.Header().Set(ServerTimingHeader, strings.Join(metrics, ", "))
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	_, err := w.ResponseWriter.Write(w.body.Bytes())
+
+	return err
+}
+
+// formatServerTiming formats a metric of the ServerTimingHeader, e.g., "aggregation;dur=1.234".
+// Durations are in milliseconds.
+func formatServerTiming(name string, d time.Duration) string {
+	return fmt.Sprintf("%s;dur=%.3f", name, float64(d)/float64(time.Millisecond))
+}
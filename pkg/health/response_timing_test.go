@@ -0,0 +1,120 @@
+package health_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// gzipResultWriter writes a Result as gzip compressed JSON.
+type gzipResultWriter struct{}
+
+func (gzipResultWriter) Write(result *health.Result, statusCode int, w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(statusCode)
+
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(result); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// parseServerTiming returns the durations of the metrics of a Server-Timing header by their names.
+func parseServerTiming(t *testing.T, header string) map[string]time.Duration {
+	t.Helper()
+
+	timings := map[string]time.Duration{}
+	for metric := range strings.SplitSeq(header, ",") {
+		name, dur, ok := strings.Cut(strings.TrimSpace(metric), ";dur=")
+		require.True(t, ok, "metric %q has no duration", metric)
+
+		ms, err := strconv.ParseFloat(dur, 64)
+		require.NoError(t, err)
+
+		timings[name] = time.Duration(ms * float64(time.Millisecond))
+	}
+
+	return timings
+}
+
+func TestResponseTiming(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{
+			Name: "slow",
+			Check: func(ctx context.Context) error {
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			},
+		}),
+	)
+	handler := health.NewHandler(ckr, health.WithResponseTiming(), health.WithResultWriter(gzipResultWriter{}))
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	// Act
+	handler.ServeHTTP(response, request)
+
+	// Assert
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "gzip", response.Header().Get("Content-Encoding"))
+
+	timings := parseServerTiming(t, response.Header().Get(health.ServerTimingHeader))
+	require.Len(t, timings, 2)
+	assert.GreaterOrEqual(t, timings[health.TimingAggregation], 5*time.Millisecond)
+	assert.Positive(t, timings[health.TimingSerialization])
+
+	body, err := gzip.NewReader(response.Body)
+	require.NoError(t, err)
+
+	var result health.Result
+	require.NoError(t, json.NewDecoder(body).Decode(&result))
+	assert.Equal(t, health.StatusUp, result.Status)
+}
+
+func TestResponseTimingWithResponseSigner(t *testing.T) {
+	// Arrange
+	key := []byte("shared-secret")
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+	handler := health.NewHandler(ckr, health.WithResponseTiming(), health.WithResponseSigner(key))
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	// Act
+	handler.ServeHTTP(response, request)
+
+	// Assert
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Header().Get(health.ServerTimingHeader), health.TimingSerialization+";dur=")
+	require.NoError(t, health.VerifyResponseSignature(key, response.Body.Bytes(), response.Header().Get(health.SignatureHeader)))
+}
+
+func TestResponseTimingDisabledByDefault(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+	handler := health.NewHandler(ckr)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	// Act
+	handler.ServeHTTP(response, request)
+
+	// Assert
+	assert.Empty(t, response.Header().Get(health.ServerTimingHeader))
+}
@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// retryPolicy configures how a failed check function is retried (see WithRetry and WithRetryIf).
+type retryPolicy struct {
+	maxAttempts     uint
+	backoff         time.Duration
+	backoffStrategy BackoffStrategy
+	retryIf         func(err error) bool
+	attemptTimeout  time.Duration
+}
+
+// WithRetry retries a failed check function within the same evaluation until it succeeds or the
+// maximum number of attempts (including the first one) is reached. Between two attempts, the check
+// waits for the given backoff duration (see WithRetryBackoff for other strategies). Retries adhere to the timeout of the check evaluation.
+// Only the result of the last attempt is reported.
+func WithRetry(maxAttempts uint, backoff time.Duration) CheckOption {
+	return func(check *Check) {
+		policy := check.retryPolicyOrDefault()
+		policy.maxAttempts = maxAttempts
+		policy.backoff = backoff
+	}
+}
+
+// WithRetryIf restricts retries (see WithRetry) to errors for which the predicate returns true.
+// This allows to retry only transient failures (e.g., timeouts), while deterministic failures
+// (e.g., authentication errors) fail immediately. By default, all errors are retried.
+func WithRetryIf(predicate func(err error) bool) CheckOption {
+	return func(check *Check) {
+		check.retryPolicyOrDefault().retryIf = predicate
+	}
+}
+
+// WithPerAttemptTimeout gives each attempt of a check function its own timeout (see WithRetry), so that a single
+// slow attempt does not consume the time of the remaining attempts. An attempt that exceeds its timeout fails with
+// ErrAttemptTimeout and is retried like any other failure. The timeout of the check evaluation still caps the total
+// time of all attempts. By default, all attempts share the timeout of the check evaluation.
+func WithPerAttemptTimeout(timeout time.Duration) CheckOption {
+	return func(check *Check) {
+		check.retryPolicyOrDefault().attemptTimeout = timeout
+	}
+}
+
+func (check *Check) retryPolicyOrDefault() *retryPolicy {
+	if check.retry == nil {
+		check.retry = &retryPolicy{maxAttempts: 1}
+	}
+
+	return check.retry
+}
+
+func executeCheckFuncWithRetries(ctx context.Context, check *Check) checkOutcome {
+	return executeCheckAttempts(ctx, check, executeCheckFuncWithAttemptTimeout)
+}
+
+// executeCheckAttempts executes the check function with retries (see WithRetry). Each attempt is executed by
+// executeAttempt, so that a debugged evaluation can record its attempts (see CheckDebugger.DebugCheck).
+func executeCheckAttempts(
+	ctx context.Context,
+	check *Check,
+	executeAttempt func(ctx context.Context, check *Check) checkOutcome,
+) checkOutcome {
+	outcome := executeAttempt(ctx, check)
+
+	policy := check.retry
+	if policy == nil {
+		return outcome
+	}
+
+	for attempt := uint(1); attempt < policy.maxAttempts && policy.shouldRetry(outcome.err); attempt++ {
+		if waitForStopSignal(ctx, policy.delay(attempt)) {
+			return outcome
+		}
+
+		outcome = executeAttempt(ctx, check)
+	}
+
+	return outcome
+}
+
+// delay returns the backoff delay before the given retry.
+func (p *retryPolicy) delay(retry uint) time.Duration {
+	if p.backoffStrategy != nil {
+		return p.backoffStrategy(retry)
+	}
+
+	return p.backoff
+}
+
+func (p *retryPolicy) shouldRetry(err error) bool {
+	if err == nil || errors.Is(err, ErrCheckCanceled) || errors.Is(err, ErrCheckTimeout) {
+		return false
+	}
+
+	return p.retryIf == nil || p.retryIf(err)
+}
+
+// executeCheckFuncWithAttemptTimeout executes the check function once, adhering to the timeout
+// of a single attempt (see WithPerAttemptTimeout).
+func executeCheckFuncWithAttemptTimeout(ctx context.Context, check *Check) checkOutcome {
+	if check.retry == nil || check.retry.attemptTimeout <= 0 {
+		return executeCheckFunc(ctx, check)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, check.retry.attemptTimeout)
+	defer cancel()
+
+	outcome := executeCheckFunc(attemptCtx, check)
+	if outcome.err != nil && attemptCtx.Err() != nil && ctx.Err() == nil {
+		// Only the attempt timed out, the evaluation may still continue with the next attempt.
+		outcome.err = fmt.Errorf("%w after %v", ErrAttemptTimeout, check.retry.attemptTimeout)
+	}
+
+	return outcome
+}
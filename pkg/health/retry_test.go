@@ -0,0 +1,216 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+var (
+	errTransient = errors.New("connection reset")
+	errAuth      = errors.New("authentication failed")
+)
+
+// failingCheck returns a check that fails with err for the first failures calls and succeeds afterwards.
+func failingCheck(name string, failures int32, err error, calls *atomic.Int32) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			if calls.Add(1) <= failures {
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func TestRetry(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(failingCheck("check", 2, errTransient, &calls), health.WithRetry(3, time.Millisecond)),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(failingCheck("check", 10, errTransient, &calls), health.WithRetry(3, time.Millisecond)),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, res.Status)
+	require.ErrorIs(t, res.Details["check"].Error, errTransient)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryIf(t *testing.T) {
+	isTransient := func(err error) bool { return errors.Is(err, errTransient) }
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedCalls  int32
+		expectedStatus health.AvailabilityStatus
+	}{
+		{
+			name:           "RetryableErrorIsRetried",
+			err:            errTransient,
+			expectedCalls:  2,
+			expectedStatus: health.StatusUp,
+		},
+		{
+			name:           "NonRetryableErrorFailsImmediately",
+			err:            errAuth,
+			expectedCalls:  1,
+			expectedStatus: health.StatusDown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var calls atomic.Int32
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithCheck(failingCheck("check", 1, tc.err, &calls),
+					health.WithRetry(3, time.Millisecond),
+					health.WithRetryIf(isTransient),
+				),
+			)
+
+			// Act
+			res := ckr.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestRetryAdheresToTimeout(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:    "check",
+			Timeout: 50 * time.Millisecond,
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				return errTransient
+			},
+		}, health.WithRetry(100, 20*time.Millisecond)),
+	)
+
+	// Act
+	start := time.Now()
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusDown, res.Status)
+	assert.Less(t, calls.Load(), int32(5))
+}
+
+func TestPerAttemptTimeout(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:    "check",
+			Timeout: 5 * time.Second,
+			Check: func(ctx context.Context) error {
+				if calls.Add(1) < 3 {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return nil
+			},
+		}, health.WithRetry(3, 0), health.WithPerAttemptTimeout(20*time.Millisecond)),
+	)
+
+	// Act
+	start := time.Now()
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestPerAttemptTimeoutIsCappedByCheckTimeout(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:    "check",
+			Timeout: 100 * time.Millisecond,
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}, health.WithRetry(100, 0), health.WithPerAttemptTimeout(40*time.Millisecond)),
+	)
+
+	// Act
+	start := time.Now()
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusDown, res.Status)
+	assert.Equal(t, health.ReasonTimeout, res.Details["check"].Reason)
+	assert.LessOrEqual(t, calls.Load(), int32(3))
+}
+
+func TestPerAttemptTimeoutReportsAttemptTimeout(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:    "check",
+			Timeout: 5 * time.Second,
+			Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}, health.WithRetry(2, 0), health.WithPerAttemptTimeout(10*time.Millisecond)),
+	)
+
+	// Act
+	result, err := ckr.(health.CheckDebugger).DebugCheck(t.Context(), "check")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Attempts, 2)
+	for _, attempt := range result.Attempts {
+		assert.Equal(t, health.ReasonTimeout, attempt.Reason)
+		assert.Contains(t, attempt.Error, health.ErrAttemptTimeout.Error())
+	}
+}
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// SelfCheckName is the name of the check that is registered by WithSelfCheck.
+const SelfCheckName = "health-checker"
+
+// SelfCheck creates a check that reports the health of the Checker itself based on its statistics
+// (see StatsProvider.Stats). The check fails if the evaluations of periodic checks are stale, e.g., because
+// an evaluation is stuck (see SchedulerStats.StaleChecks and ReasonSchedulerStalled). It is degraded if
+// check evaluations are queuing in the worker pool (see WithWorkerPool) or if states were dropped by a
+// ticker since the previous evaluation of the check (see StateTicker.Ticker and ReasonQueueOverflow).
+// Use WithSelfCheck to register the check for the Checker itself. If the checker does not implement
+// StatsProvider, the check always fails with ErrSelfCheckUnsupported.
+func SelfCheck(checker Checker) Check {
+	provider, _ := checker.(StatsProvider)
+	return newSelfCheck(provider)
+}
+
+// selfCheckTarget provides the statistics of the Checker for the self-check that is registered by WithSelfCheck.
+// The self-check is registered while the configuration is validated, i.e., before the Checker is created.
+type selfCheckTarget struct {
+	checker atomic.Pointer[defaultChecker]
+}
+
+// Stats implements StatsProvider.Stats.
+func (t *selfCheckTarget) Stats() Stats {
+	return t.checker.Load().Stats()
+}
+
+// registerSelfCheck registers the self-check (see WithSelfCheck). It returns ErrReservedCheckName if there is
+// already a check named SelfCheckName.
+func (cfg *checkerConfig) registerSelfCheck() error {
+	if _, ok := cfg.checks[SelfCheckName]; ok {
+		return fmt.Errorf("%w: %s", ErrReservedCheckName, SelfCheckName)
+	}
+
+	cfg.selfCheckTarget = &selfCheckTarget{}
+	check := newSelfCheck(cfg.selfCheckTarget)
+	cfg.checks[check.Name] = &check
+
+	return nil
+}
+
+func newSelfCheck(provider StatsProvider) Check {
+	var droppedTickerStates atomic.Uint64
+
+	return Check{
+		Name: SelfCheckName,
+		Check: func(context.Context) error {
+			if provider == nil {
+				return ErrSelfCheckUnsupported
+			}
+
+			stats := provider.Stats()
+
+			if stale := stats.Scheduler.StaleChecks; len(stale) > 0 {
+				return ErrorWithReason(ReasonSchedulerStalled,
+					fmt.Errorf("periodic checks not evaluated recently: %s", strings.Join(stale, ", ")))
+			}
+
+			if pool := stats.WorkerPool; pool != nil && pool.QueuedEvaluations > 0 {
+				return ErrorWithReason(ReasonQueueOverflow,
+					fmt.Errorf("%d check evaluations waiting for a worker: %w", pool.QueuedEvaluations, ErrDegraded))
+			}
+
+			previous := droppedTickerStates.Swap(stats.DroppedTickerStates)
+			if dropped := stats.DroppedTickerStates - previous; dropped > 0 {
+				return ErrorWithReason(ReasonQueueOverflow,
+					fmt.Errorf("%d ticker states dropped: %w", dropped, ErrDegraded))
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,196 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestSelfCheckReportsStalledScheduler(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+
+	ckr := health.NewChecker(
+		health.WithDisabledCache(),
+		health.WithSelfCheck(),
+		health.WithPeriodicCheck(10*time.Millisecond, 0, health.Check{
+			Name: "stuck",
+			Check: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+		}),
+	)
+	defer ckr.Stop()
+	defer close(release)
+
+	// Act & Assert
+	require.Eventually(t, func() bool {
+		return ckr.Check(t.Context()).Details[health.SelfCheckName].Status == health.StatusDown
+	}, time.Second, 5*time.Millisecond)
+
+	result := ckr.Check(t.Context()).Details[health.SelfCheckName]
+	assert.Equal(t, health.ReasonSchedulerStalled, result.Reason)
+	assert.ErrorContains(t, result.Error, "stuck")
+	assert.Equal(t, []string{"stuck"}, ckr.(health.StatsProvider).Stats().Scheduler.StaleChecks)
+}
+
+func TestSelfCheckWithHealthyScheduler(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledCache(),
+		health.WithSelfCheck(),
+		health.WithPeriodicCheck(10*time.Millisecond, 0, health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+	defer ckr.Stop()
+
+	// Act
+	time.Sleep(50 * time.Millisecond)
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Details[health.SelfCheckName].Status)
+	assert.Empty(t, ckr.(health.StatsProvider).Stats().Scheduler.StaleChecks)
+}
+
+func TestSelfCheckIgnoresPausedChecks(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledCache(),
+		health.WithSelfCheck(),
+		health.WithPeriodicCheck(10*time.Millisecond, 0, health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+	defer ckr.Stop()
+
+	require.NoError(t, ckr.(health.CheckPauser).PauseCheck("database"))
+
+	// Act
+	time.Sleep(50 * time.Millisecond)
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Details[health.SelfCheckName].Status)
+}
+
+func TestSelfCheckIgnoresChecksThatAreNotScheduled(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []health.Option
+		check   func(ctx context.Context) error
+	}{
+		{
+			name:    "DisabledByFlagProvider",
+			options: []health.Option{health.WithFlagProvider(&fakeFlagProvider{disabled: map[string]bool{"database": true}})},
+			check:   func(ctx context.Context) error { return nil },
+		},
+		{
+			name:    "AssignedToOtherPartition",
+			options: []health.Option{health.WithCheckPartition(2, 1-health.CheckPartition("database", 2))},
+			check:   func(ctx context.Context) error { return nil },
+		},
+		{
+			name:    "Quarantined",
+			options: []health.Option{health.WithPanicQuarantine(1)},
+			check:   func(ctx context.Context) error { panic("boom") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			options := append([]health.Option{
+				health.WithDisabledCache(),
+				health.WithSelfCheck(),
+				health.WithPeriodicCheck(10*time.Millisecond, 0, health.Check{Name: "database", Check: tt.check}),
+			}, tt.options...)
+			ckr := health.NewChecker(options...)
+			defer ckr.Stop()
+
+			// Act
+			time.Sleep(80 * time.Millisecond)
+			result := ckr.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, health.StatusUp, result.Details[health.SelfCheckName].Status)
+			assert.Empty(t, ckr.(health.StatsProvider).Stats().Scheduler.StaleChecks)
+		})
+	}
+}
+
+func TestSelfCheckReportsDroppedTickerStates(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithSelfCheck(),
+	)
+
+	_, stop := ckr.(health.StateTicker).Ticker(time.Millisecond)
+	require.Eventually(t, func() bool {
+		return ckr.(health.StatsProvider).Stats().DroppedTickerStates > 0
+	}, time.Second, time.Millisecond)
+	stop()
+
+	// Act
+	overflowing := ckr.Check(t.Context()).Details[health.SelfCheckName]
+	time.Sleep(time.Millisecond)
+	recovered := ckr.Check(t.Context()).Details[health.SelfCheckName]
+
+	// Assert
+	assert.Equal(t, health.StatusDegraded, overflowing.Status)
+	assert.Equal(t, health.ReasonQueueOverflow, overflowing.Reason)
+	assert.Equal(t, health.StatusUp, recovered.Status)
+}
+
+func TestSelfCheckWithoutStatsProvider(t *testing.T) {
+	// Arrange
+	check := health.SelfCheck(&checkerMock{})
+
+	// Act
+	err := check.Check(t.Context())
+
+	// Assert
+	assert.ErrorIs(t, err, health.ErrSelfCheckUnsupported)
+}
+
+func TestSelfCheckRejectsReservedName(t *testing.T) {
+	// Act
+	ckr, err := health.BuildChecker(
+		health.WithDisabledAutostart(),
+		health.WithSelfCheck(),
+		health.WithCheck(statusCheck(health.SelfCheckName, health.StatusUp)),
+	)
+
+	// Assert
+	require.ErrorIs(t, err, health.ErrReservedCheckName)
+	assert.Nil(t, ckr)
+}
+
+func TestSelfCheckInReadinessExpression(t *testing.T) {
+	// Arrange
+	ckr, err := health.BuildChecker(
+		health.WithDisabledAutostart(),
+		health.WithSelfCheck(),
+		health.WithReadinessExpression(`db AND "health-checker"`),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+	)
+	require.NoError(t, err)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, health.StatusUp, result.Details[health.SelfCheckName].Status)
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// UnserializableValue replaces the values of info entries that cannot be serialized (see JSONResultWriter).
+const UnserializableValue = "[UNSERIALIZABLE]"
+
+// SerializationFailure describes a Result that could not be serialized as a whole (see
+// JSONResultWriter.LastSerializationFailure).
+type SerializationFailure struct {
+	// Time holds the time (in UTC) of when the serialization failed.
+	Time time.Time
+	// Err is the error of the serialization of the complete Result.
+	Err error
+	// Values holds the Go representations (see fmt's %#v verb) of the info values that could not be serialized
+	// by their keys (see WithInfo). These values were replaced with UnserializableValue in the response.
+	Values map[string]string
+}
+
+// marshalResult marshals the result into JSON. If the result cannot be marshalled, e.g., because an info value
+// holds a channel, each offending info value is replaced with UnserializableValue, so that the rest of the result
+// is still returned, and the failure is returned as well. If the result still cannot be marshalled, an error is
+// returned.
+func marshalResult(result *Result) ([]byte, *SerializationFailure, error) {
+	data, err := json.Marshal(result)
+	if err == nil {
+		return data, nil, nil
+	}
+
+	failure := &SerializationFailure{Time: time.Now().UTC(), Err: err, Values: map[string]string{}}
+
+	// The info map is shared by all results, so it is copied before offending values are replaced.
+	sanitized := *result
+	sanitized.Info = maps.Clone(result.Info)
+
+	for key, value := range result.Info {
+		if _, err := json.Marshal(value); err != nil {
+			failure.Values[key] = fmt.Sprintf("%#v", value)
+			sanitized.Info[key] = UnserializableValue
+		}
+	}
+
+	data, err = json.Marshal(&sanitized)
+	if err != nil {
+		return nil, failure, err
+	}
+
+	return data, failure, nil
+}
@@ -0,0 +1,77 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestJSONResultWriterReplacesUnserializableValues(t *testing.T) {
+	// Arrange
+	events := make(chan int)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInfo(map[string]interface{}{"version": "1.0"}),
+		health.WithInfoFunc(func(info map[string]interface{}) {
+			info["events"] = events
+			info["ratio"] = math.NaN()
+		}),
+		health.WithCheck(health.Check{Name: "db", Check: func(ctx context.Context) error { return nil }}),
+	)
+	writer := health.NewJSONResultWriter()
+	handler := health.NewHandler(ckr, health.WithResultWriter(writer))
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &payload))
+	assert.Equal(t, "up", payload["status"])
+	assert.Equal(t, map[string]any{
+		"version": "1.0",
+		"events":  health.UnserializableValue,
+		"ratio":   health.UnserializableValue,
+	}, payload["info"])
+	assert.Contains(t, payload["details"], "db")
+
+	failure := writer.LastSerializationFailure()
+	require.NotNil(t, failure)
+	require.Error(t, failure.Err)
+	assert.False(t, failure.Time.IsZero())
+	assert.Contains(t, failure.Values, "events")
+	assert.Equal(t, "NaN", failure.Values["ratio"])
+
+	result := ckr.Check(t.Context())
+	assert.Equal(t, events, result.Info["events"], "the info values of the checker must not be modified")
+}
+
+func TestJSONResultWriterWithoutSerializationFailure(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInfo(map[string]interface{}{"version": "1.0"}),
+	)
+	writer := health.NewJSONResultWriter()
+	handler := health.NewHandler(ckr, health.WithResultWriter(writer))
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"status":"up","info":{"version":"1.0"}}`, response.Body.String())
+	assert.Nil(t, writer.LastSerializationFailure())
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// SignatureHeader is the HTTP header that holds the signature of a health response (see WithResponseSigner).
+const SignatureHeader = "X-Health-Signature"
+
+const signatureAlgorithmPrefix = "sha256="
+
+// ErrInvalidSignature is returned if the signature of a health response does not match its body.
+var ErrInvalidSignature = errors.New("invalid health response signature")
+
+// signingResponseWriter buffers the response, so that the signature of the complete
+// body can be added as a header before the response is written.
+type signingResponseWriter struct {
+	http.ResponseWriter
+
+	key        []byte
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *signingResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *signingResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *signingResponseWriter) flush() error {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	w.Header().Set(SignatureHeader, SignResponseBody(w.key, w.body.Bytes()))
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	_, err := w.ResponseWriter.Write(w.body.Bytes())
+
+	return err
+}
+
+// SignResponseBody returns the signature of a health response body as it is set in the SignatureHeader
+// (see WithResponseSigner). The signature is an HMAC-SHA256 of the body in the format "sha256=<hex>".
+func SignResponseBody(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(body)
+
+	return signatureAlgorithmPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyResponseSignature verifies the signature of a health response body (see WithResponseSigner).
+// The signature is usually taken from the SignatureHeader of the response. It returns ErrInvalidSignature
+// if the signature is missing, malformed or does not match the body.
+func VerifyResponseSignature(key, body []byte, signature string) error {
+	encoded, ok := strings.CutPrefix(signature, signatureAlgorithmPrefix)
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(body)
+
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package health_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestResponseSigner(t *testing.T) {
+	// Arrange
+	key := []byte("shared-secret")
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:  "check",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+	handler := health.NewHandler(ckr, health.WithResponseSigner(key))
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	// Act
+	handler.ServeHTTP(response, request)
+
+	// Assert
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Contains(t, response.Body.String(), `"status":"up"`)
+
+	signature := response.Header().Get(health.SignatureHeader)
+	require.NotEmpty(t, signature)
+	require.NoError(t, health.VerifyResponseSignature(key, response.Body.Bytes(), signature))
+}
+
+func TestVerifyResponseSignature(t *testing.T) {
+	key := []byte("shared-secret")
+	body := []byte(`{"status":"up"}`)
+
+	tests := []struct {
+		name      string
+		key       []byte
+		body      []byte
+		signature string
+		valid     bool
+	}{
+		{
+			name:      "ValidSignature",
+			key:       key,
+			body:      body,
+			signature: health.SignResponseBody(key, body),
+			valid:     true,
+		},
+		{
+			name:      "TamperedBody",
+			key:       key,
+			body:      []byte(`{"status":"down"}`),
+			signature: health.SignResponseBody(key, body),
+		},
+		{
+			name:      "WrongKey",
+			key:       []byte("other-secret"),
+			body:      body,
+			signature: health.SignResponseBody(key, body),
+		},
+		{
+			name:      "MissingSignature",
+			key:       key,
+			body:      body,
+			signature: "",
+		},
+		{
+			name:      "MalformedSignature",
+			key:       key,
+			body:      body,
+			signature: "sha256=not-hex",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := health.VerifyResponseSignature(tc.key, tc.body, tc.signature)
+
+			// Assert
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, health.ErrInvalidSignature)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// statePublisher delivers the State to the transition publishers (e.g., WithMQTTPublisher) in a background
+	// goroutine, so that a slow broker or API server does not block the Checker. States are delivered in the
+	// order of the status changes.
+	statePublisher struct {
+		publishers []func(context.Context, State)
+		mtx        sync.Mutex
+		pending    []publication
+		publishing bool
+		published  chan struct{}
+	}
+
+	publication struct {
+		ctx   context.Context
+		state State
+	}
+)
+
+func newStatePublisher(publishers []func(context.Context, State)) *statePublisher {
+	if len(publishers) == 0 {
+		return nil
+	}
+
+	return &statePublisher{publishers: publishers}
+}
+
+// publish queues a copy of the state for the transition publishers. It may be called while holding the lock
+// of the Checker.
+func (p *statePublisher) publish(ctx context.Context, state State) {
+	if p == nil {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// The publication may outlive the originating call, so only the values of its context are retained.
+	p.pending = append(p.pending, publication{ctx: context.WithoutCancel(ctx), state: copyState(state)})
+
+	if !p.publishing {
+		p.publishing = true
+		p.published = make(chan struct{})
+
+		go p.run()
+	}
+}
+
+func (p *statePublisher) run() {
+	for {
+		p.mtx.Lock()
+		if len(p.pending) == 0 {
+			p.publishing = false
+			close(p.published)
+			p.mtx.Unlock()
+
+			return
+		}
+
+		next := p.pending[0]
+		p.pending = p.pending[1:]
+		p.mtx.Unlock()
+
+		for _, publish := range p.publishers {
+			publish(next.ctx, next.state)
+		}
+	}
+}
+
+// wait waits until all queued states are published.
+func (p *statePublisher) wait() {
+	if p == nil {
+		return
+	}
+
+	p.mtx.Lock()
+	published := p.published
+	p.mtx.Unlock()
+
+	if published != nil {
+		<-published
+	}
+}
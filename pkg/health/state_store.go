@@ -0,0 +1,288 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	slogctx "github.com/veqryn/slog-context"
+)
+
+type (
+	// StateStore persists the states of checks across restarts of the application (see WithStateStore).
+	StateStore interface {
+		// Load returns the last saved states of the checks by their names. It returns an empty map
+		// if no states were saved yet.
+		Load(ctx context.Context) (map[string]CheckState, error)
+		// Save replaces the saved states with the given states of the checks by their names.
+		Save(ctx context.Context, states map[string]CheckState) error
+	}
+
+	// FileStateStore is a StateStore that saves the check states to a JSON file (see NewFileStateStore).
+	// Check errors are saved as their messages, so a restored CheckState.Result only retains the message
+	// (i.e., errors.Is no longer matches the original error chain).
+	FileStateStore struct {
+		path string
+	}
+
+	// stateSaver saves the check states to the StateStore in the background (see WithStateStore). States that
+	// are queued while a save is in progress are coalesced, so that only the latest states are saved next.
+	stateSaver struct {
+		store      StateStore
+		mtx        sync.Mutex
+		saving     bool
+		saved      chan struct{}
+		pendingCtx context.Context
+		pending    map[string]CheckState
+	}
+
+	persistedCheckState struct {
+		Status              AvailabilityStatus     `json:"status"`
+		Error               string                 `json:"error,omitempty"`
+		Reason              string                 `json:"reason,omitempty"`
+		LastCheckedAt       time.Time              `json:"lastCheckedAt,omitzero"`
+		LastSuccessAt       time.Time              `json:"lastSuccessAt,omitzero"`
+		LastFailureAt       time.Time              `json:"lastFailureAt,omitzero"`
+		FirstCheckStartedAt time.Time              `json:"firstCheckStartedAt,omitzero"`
+		LastStatusChangeAt  time.Time              `json:"lastStatusChangeAt,omitzero"`
+		StartedAt           time.Time              `json:"startedAt,omitzero"`
+		FinishedAt          time.Time              `json:"finishedAt,omitzero"`
+		ContiguousFails     uint                   `json:"contiguousFails,omitempty"`
+		Evaluations         uint                   `json:"evaluations,omitempty"`
+		Deviations          uint                   `json:"deviations,omitempty"`
+		SubResults          map[string]CheckResult `json:"details,omitempty"`
+	}
+)
+
+// WithStateStore restores the last known states of the checks from the given StateStore when the Checker is
+// started (see Checker.Start), so that long-interval checks (e.g., hourly periodic checks) report their prior
+// state after a restart instead of being unknown until their next evaluation. Only checks that were not yet
+// evaluated are restored and unknown check names are ignored. The aggregated status (and the times that depend on
+// it, see State) is recomputed from the restored states. The states are saved in the background whenever the
+// status of a check changes, and once more when the Checker is stopped (after the pending saves). If the store is
+// slow, intermediate states are skipped, so that only the latest states are saved. If the states cannot be
+// loaded, the checks start as unknown. States that cannot be saved are logged and saved again with the next
+// status change. NewFileStateStore provides a StateStore that saves the states to a file.
+func WithStateStore(store StateStore) Option {
+	return func(cfg *checkerConfig) {
+		cfg.stateStore = store
+	}
+}
+
+// NewFileStateStore creates a FileStateStore that saves the check states to the file with the given path.
+// The file is replaced atomically, so a crash while saving does not corrupt previously saved states.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load implements StateStore.Load. It returns an empty map if the file does not exist.
+func (s *FileStateStore) Load(_ context.Context) (map[string]CheckState, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]CheckState{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("cannot read check states: %w", err)
+	}
+
+	var persisted map[string]persistedCheckState
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal check states: %w", err)
+	}
+
+	states := make(map[string]CheckState, len(persisted))
+	for name, state := range persisted {
+		states[name] = state.checkState()
+	}
+
+	return states, nil
+}
+
+// Save implements StateStore.Save.
+func (s *FileStateStore) Save(_ context.Context, states map[string]CheckState) error {
+	persisted := make(map[string]persistedCheckState, len(states))
+	for name, state := range states {
+		persisted[name] = newPersistedCheckState(state)
+	}
+
+	data, err := json.Marshal(persisted)
+	if err != nil {
+		return fmt.Errorf("cannot marshal check states: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create check states file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cannot write check states: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write check states: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("cannot replace check states file: %w", err)
+	}
+
+	return nil
+}
+
+func newPersistedCheckState(state CheckState) persistedCheckState {
+	return persistedCheckState{
+		Status:              state.Status,
+		Error:               errorMessage(state.Result),
+		Reason:              state.Reason,
+		LastCheckedAt:       state.LastCheckedAt,
+		LastSuccessAt:       state.LastSuccessAt,
+		LastFailureAt:       state.LastFailureAt,
+		FirstCheckStartedAt: state.FirstCheckStartedAt,
+		LastStatusChangeAt:  state.LastStatusChangeAt,
+		StartedAt:           state.StartedAt,
+		FinishedAt:          state.FinishedAt,
+		ContiguousFails:     state.ContiguousFails,
+		Evaluations:         state.Evaluations,
+		Deviations:          state.Deviations,
+		SubResults:          state.SubResults,
+	}
+}
+
+func (s persistedCheckState) checkState() CheckState {
+	state := CheckState{
+		Status:              s.Status,
+		Reason:              s.Reason,
+		LastCheckedAt:       s.LastCheckedAt,
+		LastSuccessAt:       s.LastSuccessAt,
+		LastFailureAt:       s.LastFailureAt,
+		FirstCheckStartedAt: s.FirstCheckStartedAt,
+		LastStatusChangeAt:  s.LastStatusChangeAt,
+		StartedAt:           s.StartedAt,
+		FinishedAt:          s.FinishedAt,
+		ContiguousFails:     s.ContiguousFails,
+		Evaluations:         s.Evaluations,
+		Deviations:          s.Deviations,
+		SubResults:          s.SubResults,
+	}
+
+	if s.Error != "" {
+		state.Result = errors.New(s.Error)
+	}
+
+	return state
+}
+
+// restoreState restores the states of all checks that were not evaluated yet from the StateStore.
+// It must be called while holding the lock of the Checker.
+func (ck *defaultChecker) restoreState(ctx context.Context) {
+	if ck.cfg.stateStore == nil {
+		return
+	}
+
+	states, err := ck.cfg.stateStore.Load(ctx)
+	if err != nil {
+		slogctx.Error(ctx, "Failed to load health check states", "error", err)
+		return
+	}
+
+	restored := false
+	for name, state := range states {
+		current, ok := ck.state.CheckState[name]
+		if !ok || !current.LastCheckedAt.IsZero() {
+			continue
+		}
+
+		ck.state.CheckState[name] = state
+		restored = true
+	}
+
+	if !restored {
+		return
+	}
+
+	ck.aggregate(ck.cfg.clock.Now().UTC())
+	ck.publishSnapshot()
+}
+
+// saveState queues the states of all checks to be saved to the StateStore. It must be called while holding
+// the lock of the Checker.
+func (ck *defaultChecker) saveState(ctx context.Context) {
+	if ck.stateSaver != nil {
+		ck.stateSaver.queue(ctx, maps.Clone(ck.state.CheckState))
+	}
+}
+
+func newStateSaver(store StateStore) *stateSaver {
+	if store == nil {
+		return nil
+	}
+
+	return &stateSaver{store: store}
+}
+
+// queue saves the states in the background. If a save is in progress, the states replace the states that are
+// queued for the next save.
+func (s *stateSaver) queue(ctx context.Context, states map[string]CheckState) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// The save may outlive the originating call, so only the values of its context are retained.
+	s.pendingCtx = context.WithoutCancel(ctx)
+	s.pending = states
+
+	if !s.saving {
+		s.saving = true
+		s.saved = make(chan struct{})
+
+		go s.run()
+	}
+}
+
+func (s *stateSaver) run() {
+	for {
+		s.mtx.Lock()
+		ctx, states := s.pendingCtx, s.pending
+		s.pendingCtx, s.pending = nil, nil
+		if states == nil {
+			s.saving = false
+			close(s.saved)
+			s.mtx.Unlock()
+
+			return
+		}
+		s.mtx.Unlock()
+
+		s.save(ctx, states)
+	}
+}
+
+// flush waits for the queued states to be saved and then saves the given states, so that they are saved last.
+func (s *stateSaver) flush(ctx context.Context, states map[string]CheckState) {
+	if s == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	saved := s.saved
+	s.mtx.Unlock()
+
+	if saved != nil {
+		<-saved
+	}
+
+	s.save(ctx, states)
+}
+
+func (s *stateSaver) save(ctx context.Context, states map[string]CheckState) {
+	if err := s.store.Save(ctx, states); err != nil {
+		slogctx.Error(ctx, "Failed to save health check states", "error", err)
+	}
+}
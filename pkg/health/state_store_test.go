@@ -0,0 +1,213 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type failingStateStore struct {
+	saves atomic.Int32
+}
+
+func (s *failingStateStore) Load(context.Context) (map[string]health.CheckState, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (s *failingStateStore) Save(context.Context, map[string]health.CheckState) error {
+	s.saves.Add(1)
+	return errors.New("unavailable")
+}
+
+func TestStateStoreRestoresStateAfterRestart(t *testing.T) {
+	// Arrange
+	store := health.NewFileStateStore(filepath.Join(t.TempDir(), "health.json"))
+
+	var fail atomic.Bool
+	fail.Store(true)
+	first := health.NewChecker(
+		health.WithStateStore(store),
+		health.WithPeriodicCheck(time.Hour, 0, toggledCheck("hourly", &fail)),
+	)
+	require.Eventually(t, func() bool {
+		state, _ := first.(health.StateReader).LastCheckState("hourly")
+		return state.Status == health.StatusDown
+	}, time.Second, time.Millisecond)
+	saved, _ := first.(health.StateReader).LastCheckState("hourly")
+	first.Stop()
+
+	// Act
+	fail.Store(false)
+	restarted := health.NewChecker(
+		health.WithStateStore(store),
+		// The initial delay prevents that the restored state is replaced by a new evaluation.
+		health.WithPeriodicCheck(time.Hour, time.Hour, toggledCheck("hourly", &fail)),
+	)
+	defer restarted.Stop()
+
+	// Assert
+	state, ok := restarted.(health.StateReader).LastCheckState("hourly")
+	require.True(t, ok)
+	assert.Equal(t, health.StatusDown, state.Status)
+	require.Error(t, state.Result)
+	assert.Equal(t, "unavailable", state.Result.Error())
+	assert.Equal(t, health.ReasonError, state.Reason)
+	assert.Equal(t, saved.ContiguousFails, state.ContiguousFails)
+	assert.True(t, saved.LastCheckedAt.Equal(state.LastCheckedAt))
+	assert.Equal(t, health.StatusDown, restarted.(health.StateReader).State().Status)
+	assert.NotEmpty(t, restarted.(health.StateReader).State().IncidentID)
+	assert.False(t, restarted.(health.StateReader).State().LastStatusChangeAt.IsZero())
+	assert.True(t, restarted.(health.StateReader).State().UpSince.IsZero())
+}
+
+func TestStateStoreSavesOnTransition(t *testing.T) {
+	// Arrange
+	store := health.NewFileStateStore(filepath.Join(t.TempDir(), "health.json"))
+
+	var fail atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithStateStore(store),
+		health.WithCheck(toggledCheck("db", &fail)),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+	fail.Store(true)
+	ckr.Check(t.Context())
+
+	// Assert
+	// The states are saved in the background.
+	require.Eventually(t, func() bool {
+		states, err := store.Load(t.Context())
+		return err == nil && states["db"].Status == health.StatusDown
+	}, time.Second, time.Millisecond)
+
+	states, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), states["db"].ContiguousFails)
+}
+
+// blockingStateStore blocks each save until it is released and records the saved states.
+type blockingStateStore struct {
+	release chan struct{}
+	saved   chan map[string]health.CheckState
+}
+
+func (s *blockingStateStore) Load(context.Context) (map[string]health.CheckState, error) {
+	return map[string]health.CheckState{}, nil
+}
+
+func (s *blockingStateStore) Save(_ context.Context, states map[string]health.CheckState) error {
+	<-s.release
+	s.saved <- states
+
+	return nil
+}
+
+func TestStateStoreSavesInBackground(t *testing.T) {
+	// Arrange
+	store := &blockingStateStore{release: make(chan struct{}), saved: make(chan map[string]health.CheckState, 10)}
+
+	var fail atomic.Bool
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithStateStore(store),
+		health.WithCheck(toggledCheck("db", &fail)),
+	)
+
+	// Act
+	// The checks are not blocked by the slow store, and the intermediate states are skipped.
+	for i := range 4 {
+		fail.Store(i%2 == 0)
+		ckr.Check(t.Context())
+	}
+	close(store.release)
+
+	// Assert
+	var saved []map[string]health.CheckState
+	require.Eventually(t, func() bool {
+		select {
+		case states := <-store.saved:
+			saved = append(saved, states)
+			return states["db"].Status == health.StatusUp
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	assert.LessOrEqual(t, len(saved), 2)
+}
+
+func TestFileStateStoreLoadWithoutFile(t *testing.T) {
+	// Arrange
+	store := health.NewFileStateStore(filepath.Join(t.TempDir(), "missing.json"))
+
+	// Act
+	states, err := store.Load(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, states)
+}
+
+func TestFileStateStoreRoundTrip(t *testing.T) {
+	// Arrange
+	store := health.NewFileStateStore(filepath.Join(t.TempDir(), "health.json"))
+	checkedAt := time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC)
+	states := map[string]health.CheckState{
+		"db": {
+			Status:          health.StatusDegraded,
+			Result:          errors.New("slow"),
+			Reason:          health.ReasonDegraded,
+			LastCheckedAt:   checkedAt,
+			LastFailureAt:   checkedAt,
+			ContiguousFails: 2,
+			SubResults: map[string]health.CheckResult{
+				"replica": {Status: health.StatusDown, Timestamp: checkedAt, Error: errors.New("lagging")},
+			},
+		},
+	}
+
+	// Act
+	require.NoError(t, store.Save(t.Context(), states))
+	loaded, err := store.Load(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Contains(t, loaded, "db")
+	assert.Equal(t, health.StatusDegraded, loaded["db"].Status)
+	assert.EqualError(t, loaded["db"].Result, "slow")
+	assert.Equal(t, health.ReasonDegraded, loaded["db"].Reason)
+	assert.Equal(t, checkedAt, loaded["db"].LastCheckedAt)
+	assert.Equal(t, uint(2), loaded["db"].ContiguousFails)
+	assert.Equal(t, health.StatusDown, loaded["db"].SubResults["replica"].Status)
+	assert.EqualError(t, loaded["db"].SubResults["replica"].Error, "lagging")
+}
+
+func TestStateStoreFailureDoesNotAffectChecker(t *testing.T) {
+	// Arrange
+	store := &failingStateStore{}
+	ckr := health.NewChecker(
+		health.WithStateStore(store),
+		health.WithCheck(statusCheck("db", health.StatusUp)),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+	ckr.Stop()
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Positive(t, store.saves.Load())
+}
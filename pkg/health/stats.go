@@ -0,0 +1,146 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// staleEvaluationIntervals is the number of update intervals after which a periodic check whose evaluation
+// did not complete is considered stale (see SchedulerStats.StaleChecks).
+const staleEvaluationIntervals = 3
+
+type (
+	// Stats holds internal statistics of the Checker (see StatsProvider.Stats).
+	Stats struct {
+		// WorkerPool holds the statistics of the shared worker pool (see WithWorkerPool).
+		// It is nil if no worker pool is configured.
+		WorkerPool *WorkerPoolStats `json:"workerPool,omitempty"`
+		// Scheduler holds the statistics of the scheduler of the periodic checks.
+		Scheduler SchedulerStats `json:"scheduler"`
+		// DroppedTickerStates is the number of states that were dropped by all tickers, because
+		// their receivers did not consume the previous state in time (see StateTicker.Ticker).
+		DroppedTickerStates uint64 `json:"droppedTickerStates"`
+		// Cache holds the cache statistics of all synchronous checks by their names (see WithCheck). Each
+		// evaluation of Checker.Check counts either a hit or a miss for each enabled synchronous check.
+		Cache map[string]CacheStats `json:"cache,omitempty"`
+		// TimeToReady is the time it took after the start of the Checker until the aggregated status was StatusUp
+		// for the first time (see WithOnReady). It is 0 if the Checker was not ready yet.
+		TimeToReady time.Duration `json:"-"`
+	}
+
+	// SchedulerStats holds the statistics of the scheduler of the periodic checks.
+	SchedulerStats struct {
+		// StaleChecks holds the names of all periodic checks (sorted by name) whose last evaluation completed
+		// more than three update intervals (plus the timeout of the check) ago, e.g., because the evaluation
+		// is stuck. Checks that are paused, quarantined, disabled or assigned to another partition are never
+		// stale. It is empty while the Checker is not started.
+		StaleChecks []string `json:"staleChecks,omitempty"`
+	}
+)
+
+// Stats implements StatsProvider.Stats. Please refer to StatsProvider.Stats for more information.
+func (ck *defaultChecker) Stats() Stats {
+	return Stats{
+		WorkerPool:          ck.workers.stats(),
+		Scheduler:           SchedulerStats{StaleChecks: ck.staleChecks()},
+		DroppedTickerStates: ck.droppedTickerStates.Load(),
+		Cache:               ck.cacheStats(),
+		TimeToReady:         ck.loadTimeToReady(),
+	}
+}
+
+// MarshalJSON provides a custom marshaller for the Stats type that represents the time to ready as a string.
+func (s Stats) MarshalJSON() ([]byte, error) {
+	type alias Stats
+
+	timeToReady := ""
+	if s.TimeToReady > 0 {
+		timeToReady = s.TimeToReady.String()
+	}
+
+	return json.Marshal(struct {
+		alias
+		TimeToReady string `json:"timeToReady,omitempty"`
+	}{alias: alias(s), TimeToReady: timeToReady})
+}
+
+// UnmarshalJSON provides a custom unmarshaller for the Stats type.
+func (s *Stats) UnmarshalJSON(data []byte) error {
+	type alias Stats
+
+	var stats struct {
+		alias
+		TimeToReady string `json:"timeToReady,omitempty"`
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return err
+	}
+
+	*s = Stats(stats.alias)
+
+	if stats.TimeToReady != "" {
+		timeToReady, err := time.ParseDuration(stats.TimeToReady)
+		if err != nil {
+			return fmt.Errorf("invalid time to ready: %w", err)
+		}
+
+		s.TimeToReady = timeToReady
+	}
+
+	return nil
+}
+
+func (ck *defaultChecker) loadTimeToReady() time.Duration {
+	if timeToReady := ck.timeToReady.Load(); timeToReady != nil {
+		return *timeToReady
+	}
+
+	return 0
+}
+
+// staleChecks returns the names of all stale periodic checks (see SchedulerStats.StaleChecks). It reads
+// the check states from the snapshot, so it does not acquire the lock of the Checker.
+func (ck *defaultChecker) staleChecks() []string {
+	startedAt := ck.startedAt.Load()
+	if startedAt == nil {
+		return nil
+	}
+
+	var (
+		now    = ck.cfg.clock.Now()
+		states = ck.snapshot.Load().CheckState
+		stale  []string
+	)
+
+	for _, check := range ck.cfg.checks {
+		if !isPeriodicCheck(check) || !ck.isScheduled(check) {
+			continue
+		}
+
+		lastEvaluatedAt := states[check.Name].LastCheckedAt
+		if lastEvaluatedAt.IsZero() {
+			lastEvaluatedAt = startedAt.Add(check.initialDelay)
+		}
+
+		maxAge := staleEvaluationIntervals*check.updateInterval + check.effectiveTimeout()
+		if now.Sub(lastEvaluatedAt) > maxAge {
+			stale = append(stale, check.Name)
+		}
+	}
+
+	sort.Strings(stale)
+
+	return stale
+}
+
+// isScheduled returns true, if the periodic check is expected to be evaluated on its schedule, i.e., if it is
+// neither paused nor quarantined (see WithPanicQuarantine), disabled (see WithFlagProvider) or assigned to another
+// partition (see WithCheckPartition). It does not acquire the lock of the Checker.
+func (ck *defaultChecker) isScheduled(check *Check) bool {
+	pause := ck.pauseStates[check.Name]
+
+	return ck.isAssignedToPartition(check) &&
+		!pause.paused.Load() && !pause.quarantined.Load() && !pause.disabled.Load()
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	slogctx "github.com/veqryn/slog-context"
+)
+
+// StatsDClient is the minimal interface of a StatsD client that is required by the StatsDInterceptor.
+// Since the lines are already formatted, writing them to a UDP connection of the StatsD daemon is sufficient.
+type StatsDClient interface {
+	// Send sends a single metric line in the StatsD line protocol (e.g., "health.check.db.status:1|g").
+	Send(line string) error
+}
+
+// StatsDInterceptor creates an Interceptor that emits the result of each check evaluation to StatsD.
+// For each evaluation, a gauge "health.check.<name>.status" (1 if the check is up, 0 otherwise) and
+// a timing "health.check.<name>.duration" (in milliseconds) are sent. Characters in the check name that
+// are not allowed in StatsD metric names are replaced by underscores. The observability tags of the check
+// (see WithObservabilityTags) are appended in the DogStatsD tag format (e.g., "...|g|#team:payments,tier:1").
+func StatsDInterceptor(client StatsDClient) Interceptor {
+	return func(next InterceptorFunc) InterceptorFunc {
+		return func(ctx context.Context, checkName string, state CheckState) CheckState {
+			start := time.Now()
+			result := next(ctx, checkName, state)
+			duration := time.Since(start)
+
+			prefix := "health.check." + sanitizeStatsDName(checkName)
+			suffix := statsDTags(ObservabilityTagsFromContext(ctx))
+			statusValue := 0
+			if result.Status == StatusUp {
+				statusValue = 1
+			}
+
+			lines := []string{
+				fmt.Sprintf("%s.status:%d|g%s", prefix, statusValue, suffix),
+				fmt.Sprintf("%s.duration:%d|ms%s", prefix, duration.Milliseconds(), suffix),
+			}
+			for _, line := range lines {
+				if err := client.Send(line); err != nil {
+					slogctx.Error(ctx, "Failed to send StatsD metric", "metric", line, "error", err)
+				}
+			}
+
+			return result
+		}
+	}
+}
+
+// statsDTags formats the tags in the DogStatsD tag format (e.g., "|#team:payments,tier:1").
+// It returns an empty string if there are no tags.
+func statsDTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	return "|#" + strings.Join(datadogTags(tags), ",")
+}
+
+// sanitizeStatsDTag replaces the characters that separate tags and metric fields by underscores.
+func sanitizeStatsDTag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ',', '|', ':', '#', '\n':
+			return '_'
+		default:
+			return r
+		}
+	}, tag)
+}
+
+func sanitizeStatsDName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
@@ -0,0 +1,104 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type fakeStatsDClient struct {
+	mtx   sync.Mutex
+	lines []string
+	err   error
+}
+
+func (c *fakeStatsDClient) Send(line string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.lines = append(c.lines, line)
+
+	return c.err
+}
+
+func TestStatsDInterceptor(t *testing.T) {
+	// Arrange
+	client := &fakeStatsDClient{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.StatsDInterceptor(client)),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+		health.WithCheck(health.Check{
+			Name:  "GRPC Server",
+			Check: func(ctx context.Context) error { return errors.New("unavailable") },
+		}),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Len(t, client.lines, 4)
+	assert.Contains(t, client.lines, "health.check.database.status:1|g")
+	assert.Contains(t, client.lines, "health.check.GRPC_Server.status:0|g")
+
+	durationLine := regexp.MustCompile(`^health\.check\.(database|GRPC_Server)\.duration:\d+\|ms$`)
+	durations := 0
+	for _, line := range client.lines {
+		if durationLine.MatchString(line) {
+			durations++
+		}
+	}
+	assert.Equal(t, 2, durations)
+}
+
+func TestStatsDInterceptorWithObservabilityTags(t *testing.T) {
+	// Arrange
+	client := &fakeStatsDClient{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.StatsDInterceptor(client)),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}, health.WithObservabilityTags(map[string]string{"tier": "1", "team": "pay|ments"})),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	require.Len(t, client.lines, 2)
+	assert.Equal(t, "health.check.database.status:1|g|#team:pay_ments,tier:1", client.lines[0])
+	assert.Regexp(t, `^health\.check\.database\.duration:\d+\|ms\|#team:pay_ments,tier:1$`, client.lines[1])
+}
+
+func TestStatsDInterceptorIgnoresClientErrors(t *testing.T) {
+	// Arrange
+	client := &fakeStatsDClient{err: errors.New("network unreachable")}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(health.StatsDInterceptor(client)),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Len(t, client.lines, 2)
+}
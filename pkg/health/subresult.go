@@ -0,0 +1,72 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SubResult is the result of one aspect of a check that probes several aspects (see Check.SubChecks).
+type SubResult struct {
+	// Name identifies the aspect. It must be unique among the sub-results of a check.
+	Name string
+	// Error must be set if the aspect is considered not available. An error that wraps ErrDegraded
+	// marks the aspect as degraded.
+	Error error
+	// Message optionally describes the aspect in a human-readable form (e.g., measured values).
+	// In contrast to Error, it is reported regardless of the status (see CheckResult.Message).
+	Message string
+}
+
+// rollUpSubResults returns the error of a check that is derived from the worst status of its sub-results.
+// If any sub-result is down, the errors of all sub-results that are down are joined. Otherwise, the errors
+// of all degraded sub-results are joined, so that the check is considered degraded as well.
+func rollUpSubResults(subResults []SubResult) error {
+	var downErrs, degradedErrs []error
+
+	for _, subResult := range subResults {
+		switch subResultStatus(subResult.Error) {
+		case StatusDown:
+			downErrs = append(downErrs, fmt.Errorf("%s: %w", subResult.Name, subResult.Error))
+		case StatusDegraded:
+			degradedErrs = append(degradedErrs, fmt.Errorf("%s: %w", subResult.Name, subResult.Error))
+		default:
+		}
+	}
+
+	if len(downErrs) > 0 {
+		return errors.Join(downErrs...)
+	}
+
+	return errors.Join(degradedErrs...)
+}
+
+func subResultStatus(err error) AvailabilityStatus {
+	switch {
+	case err == nil:
+		return StatusUp
+	case errors.Is(err, ErrDegraded):
+		return StatusDegraded
+	default:
+		return StatusDown
+	}
+}
+
+func newSubCheckResults(subResults []SubResult, now time.Time, maxErrorLength int) map[string]CheckResult {
+	if len(subResults) == 0 {
+		return nil
+	}
+
+	results := make(map[string]CheckResult, len(subResults))
+	for _, subResult := range subResults {
+		results[subResult.Name] = CheckResult{
+			Status:    subResultStatus(subResult.Error),
+			Timestamp: now,
+			Error:     truncateError(subResult.Error, maxErrorLength),
+			Reason:    ReasonOf(subResult.Error),
+			Message:   subResult.Message,
+		}
+	}
+
+	return results
+}
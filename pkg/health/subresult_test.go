@@ -0,0 +1,147 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestSubChecks(t *testing.T) {
+	errReplication := fmt.Errorf("replication lag: %w", health.ErrDegraded)
+	errWrite := errors.New("read-only transaction")
+
+	tests := []struct {
+		name              string
+		subResults        []health.SubResult
+		expectedStatus    health.AvailabilityStatus
+		expectedSubStatus map[string]health.AvailabilityStatus
+	}{
+		{
+			name: "AllUp",
+			subResults: []health.SubResult{
+				{Name: "read"},
+				{Name: "write"},
+				{Name: "replication"},
+			},
+			expectedStatus: health.StatusUp,
+			expectedSubStatus: map[string]health.AvailabilityStatus{
+				"read":        health.StatusUp,
+				"write":       health.StatusUp,
+				"replication": health.StatusUp,
+			},
+		},
+		{
+			name: "WorstIsDegraded",
+			subResults: []health.SubResult{
+				{Name: "read"},
+				{Name: "write"},
+				{Name: "replication", Error: errReplication},
+			},
+			expectedStatus: health.StatusDegraded,
+			expectedSubStatus: map[string]health.AvailabilityStatus{
+				"read":        health.StatusUp,
+				"write":       health.StatusUp,
+				"replication": health.StatusDegraded,
+			},
+		},
+		{
+			name: "WorstIsDown",
+			subResults: []health.SubResult{
+				{Name: "read"},
+				{Name: "write", Error: errWrite},
+				{Name: "replication", Error: errReplication},
+			},
+			expectedStatus: health.StatusDown,
+			expectedSubStatus: map[string]health.AvailabilityStatus{
+				"read":        health.StatusUp,
+				"write":       health.StatusDown,
+				"replication": health.StatusDegraded,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithCheck(health.Check{
+					Name:      "database",
+					SubChecks: func(ctx context.Context) []health.SubResult { return tc.subResults },
+				}),
+			)
+
+			// Act
+			res := ckr.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			assert.Equal(t, tc.expectedStatus, res.Details["database"].Status)
+
+			subStatus := map[string]health.AvailabilityStatus{}
+			for name, subResult := range res.Details["database"].SubResults {
+				subStatus[name] = subResult.Status
+			}
+			assert.Equal(t, tc.expectedSubStatus, subStatus)
+		})
+	}
+}
+
+func TestSubChecksErrorOnlyContainsWorstSubResults(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			SubChecks: func(ctx context.Context) []health.SubResult {
+				return []health.SubResult{
+					{Name: "write", Error: errors.New("read-only transaction")},
+					{Name: "replication", Error: fmt.Errorf("replication lag: %w", health.ErrDegraded)},
+				}
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	require.Error(t, res.Details["database"].Error)
+	assert.Equal(t, "write: read-only transaction", res.Details["database"].Error.Error())
+	assert.Equal(t, health.ReasonDegraded, res.Details["database"].SubResults["replication"].Reason)
+}
+
+func TestSubChecksJSON(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			SubChecks: func(ctx context.Context) []health.SubResult {
+				return []health.SubResult{{Name: "read"}, {Name: "write", Error: errors.New("read-only transaction")}}
+			},
+		}),
+	)
+	res := ckr.Check(t.Context())
+
+	// Act
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	var decoded health.Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// Assert
+	subResults := decoded.Details["database"].SubResults
+	require.Len(t, subResults, 2)
+	assert.Equal(t, health.StatusUp, subResults["read"].Status)
+	assert.Equal(t, health.StatusDown, subResults["write"].Status)
+	assert.EqualError(t, subResults["write"].Error, "read-only transaction")
+}
@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	slogctx "github.com/veqryn/slog-context"
+)
+
+type (
+	// SyslogFacility is a syslog facility code as defined by RFC 5424 (see WithSyslog).
+	SyslogFacility int
+
+	// SyslogSeverity is a syslog severity level as defined by RFC 5424 (see WithSyslog).
+	SyslogSeverity int
+
+	// SyslogWriter is the minimal interface of a syslog client that is required by WithSyslog.
+	// With log/syslog, it can be implemented by a syslog.Writer per priority (see syslog.Dial).
+	// The priority value of a message is facility * 8 + severity.
+	SyslogWriter interface {
+		// WriteSyslog writes the message with the given facility, severity and tag to the syslog endpoint.
+		WriteSyslog(facility SyslogFacility, severity SyslogSeverity, tag, message string) error
+	}
+)
+
+// The syslog facilities that are commonly used by applications.
+const (
+	SyslogFacilityUser   SyslogFacility = 1
+	SyslogFacilityDaemon SyslogFacility = 3
+	SyslogFacilityLocal0 SyslogFacility = 16
+	SyslogFacilityLocal1 SyslogFacility = 17
+	SyslogFacilityLocal2 SyslogFacility = 18
+	SyslogFacilityLocal3 SyslogFacility = 19
+	SyslogFacilityLocal4 SyslogFacility = 20
+	SyslogFacilityLocal5 SyslogFacility = 21
+	SyslogFacilityLocal6 SyslogFacility = 22
+	SyslogFacilityLocal7 SyslogFacility = 23
+)
+
+// The syslog severities that are used by WithSyslog.
+const (
+	SyslogSeverityErr     SyslogSeverity = 3
+	SyslogSeverityWarning SyslogSeverity = 4
+	SyslogSeverityNotice  SyslogSeverity = 5
+	SyslogSeverityInfo    SyslogSeverity = 6
+)
+
+// WithSyslog writes a message to the given syslog endpoint whenever the aggregated health status changes
+// (e.g. from "up" to "down"). The message summarizes the new status and the checks which are not up, e.g.,
+// "health status changed to down: 1 of 3 checks not up: database". The severity is derived from the new
+// status: down is written as error, degraded as warning, unknown as notice and up as info. Messages are written
+// after the Checker applied the status change, like the other transition publishers (see WithMQTTPublisher),
+// and write errors are logged with the tag.
+func WithSyslog(writer SyslogWriter, facility SyslogFacility, tag string) Option {
+	return func(cfg *checkerConfig) {
+		cfg.transitionPublishers = append(cfg.transitionPublishers, func(ctx context.Context, state State) {
+			message := fmt.Sprintf("health status changed to %s: %s", state.Status, newResourceHealthStatus(state).Message)
+
+			if err := writer.WriteSyslog(facility, syslogSeverity(state.Status), tag, message); err != nil {
+				slogctx.Error(ctx, "Failed to write health status to syslog", "tag", tag, "error", err)
+			}
+		})
+	}
+}
+
+func syslogSeverity(status AvailabilityStatus) SyslogSeverity {
+	switch status {
+	case StatusDown:
+		return SyslogSeverityErr
+	case StatusDegraded:
+		return SyslogSeverityWarning
+	case StatusUp:
+		return SyslogSeverityInfo
+	default:
+		return SyslogSeverityNotice
+	}
+}
@@ -0,0 +1,133 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type (
+	fakeSyslogWriter struct {
+		mtx      sync.Mutex
+		messages []syslogMessage
+		err      error
+	}
+
+	syslogMessage struct {
+		facility health.SyslogFacility
+		severity health.SyslogSeverity
+		tag      string
+		message  string
+	}
+)
+
+func (w *fakeSyslogWriter) WriteSyslog(facility health.SyslogFacility, severity health.SyslogSeverity, tag, message string) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.messages = append(w.messages, syslogMessage{facility: facility, severity: severity, tag: tag, message: message})
+
+	return w.err
+}
+
+// awaitMessages waits until n messages were written, since they are written in the background.
+func (w *fakeSyslogWriter) awaitMessages(t *testing.T, n int) []syslogMessage {
+	t.Helper()
+
+	get := func() []syslogMessage {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+
+		return append([]syslogMessage{}, w.messages...)
+	}
+	require.Eventually(t, func() bool { return len(get()) >= n }, time.Second, time.Millisecond)
+
+	return get()
+}
+
+func TestSyslog(t *testing.T) {
+	// Arrange
+	writer := &fakeSyslogWriter{}
+
+	var status atomic.Value
+	status.Store(health.StatusUp)
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithSyslog(writer, health.SyslogFacilityLocal3, "payments"),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				switch status.Load() {
+				case health.StatusDown:
+					return errors.New("unavailable")
+				case health.StatusDegraded:
+					return fmt.Errorf("slow: %w", health.ErrDegraded)
+				default:
+					return nil
+				}
+			},
+		}),
+		health.WithCheck(statusCheck("cache", health.StatusUp)),
+	)
+
+	// Act
+	for _, s := range []health.AvailabilityStatus{health.StatusUp, health.StatusDegraded, health.StatusDown, health.StatusUp} {
+		status.Store(s)
+		ckr.Check(t.Context())
+	}
+
+	// Assert
+	require.Equal(t, []syslogMessage{
+		{
+			facility: health.SyslogFacilityLocal3,
+			severity: health.SyslogSeverityInfo,
+			tag:      "payments",
+			message:  "health status changed to up: all 2 checks up",
+		},
+		{
+			facility: health.SyslogFacilityLocal3,
+			severity: health.SyslogSeverityWarning,
+			tag:      "payments",
+			message:  "health status changed to degraded: 1 of 2 checks not up: database",
+		},
+		{
+			facility: health.SyslogFacilityLocal3,
+			severity: health.SyslogSeverityErr,
+			tag:      "payments",
+			message:  "health status changed to down: 1 of 2 checks not up: database",
+		},
+		{
+			facility: health.SyslogFacilityLocal3,
+			severity: health.SyslogSeverityInfo,
+			tag:      "payments",
+			message:  "health status changed to up: all 2 checks up",
+		},
+	}, writer.awaitMessages(t, 4))
+}
+
+func TestSyslogFailureDoesNotAffectChecker(t *testing.T) {
+	// Arrange
+	writer := &fakeSyslogWriter{err: errors.New("connection refused")}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithSyslog(writer, health.SyslogFacilityDaemon, "payments"),
+		health.WithCheck(statusCheck("database", health.StatusUp)),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Len(t, writer.awaitMessages(t, 1), 1)
+}
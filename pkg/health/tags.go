@@ -0,0 +1,51 @@
+package health
+
+import (
+	"context"
+	"maps"
+	"sort"
+)
+
+type observabilityTagsContextKey struct{}
+
+// WithObservabilityTags attaches static tags (e.g., "team", "tier" or "region") to a check. The tags are
+// propagated to the context of each evaluation of the check (see ObservabilityTagsFromContext), so that the
+// built-in interceptors add them to the spans, metrics, service checks and logs they emit (see
+// OTelTraceInterceptor, StatsDInterceptor, DatadogInterceptor and OTelLogInterceptor). The option can be used
+// multiple times; later tags override earlier tags with the same key.
+func WithObservabilityTags(tags map[string]string) CheckOption {
+	return func(check *Check) {
+		if check.tags == nil {
+			check.tags = make(map[string]string, len(tags))
+		}
+
+		maps.Copy(check.tags, tags)
+	}
+}
+
+// ObservabilityTagsFromContext returns the observability tags of the check that is evaluated with the given
+// context (see WithObservabilityTags). This allows custom interceptors to add the tags to their telemetry.
+// The returned map must not be modified. It returns nil if the check has no tags.
+func ObservabilityTagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(observabilityTagsContextKey{}).(map[string]string)
+	return tags
+}
+
+func withObservabilityTags(ctx context.Context, tags map[string]string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, observabilityTagsContextKey{}, tags)
+}
+
+// sortedTagKeys returns the keys of the tags in ascending order, so that telemetry is emitted deterministically.
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,42 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestObservabilityTagsFromContext(t *testing.T) {
+	// Arrange
+	var tags map[string]string
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				tags = health.ObservabilityTagsFromContext(ctx)
+				return nil
+			},
+		},
+			health.WithObservabilityTags(map[string]string{"team": "payments", "tier": "2"}),
+			health.WithObservabilityTags(map[string]string{"tier": "1", "region": "eu"}),
+		),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, map[string]string{"team": "payments", "tier": "1", "region": "eu"}, tags)
+}
+
+func TestObservabilityTagsFromContextWithoutTags(t *testing.T) {
+	// Act
+	tags := health.ObservabilityTagsFromContext(t.Context())
+
+	// Assert
+	assert.Nil(t, tags)
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	targetProbesKey struct{}
+
+	// targetProbes holds the probes of the targets of an evaluation cycle (see WithTargetDeduplication).
+	targetProbes struct {
+		mtx    sync.Mutex
+		probes map[targetProbeKey]*targetProbe
+	}
+
+	// targetProbeKey identifies a probe of a target. Checks with different timeouts probe the same target
+	// separately, since a check with a short timeout must not report the outcome of a slow probe as its own
+	// (and the other way around).
+	targetProbeKey struct {
+		target  string
+		timeout time.Duration
+	}
+
+	targetProbe struct {
+		done    chan struct{}
+		outcome checkOutcome
+	}
+)
+
+// WithTarget sets the target that a check probes (e.g., a URL or a host and port). Checks with the same
+// target are probed only once per evaluation cycle, if the deduplication is enabled (see WithTargetDeduplication).
+// HTTPCheck and TCPCheck set their URL or address as target. The target of a periodic check is ignored.
+func WithTarget(target string) CheckOption {
+	return func(check *Check) {
+		check.target = target
+	}
+}
+
+// WithTargetDeduplication makes checks with the same target (see WithTarget) share a single probe per evaluation
+// cycle of Checker.Check. The first check of a cycle that probes a target executes its check function and all other
+// checks with the same target and timeout (see Check.Timeout) report its result instead of probing the target again,
+// which avoids redundant load on the target. This requires that checks with the same target probe it the same way.
+// Periodic checks are evaluated on their own schedules and are therefore never deduplicated.
+func WithTargetDeduplication() Option {
+	return func(cfg *checkerConfig) {
+		cfg.targetDeduplication = true
+	}
+}
+
+func withTargetProbes(ctx context.Context) context.Context {
+	return context.WithValue(ctx, targetProbesKey{}, &targetProbes{probes: map[targetProbeKey]*targetProbe{}})
+}
+
+// probeTarget calls probe, unless another check of the evaluation cycle already probes (or probed) the
+// target of the check with the same timeout. In that case, it waits for and returns the outcome of the other check.
+func probeTarget(ctx context.Context, check *Check, probe func() checkOutcome) checkOutcome {
+	probes, ok := ctx.Value(targetProbesKey{}).(*targetProbes)
+	if !ok || check.target == "" {
+		return probe()
+	}
+
+	key := targetProbeKey{target: check.target, timeout: check.effectiveTimeout()}
+
+	probes.mtx.Lock()
+	p, shared := probes.probes[key]
+	if !shared {
+		p = &targetProbe{done: make(chan struct{})}
+		probes.probes[key] = p
+	}
+	probes.mtx.Unlock()
+
+	if !shared {
+		p.outcome = probe()
+		close(p.done)
+
+		return p.outcome
+	}
+
+	select {
+	case <-p.done:
+		return p.outcome
+	case <-ctx.Done():
+		return checkOutcome{err: cancellationError(ctx)}
+	}
+}
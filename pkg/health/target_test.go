@@ -0,0 +1,152 @@
+package health_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+// countingCheck returns a check that counts its calls.
+func countingCheck(name string, calls *atomic.Int32) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	}
+}
+
+func TestTargetDeduplication(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithTargetDeduplication(),
+		health.WithCheck(countingCheck("orders-db", &calls), health.WithTarget("db:5432")),
+		health.WithCheck(countingCheck("billing-db", &calls), health.WithTarget("db:5432")),
+	)
+
+	// Act
+	first := ckr.Check(t.Context())
+	callsAfterFirstCycle := calls.Load()
+	ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, first.Status)
+	assert.Equal(t, health.StatusUp, first.Details["orders-db"].Status)
+	assert.Equal(t, health.StatusUp, first.Details["billing-db"].Status)
+	assert.Equal(t, int32(1), callsAfterFirstCycle, "the target must be probed once per cycle")
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTargetDeduplicationSharesFailures(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithTargetDeduplication(),
+		health.WithCheck(failingCheck("orders-db", 1, assert.AnError, &calls), health.WithTarget("db:5432")),
+		health.WithCheck(failingCheck("billing-db", 1, assert.AnError, &calls), health.WithTarget("db:5432")),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, int32(1), calls.Load())
+	for _, name := range []string{"orders-db", "billing-db"} {
+		state, _ := ckr.(health.StateReader).LastCheckState(name)
+		assert.Equal(t, health.StatusDown, state.Status)
+		assert.ErrorIs(t, state.Result, assert.AnError)
+	}
+}
+
+func TestTargetDeduplicationOfHTTPChecks(t *testing.T) {
+	// Arrange
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithTargetDeduplication(),
+		health.WithCheck(health.HTTPCheck("api", server.URL)),
+		health.WithCheck(health.HTTPCheck("api-alias", server.URL)),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	require.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestTargetDeduplicationProbesTargetPerTimeout(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	fast := countingCheck("orders-db", &calls)
+	fast.Timeout = time.Second
+	slow := countingCheck("billing-db", &calls)
+	slow.Timeout = time.Minute
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithTargetDeduplication(),
+		health.WithCheck(fast, health.WithTarget("db:5432")),
+		health.WithCheck(slow, health.WithTarget("db:5432")),
+		health.WithCheck(countingCheck("reporting-db", &calls), health.WithTarget("db:5432")),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	require.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, int32(3), calls.Load(), "checks with different timeouts must probe the target separately")
+}
+
+func TestTargetDeduplicationIgnoresPeriodicChecks(t *testing.T) {
+	// Arrange
+	var periodicCalls, calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithTargetDeduplication(),
+		health.WithPeriodicCheck(time.Hour, 0, countingCheck("orders-db", &periodicCalls), health.WithTarget("db:5432")),
+		health.WithCheck(countingCheck("billing-db", &calls), health.WithTarget("db:5432")),
+	)
+	defer ckr.Stop()
+	require.Eventually(t, func() bool { return periodicCalls.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, int32(1), calls.Load(), "the synchronous check must probe the target itself")
+}
+
+func TestTargetDeduplicationDisabledByDefault(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(countingCheck("orders-db", &calls), health.WithTarget("db:5432")),
+		health.WithCheck(countingCheck("billing-db", &calls), health.WithTarget("db:5432")),
+	)
+
+	// Act
+	ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// maxBannerLength is the maximum number of bytes that are read from a TCP banner (see TCPBannerCheck).
+const maxBannerLength = 512
+
+// ErrUnexpectedBanner is reported by a TCPBannerCheck if the banner does not start with the expected prefix.
+var ErrUnexpectedBanner = errors.New("unexpected banner")
+
+// TCPCheck creates a check that opens a TCP connection to the given address (e.g., "localhost:5432").
+// The check succeeds if the connection can be established. The connection is closed right away.
+func TCPCheck(name, address string) Check {
+	return Check{
+		Name:   name,
+		target: address,
+		Check: func(ctx context.Context) error {
+			var dialer net.Dialer
+
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			if err != nil {
+				return err
+			}
+
+			return conn.Close()
+		},
+	}
+}
+
+// TCPBannerCheck creates a check for services that identify themselves with a banner on connect (e.g., SMTP
+// or FTP servers). It opens a TCP connection to the given address and reads the banner up to the first newline
+// (but at most 512 bytes). The check succeeds if the banner starts with expectPrefix (e.g., "220 "). Otherwise,
+// it fails with ErrUnexpectedBanner. The timeout limits the time for connecting and reading the banner. If it
+// is zero, only the deadline of the check context applies.
+func TCPBannerCheck(name, address, expectPrefix string, timeout time.Duration) Check {
+	return Check{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			var dialer net.Dialer
+
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			if deadline, ok := ctx.Deadline(); ok {
+				if err := conn.SetReadDeadline(deadline); err != nil {
+					return err
+				}
+			}
+
+			banner, err := readBanner(conn)
+			if err != nil {
+				return fmt.Errorf("cannot read banner: %w", err)
+			}
+
+			if !strings.HasPrefix(banner, expectPrefix) {
+				return fmt.Errorf("%w: %q does not start with %q", ErrUnexpectedBanner, banner, expectPrefix)
+			}
+
+			return nil
+		},
+	}
+}
+
+// readBanner reads a banner up to the first newline or maxBannerLength bytes. The line ending is removed.
+func readBanner(conn net.Conn) (string, error) {
+	reader := bufio.NewReaderSize(conn, maxBannerLength)
+
+	line, err := reader.ReadSlice('\n')
+	switch {
+	case err == nil, errors.Is(err, bufio.ErrBufferFull):
+	case errors.Is(err, io.EOF) && len(line) > 0:
+		// The server closed the connection after a banner without a newline.
+	default:
+		return "", err
+	}
+
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
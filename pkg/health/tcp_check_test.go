@@ -0,0 +1,104 @@
+package health_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestTCPCheck(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	available := health.TCPCheck("available", listener.Addr().String())
+	refused := health.TCPCheck("refused", closed.Addr().String())
+
+	// Act
+	availableErr := available.Check(t.Context())
+	refusedErr := refused.Check(t.Context())
+
+	// Assert
+	require.NoError(t, availableErr)
+	require.Error(t, refusedErr)
+	assert.Equal(t, health.ReasonConnRefused, health.ReasonOf(refusedErr))
+}
+
+// bannerListener starts a TCP listener that writes the given banner to each accepted connection.
+func bannerListener(t *testing.T, banner string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			_, _ = conn.Write([]byte(banner))
+			_ = conn.Close()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestTCPBannerCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		banner    string
+		expectErr error
+	}{
+		{name: "matching banner", banner: "220 mail.example.com ESMTP ready\r\n"},
+		{name: "matching banner without newline", banner: "220 mail.example.com"},
+		{name: "non-matching banner", banner: "554 no service\r\n", expectErr: health.ErrUnexpectedBanner},
+		{name: "prefix after newline", banner: "hello\r\n220 ready\r\n", expectErr: health.ErrUnexpectedBanner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			check := health.TCPBannerCheck("smtp", bannerListener(t, tt.banner), "220 ", time.Second)
+
+			// Act
+			err := check.Check(t.Context())
+
+			// Assert
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTCPBannerCheckTimesOutWithoutBanner(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	check := health.TCPBannerCheck("silent", listener.Addr().String(), "220 ", 50*time.Millisecond)
+
+	// Act
+	err = check.Check(t.Context())
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, health.ReasonTimeout, health.ReasonOf(err))
+}
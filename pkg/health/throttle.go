@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// listenerThrottle limits how often the StatusListener of a check is notified
+	// (see WithListenerCoolDown). Notifications that arrive within the cool-down
+	// period of a check are coalesced, so that only the latest state is delivered
+	// once the cool-down period is over (or when the Checker is stopped).
+	listenerThrottle struct {
+		coolDown time.Duration
+		clock    TimerClock
+		mtx      sync.Mutex
+		checks   map[string]*throttledListener
+	}
+
+	throttledListener struct {
+		check              *Check
+		lastNotifiedAt     time.Time
+		lastNotifiedStatus AvailabilityStatus
+		pendingCtx         context.Context
+		pendingState       *CheckState
+		timer              Timer
+	}
+)
+
+func newListenerThrottle(coolDown time.Duration, clock Clock) *listenerThrottle {
+	return &listenerThrottle{
+		coolDown: coolDown,
+		clock:    timerClockOf(clock),
+		checks:   map[string]*throttledListener{},
+	}
+}
+
+func (lt *listenerThrottle) notify(ctx context.Context, check *Check, state CheckState) {
+	if lt.coolDown <= 0 {
+		check.StatusListener(ctx, check.Name, state)
+		return
+	}
+
+	lt.mtx.Lock()
+
+	tl, ok := lt.checks[check.Name]
+	if !ok {
+		tl = &throttledListener{check: check}
+		lt.checks[check.Name] = tl
+	}
+
+	now := lt.clock.Now()
+	nextAllowedAt := tl.lastNotifiedAt.Add(lt.coolDown)
+
+	if tl.timer == nil && !now.Before(nextAllowedAt) {
+		tl.lastNotifiedAt = now
+		tl.lastNotifiedStatus = state.Status
+		lt.mtx.Unlock()
+
+		check.StatusListener(ctx, check.Name, state)
+
+		return
+	}
+
+	// The listener must not be called before the cool-down period is over. The context of the
+	// originating call may already be cancelled by then, so only its values are retained.
+	tl.pendingCtx = context.WithoutCancel(ctx)
+	tl.pendingState = &state
+
+	if tl.timer == nil {
+		tl.timer = lt.clock.AfterFunc(nextAllowedAt.Sub(now), func() {
+			lt.flush(check)
+		})
+	}
+
+	lt.mtx.Unlock()
+}
+
+func (lt *listenerThrottle) flush(check *Check) {
+	lt.mtx.Lock()
+
+	tl := lt.checks[check.Name]
+	ctx, state := tl.pendingCtx, tl.pendingState
+	tl.pendingCtx, tl.pendingState, tl.timer = nil, nil, nil
+
+	// If the check flapped back to the last reported status, there is nothing to report.
+	if state == nil || state.Status == tl.lastNotifiedStatus {
+		lt.mtx.Unlock()
+		return
+	}
+
+	tl.lastNotifiedAt = lt.clock.Now()
+	tl.lastNotifiedStatus = state.Status
+	lt.mtx.Unlock()
+
+	check.StatusListener(ctx, check.Name, *state)
+}
+
+// stop delivers all pending notifications right away, so that the latest status changes are not lost when
+// the Checker is stopped.
+func (lt *listenerThrottle) stop() {
+	lt.mtx.Lock()
+
+	var pending []*Check
+	for _, tl := range lt.checks {
+		if tl.timer != nil {
+			tl.timer.Stop()
+			pending = append(pending, tl.check)
+		}
+	}
+
+	lt.mtx.Unlock()
+
+	for _, check := range pending {
+		lt.flush(check)
+	}
+}
@@ -0,0 +1,191 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+type notificationRecorder struct {
+	mtx           sync.Mutex
+	notifications []time.Time
+	statuses      []health.AvailabilityStatus
+}
+
+func (r *notificationRecorder) listener(_ context.Context, _ string, state health.CheckState) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.notifications = append(r.notifications, time.Now())
+	r.statuses = append(r.statuses, state.Status)
+}
+
+func (r *notificationRecorder) snapshot() ([]time.Time, []health.AvailabilityStatus) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([]time.Time(nil), r.notifications...), append([]health.AvailabilityStatus(nil), r.statuses...)
+}
+
+func flappingCheck(recorder *notificationRecorder) health.Check {
+	var (
+		mtx  sync.Mutex
+		fail bool
+	)
+
+	return health.Check{
+		Name: "flapping",
+		Check: func(ctx context.Context) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			fail = !fail
+			if fail {
+				return errors.New("flap")
+			}
+
+			return nil
+		},
+		StatusListener: recorder.listener,
+	}
+}
+
+func TestListenerCoolDownThrottlesNotifications(t *testing.T) {
+	// Arrange
+	coolDown := 200 * time.Millisecond
+	recorder := &notificationRecorder{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithListenerCoolDown(coolDown),
+		health.WithCheck(flappingCheck(recorder)),
+	)
+
+	// Act
+	start := time.Now()
+	for time.Since(start) < 3*coolDown {
+		ckr.Check(t.Context())
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(2 * coolDown)
+
+	// Assert
+	notifications, _ := recorder.snapshot()
+	require.NotEmpty(t, notifications)
+	assert.LessOrEqual(t, len(notifications), 5)
+	for i := 1; i < len(notifications); i++ {
+		assert.GreaterOrEqual(t, notifications[i].Sub(notifications[i-1]), coolDown-10*time.Millisecond)
+	}
+}
+
+func TestListenerCoolDownDeliversLatestState(t *testing.T) {
+	// Arrange
+	coolDown := 100 * time.Millisecond
+	recorder := &notificationRecorder{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithListenerCoolDown(coolDown),
+		health.WithCheck(flappingCheck(recorder)),
+	)
+
+	// Act
+	ckr.Check(t.Context()) // unknown -> down: notified immediately
+	ckr.Check(t.Context()) // down -> up: deferred
+	ckr.Check(t.Context()) // up -> down: coalesced, same as last notified status
+	ckr.Check(t.Context()) // down -> up: coalesced, latest state
+	time.Sleep(3 * coolDown)
+
+	// Assert
+	_, statuses := recorder.snapshot()
+	assert.Equal(t, []health.AvailabilityStatus{health.StatusDown, health.StatusUp}, statuses)
+}
+
+func TestWithoutListenerCoolDownEveryTransitionIsNotified(t *testing.T) {
+	// Arrange
+	recorder := &notificationRecorder{}
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(flappingCheck(recorder)),
+	)
+
+	// Act
+	for range 4 {
+		ckr.Check(t.Context())
+	}
+
+	// Assert
+	_, statuses := recorder.snapshot()
+	assert.Len(t, statuses, 4)
+}
+
+func TestListenerCoolDownUsesClockAndIsFlushedOnStop(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	clock := newFakeClock(time.Now())
+	recorder := &notificationRecorder{}
+	check := toggledCheck("check", &fail)
+	check.StatusListener = recorder.listener
+	ckr := health.NewChecker(
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithListenerCoolDown(time.Minute),
+		health.WithCheck(check),
+	)
+	require.Eventually(t, func() bool {
+		_, statuses := recorder.snapshot()
+		return len(statuses) == 1
+	}, time.Second, time.Millisecond)
+
+	// Act
+	clock.Advance(time.Minute)
+	fail.Store(true)
+	ckr.Check(t.Context()) // up -> down: notified immediately, since the cool-down period is over
+	fail.Store(false)
+	clock.Advance(time.Second)
+	ckr.Check(t.Context()) // down -> up: deferred
+	ckr.Stop()
+
+	// Assert
+	_, statuses := recorder.snapshot()
+	assert.Equal(t, []health.AvailabilityStatus{health.StatusUp, health.StatusDown, health.StatusUp}, statuses)
+}
+
+func TestListenerCoolDownIsScheduledWithClock(t *testing.T) {
+	// Arrange
+	var fail atomic.Bool
+	clock := newFakeClock(time.Now())
+	recorder := &notificationRecorder{}
+	check := toggledCheck("check", &fail)
+	check.StatusListener = recorder.listener
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithListenerCoolDown(time.Minute),
+		health.WithCheck(check),
+	)
+	ckr.Check(t.Context()) // unknown -> up: notified immediately
+	fail.Store(true)
+	clock.Advance(time.Second)
+	ckr.Check(t.Context()) // up -> down: deferred
+
+	// Act
+	clock.Advance(58 * time.Second)
+	_, withinCoolDown := recorder.snapshot()
+	clock.Advance(time.Second)
+	_, afterCoolDown := recorder.snapshot()
+
+	// Assert
+	assert.Equal(t, []health.AvailabilityStatus{health.StatusUp}, withinCoolDown)
+	assert.Equal(t, []health.AvailabilityStatus{health.StatusUp, health.StatusDown}, afterCoolDown)
+}
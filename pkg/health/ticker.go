@@ -0,0 +1,78 @@
+package health
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// Ticker implements StateTicker.Ticker. Please refer to StateTicker.Ticker for more information.
+func (ck *defaultChecker) Ticker(interval time.Duration) (<-chan State, func()) {
+	var (
+		states   = make(chan State, 1)
+		done     = make(chan struct{})
+		stopOnce sync.Once
+		stopped  = make(chan struct{})
+	)
+
+	if interval <= 0 {
+		close(states)
+		return states, func() {}
+	}
+
+	checkerStopped := *ck.tickersDone.Load()
+
+	go func() {
+		defer close(stopped)
+		defer close(states)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-checkerStopped:
+				return
+			case <-ticker.C:
+				select {
+				case states <- ck.copySnapshot():
+				default:
+					// The receiver did not consume the previous state yet, so this tick is dropped.
+					ck.droppedTickerStates.Add(1)
+				}
+			}
+		}
+	}()
+
+	stop := func() {
+		stopOnce.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+
+	return states, stop
+}
+
+// stopTickers stops all tickers that were created before (see Checker.Stop). Tickers that are created
+// afterwards are only stopped by the next call.
+func (ck *defaultChecker) stopTickers() {
+	next := make(chan struct{})
+	close(*ck.tickersDone.Swap(&next))
+}
+
+// State implements StateReader.State. Please refer to StateReader.State for more information.
+func (ck *defaultChecker) State() State {
+	return ck.copySnapshot()
+}
+
+// copySnapshot returns a copy of the latest published state (see publishSnapshot)
+// that may be modified by the caller.
+func (ck *defaultChecker) copySnapshot() State {
+	state := *ck.snapshot.Load()
+	state.CheckState = maps.Clone(state.CheckState)
+
+	return state
+}
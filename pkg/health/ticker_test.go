@@ -0,0 +1,115 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestTickerDeliversStatePeriodically(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:  "check",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+	ckr.Check(t.Context())
+
+	// Act
+	states, stop := ckr.(health.StateTicker).Ticker(10 * time.Millisecond)
+	defer stop()
+
+	// Assert
+	for range 3 {
+		select {
+		case state := <-states:
+			assert.Equal(t, health.StatusUp, state.Status)
+			assert.Equal(t, health.StatusUp, state.CheckState["check"].Status)
+		case <-time.After(time.Second):
+			require.FailNow(t, "no state was delivered")
+		}
+	}
+}
+
+func TestTickerStop(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+	states, stop := ckr.(health.StateTicker).Ticker(5 * time.Millisecond)
+
+	// Act
+	stop()
+	stop()
+
+	// Assert
+	for range states {
+		// Drain a state that may have been delivered before the ticker was stopped.
+	}
+
+	_, ok := <-states
+	assert.False(t, ok)
+}
+
+func TestTickerWithInvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		t.Run(interval.String(), func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(health.WithDisabledAutostart())
+
+			// Act
+			states, stop := ckr.(health.StateTicker).Ticker(interval)
+			defer stop()
+
+			// Assert
+			_, ok := <-states
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestTickerIsStoppedWithChecker(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker()
+	states, stop := ckr.(health.StateTicker).Ticker(5 * time.Millisecond)
+	defer stop()
+
+	// Act
+	ckr.Stop()
+
+	// Assert
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-states:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+}
+
+func TestTickerStateIsACopy(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:  "check",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+	states, stop := ckr.(health.StateTicker).Ticker(5 * time.Millisecond)
+	defer stop()
+
+	// Act
+	state := <-states
+	delete(state.CheckState, "check")
+
+	// Assert
+	_, ok := ckr.(health.StateReader).LastCheckState("check")
+	assert.True(t, ok)
+}
@@ -0,0 +1,47 @@
+package health
+
+import "unicode/utf8"
+
+// truncationSuffix is appended to error messages that were truncated (see WithMaxErrorLength).
+const truncationSuffix = "..."
+
+// truncatedError limits the length of the message of an error (see WithMaxErrorLength),
+// while the error chain is retained (e.g., for errors.Is and ReasonOf).
+type truncatedError struct {
+	err       error
+	maxLength int
+}
+
+func (e *truncatedError) Error() string {
+	return truncateString(e.err.Error(), e.maxLength)
+}
+
+func (e *truncatedError) Unwrap() error {
+	return e.err
+}
+
+// truncateError limits the length of the error message to maxLength characters.
+// It returns the error as is, if it is nil, short enough or maxLength is not positive.
+func truncateError(err error, maxLength int) error {
+	if err == nil || maxLength <= 0 || utf8.RuneCountInString(err.Error()) <= maxLength {
+		return err
+	}
+
+	return &truncatedError{err: err, maxLength: maxLength}
+}
+
+func truncateString(s string, maxLength int) string {
+	if maxLength <= 0 || utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+
+	runes := 0
+	for idx := range s {
+		if runes == maxLength {
+			return s[:idx] + truncationSuffix
+		}
+		runes++
+	}
+
+	return s
+}
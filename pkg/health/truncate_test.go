@@ -0,0 +1,98 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestMaxErrorLength(t *testing.T) {
+	tests := []struct {
+		name            string
+		errMsg          string
+		expectedMessage string
+	}{
+		{
+			name:            "ShorterThanLimit",
+			errMsg:          "short",
+			expectedMessage: "short",
+		},
+		{
+			name:            "ExactlyAtLimit",
+			errMsg:          "0123456789",
+			expectedMessage: "0123456789",
+		},
+		{
+			name:            "OneAboveLimit",
+			errMsg:          "0123456789X",
+			expectedMessage: "0123456789...",
+		},
+		{
+			name:            "LongError",
+			errMsg:          "SELECT " + strings.Repeat("column, ", 1000),
+			expectedMessage: "SELECT col...",
+		},
+		{
+			name:            "MultiByteCharacters",
+			errMsg:          "äöüäöüäöüäöü",
+			expectedMessage: "äöüäöüäöüä...",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			checkErr := errors.New(tc.errMsg)
+			listenerStates := make(chan health.CheckState, 2)
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithMaxErrorLength(10),
+				health.WithCheck(health.Check{
+					Name:  "check",
+					Check: func(ctx context.Context) error { return checkErr },
+					StatusListener: func(ctx context.Context, name string, state health.CheckState) {
+						listenerStates <- state
+					},
+				}),
+			)
+
+			// Act
+			res := ckr.Check(t.Context())
+
+			// Assert
+			require.Error(t, res.Details["check"].Error)
+			assert.Equal(t, tc.expectedMessage, res.Details["check"].Error.Error())
+			require.ErrorIs(t, res.Details["check"].Error, checkErr)
+
+			state := <-listenerStates
+			assert.Equal(t, tc.expectedMessage, state.Result.Error())
+		})
+	}
+}
+
+func TestMaxErrorLengthRetainsReason(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithMaxErrorLength(5),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return health.ErrorWithReason(health.ReasonUnavailable, errors.New("service unavailable"))
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, "servi...", res.Details["check"].Error.Error())
+	assert.Equal(t, health.ReasonUnavailable, res.Details["check"].Reason)
+}
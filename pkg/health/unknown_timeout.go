@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithUnknownTimeout reports checks that are still unknown (i.e., that did not produce a first result) after the
+// given duration as down, e.g., if a periodic check hangs, because its dependency is unreachable at startup. The
+// duration is measured from the start of the Checker (see Checker.Start) with its Clock (see WithClock). Such a
+// check reports StatusDown with ReasonUnknownTimeout and an error that wraps ErrUnknownTimeout (and its status
+// listener is notified), until it produces its first result. The timeout is applied once it elapsed and whenever
+// the state of the Checker is updated (e.g., by Checker.Check). By default, checks may be unknown indefinitely.
+func WithUnknownTimeout(timeout time.Duration) Option {
+	return func(cfg *checkerConfig) {
+		cfg.unknownTimeout = timeout
+	}
+}
+
+// applyUnknownTimeout reports all checks that are still unknown after the unknown timeout as down
+// (see WithUnknownTimeout) and notifies their status listeners. It returns true if the status of any check
+// changed. The caller must hold the mutex lock.
+func (ck *defaultChecker) applyUnknownTimeout(ctx context.Context, now time.Time) bool {
+	timeout := ck.cfg.unknownTimeout
+	startedAt := ck.startedAt.Load()
+	if timeout <= 0 || startedAt == nil || now.Sub(*startedAt) < timeout {
+		return false
+	}
+
+	changed := false
+	for _, check := range ck.cfg.checks {
+		state := ck.state.CheckState[check.Name]
+		if state.Status != StatusUnknown || !state.LastCheckedAt.IsZero() || ck.disabledChecks[check.Name] {
+			continue
+		}
+
+		state.Result = ErrorWithReason(ReasonUnknownTimeout, fmt.Errorf("%w after %s", ErrUnknownTimeout, timeout))
+		state.Reason = ReasonUnknownTimeout
+		state.Status = StatusDown
+		state.LastStatusChangeAt = now
+		ck.state.CheckState[check.Name] = state
+
+		ck.notifyStatusListener(ctx, check, state)
+
+		changed = true
+	}
+
+	return changed
+}
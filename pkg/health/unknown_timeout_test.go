@@ -0,0 +1,145 @@
+package health_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestUnknownTimeout(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	defer close(release)
+
+	var calls atomic.Int32
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithClock(clock),
+		health.WithUnknownTimeout(time.Minute),
+		// The periodic check does not produce a first result, since its evaluation hangs.
+		health.WithPeriodicCheck(time.Hour, 0, health.Check{
+			Name: "stuck",
+			Check: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+		}),
+		health.WithCheck(countingCheck("db", &calls)),
+	)
+	defer ckr.Stop()
+
+	// The timeout is measured from the start of the Checker.
+	clock.Advance(time.Hour)
+	ckr.Start()
+
+	// Act
+	clock.Advance(59 * time.Second)
+	before := ckr.Check(t.Context())
+	clock.Advance(time.Second)
+	after := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUnknown, before.Status)
+	assert.Equal(t, health.StatusUnknown, before.Details["stuck"].Status)
+
+	assert.Equal(t, health.StatusDown, after.Status)
+	assert.Equal(t, health.StatusDown, after.Details["stuck"].Status)
+	assert.Equal(t, health.ReasonUnknownTimeout, after.Details["stuck"].Reason)
+	assert.ErrorIs(t, after.Details["stuck"].Error, health.ErrUnknownTimeout)
+	assert.Equal(t, health.StatusUp, after.Details["db"].Status)
+
+	state, _ := ckr.(health.StateReader).LastCheckState("stuck")
+	assert.Equal(t, clock.Now().UTC(), state.LastStatusChangeAt)
+}
+
+func TestUnknownTimeoutIsAppliedWithoutStateUpdates(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	defer close(release)
+
+	notified := make(chan health.CheckState, 1)
+	ckr := health.NewChecker(
+		health.WithUnknownTimeout(20*time.Millisecond),
+		health.WithPeriodicCheck(time.Hour, 0, health.Check{
+			Name: "stuck",
+			Check: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+			StatusListener: func(ctx context.Context, name string, state health.CheckState) {
+				notified <- state
+			},
+		}),
+	)
+	defer ckr.Stop()
+
+	// Act
+	var state health.CheckState
+	select {
+	case state = <-notified:
+	case <-time.After(time.Second):
+		require.FailNow(t, "status listener was not notified")
+	}
+
+	// Assert
+	assert.Equal(t, health.StatusDown, state.Status)
+	assert.Equal(t, health.ReasonUnknownTimeout, state.Reason)
+	assert.Equal(t, health.StatusDown, ckr.(health.StateReader).State().Status)
+}
+
+func TestUnknownTimeoutIsReplacedByFirstResult(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	clock := newFakeClock(time.Now())
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithClock(clock),
+		health.WithUnknownTimeout(time.Minute),
+		health.WithPeriodicCheck(time.Hour, 0, health.Check{
+			Name: "slow",
+			Check: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+		}),
+	)
+	defer ckr.Stop()
+
+	ckr.Start()
+	clock.Advance(time.Minute)
+	assert.Equal(t, health.StatusDown, ckr.Check(t.Context()).Details["slow"].Status)
+
+	// Act
+	close(release)
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		state, _ := ckr.(health.StateReader).LastCheckState("slow")
+		return state.Status == health.StatusUp
+	}, time.Second, time.Millisecond)
+}
+
+func TestUnknownTimeoutDisabledByDefault(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithClock(clock),
+		health.WithPeriodicCheck(time.Hour, 0, countingCheck("stuck", &calls)),
+	)
+
+	// Act
+	clock.Advance(24 * time.Hour)
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusUnknown, result.Details["stuck"].Status)
+}
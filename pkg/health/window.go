@@ -0,0 +1,57 @@
+package health
+
+import "time"
+
+// ActiveWindow defines a recurring time window (e.g., business hours) in a specific time zone.
+// See WithActiveWindow for more information.
+type ActiveWindow struct {
+	// Location is the time zone in which the window is defined. Defaults to UTC.
+	Location *time.Location
+	// Days holds the weekdays on which the window starts. If empty, the window starts every day.
+	Days []time.Weekday
+	// Start is the wall-clock time of day at which the window starts as an offset from midnight (e.g.,
+	// 8*time.Hour for 08:00), so that the window is not shifted on days with a DST change.
+	Start time.Duration
+	// End is the wall-clock time of day at which the window ends (exclusive). If End is before Start,
+	// the window spans midnight and ends on the following day.
+	End time.Duration
+}
+
+// Contains returns true, if the time t lies within the window.
+func (w ActiveWindow) Contains(t time.Time) bool {
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// The offset is determined from the wall clock, since days with a DST change do not have 24 hours.
+	t = t.In(loc)
+	hour, minute, second := t.Clock()
+	offset := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(t.Nanosecond())
+
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End && w.startsOn(t.Weekday())
+	}
+
+	// The window spans midnight.
+	if offset >= w.Start {
+		return w.startsOn(t.Weekday())
+	}
+
+	return offset < w.End && w.startsOn((t.Weekday()+6)%7)
+}
+
+func (w ActiveWindow) startsOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,193 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func TestActiveWindowContains(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	businessHours := health.ActiveWindow{
+		Location: berlin,
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start:    8 * time.Hour,
+		End:      18 * time.Hour,
+	}
+	daily := health.ActiveWindow{Location: berlin, Start: 8 * time.Hour, End: 18 * time.Hour}
+	nightShift := health.ActiveWindow{
+		Days:  []time.Weekday{time.Friday},
+		Start: 22 * time.Hour,
+		End:   6 * time.Hour,
+	}
+
+	tests := []struct {
+		name     string
+		window   health.ActiveWindow
+		time     time.Time
+		expected bool
+	}{
+		{
+			name:     "WithinBusinessHours",
+			window:   businessHours,
+			time:     time.Date(2025, time.June, 2, 9, 0, 0, 0, berlin), // Monday
+			expected: true,
+		},
+		{
+			name:     "WithinBusinessHoursInOtherTimezone",
+			window:   businessHours,
+			time:     time.Date(2025, time.June, 2, 6, 30, 0, 0, time.UTC), // 08:30 in Berlin
+			expected: true,
+		},
+		{
+			name:     "BeforeBusinessHoursInOtherTimezone",
+			window:   businessHours,
+			time:     time.Date(2025, time.June, 2, 5, 30, 0, 0, time.UTC), // 07:30 in Berlin
+			expected: false,
+		},
+		{
+			name:     "EndIsExclusive",
+			window:   businessHours,
+			time:     time.Date(2025, time.June, 2, 18, 0, 0, 0, berlin),
+			expected: false,
+		},
+		{
+			name:     "WeekendIsOutsideBusinessHours",
+			window:   businessHours,
+			time:     time.Date(2025, time.June, 1, 9, 0, 0, 0, berlin), // Sunday
+			expected: false,
+		},
+		{
+			name:     "StartOnDayOfDSTBegin",
+			window:   daily,
+			time:     time.Date(2025, time.March, 30, 8, 30, 0, 0, berlin), // the day has 23 hours
+			expected: true,
+		},
+		{
+			name:     "EndOnDayOfDSTEnd",
+			window:   daily,
+			time:     time.Date(2025, time.October, 26, 17, 30, 0, 0, berlin), // the day has 25 hours
+			expected: true,
+		},
+		{
+			name:     "OvernightWindowBeforeMidnight",
+			window:   nightShift,
+			time:     time.Date(2025, time.June, 6, 23, 0, 0, 0, time.UTC), // Friday
+			expected: true,
+		},
+		{
+			name:     "OvernightWindowAfterMidnight",
+			window:   nightShift,
+			time:     time.Date(2025, time.June, 7, 5, 0, 0, 0, time.UTC), // Saturday
+			expected: true,
+		},
+		{
+			name:     "OvernightWindowStartsOnlyOnConfiguredDays",
+			window:   nightShift,
+			time:     time.Date(2025, time.June, 8, 5, 0, 0, 0, time.UTC), // Sunday
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := tc.window.Contains(tc.time)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestActiveWindowCheckStatus(t *testing.T) {
+	window := health.ActiveWindow{Start: 8 * time.Hour, End: 18 * time.Hour}
+
+	tests := []struct {
+		name           string
+		now            time.Time
+		expectedStatus health.AvailabilityStatus
+		expectedReason string
+	}{
+		{
+			name:           "FailureInsideWindowCounts",
+			now:            time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC),
+			expectedStatus: health.StatusDown,
+			expectedReason: health.ReasonError,
+		},
+		{
+			name:           "FailureOutsideWindowIsNeutral",
+			now:            time.Date(2025, time.June, 2, 20, 0, 0, 0, time.UTC),
+			expectedStatus: health.StatusUp,
+			expectedReason: health.ReasonOutsideActiveWindow,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ckr := health.NewChecker(
+				health.WithDisabledAutostart(),
+				health.WithClock(newFakeClock(tc.now)),
+				health.WithCheck(health.Check{
+					Name:  "business-hours-only",
+					Check: func(ctx context.Context) error { return errors.New("unavailable") },
+				}, health.WithActiveWindow(window)),
+			)
+
+			// Act
+			res := ckr.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			require.Contains(t, res.Details, "business-hours-only")
+			assert.Equal(t, tc.expectedReason, res.Details["business-hours-only"].Reason)
+			assert.EqualError(t, res.Details["business-hours-only"].Error, "unavailable")
+		})
+	}
+}
+
+func TestActiveWindowResetsThresholdsOfFailingCheck(t *testing.T) {
+	// Arrange
+	clock := newFakeClock(time.Date(2025, time.June, 2, 17, 58, 0, 0, time.UTC))
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithClock(clock),
+		health.WithCheck(health.Check{
+			Name:               "business-hours-only",
+			MaxContiguousFails: 3,
+			MaxTimeInError:     10 * time.Minute,
+			Check:              func(ctx context.Context) error { return errors.New("unavailable") },
+		}, health.WithActiveWindow(health.ActiveWindow{Start: 8 * time.Hour, End: 18 * time.Hour})),
+	)
+
+	statusAt := func(now time.Time) health.AvailabilityStatus {
+		clock.Set(now)
+		return ckr.Check(t.Context()).Status
+	}
+
+	// Act
+	ckr.Check(t.Context())
+	beforeClose := statusAt(time.Date(2025, time.June, 2, 17, 59, 0, 0, time.UTC))
+	statusAt(time.Date(2025, time.June, 2, 20, 0, 0, 0, time.UTC))
+	statusAt(time.Date(2025, time.June, 3, 3, 0, 0, 0, time.UTC))
+	atOpening := statusAt(time.Date(2025, time.June, 3, 8, 0, 0, 0, time.UTC))
+	statusAt(time.Date(2025, time.June, 3, 8, 1, 0, 0, time.UTC))
+	thirdFailure := statusAt(time.Date(2025, time.June, 3, 8, 2, 0, 0, time.UTC))
+	afterGrace := statusAt(time.Date(2025, time.June, 3, 8, 10, 0, 0, time.UTC))
+
+	// Assert
+	assert.Equal(t, health.StatusUp, beforeClose, "the check is at 2 of 3 failures when the window closes")
+	assert.Equal(t, health.StatusUp, atOpening, "failures before the opening of the window must not count")
+	assert.Equal(t, health.StatusUp, thirdFailure, "the time in error must be measured from the opening")
+	assert.Equal(t, health.StatusDown, afterGrace)
+}
@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+
+	slogctx "github.com/veqryn/slog-context"
+)
+
+type (
+	// WorkerPoolStats holds the statistics of the shared worker pool (see WithWorkerPool).
+	WorkerPoolStats struct {
+		// Size is the number of workers of the pool.
+		Size int `json:"size"`
+		// ActiveWorkers is the number of workers that currently evaluate a check.
+		ActiveWorkers int `json:"activeWorkers"`
+		// QueuedEvaluations is the number of check evaluations that currently wait for a worker.
+		QueuedEvaluations int `json:"queuedEvaluations"`
+		// Saturations is the number of times the pool became saturated, i.e., an evaluation had to
+		// wait for a worker, while no other evaluations were waiting.
+		Saturations uint64 `json:"saturations"`
+	}
+
+	// workerPool limits the number of concurrent check evaluations (see WithWorkerPool).
+	workerPool struct {
+		size        int
+		slots       chan struct{}
+		active      atomic.Int64
+		queued      atomic.Int64
+		saturations atomic.Uint64
+	}
+)
+
+func newWorkerPool(size int) *workerPool {
+	if size <= 0 {
+		return nil
+	}
+
+	return &workerPool{size: size, slots: make(chan struct{}, size)}
+}
+
+// run executes f on a worker of the pool. If all workers are busy, it waits until a worker is available
+// or the context is done. In the latter case, f is not executed. Without a pool, f is executed right away.
+func (p *workerPool) run(ctx context.Context, f func() checkOutcome) checkOutcome {
+	if p == nil {
+		return f()
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		if p.queued.Add(1) == 1 {
+			p.saturations.Add(1)
+			slogctx.Warn(ctx, "Health check worker pool is saturated, check evaluations are queuing",
+				"size", p.size, "activeWorkers", p.active.Load())
+		}
+
+		select {
+		case p.slots <- struct{}{}:
+			p.queued.Add(-1)
+		case <-ctx.Done():
+			p.queued.Add(-1)
+
+			return checkOutcome{err: cancellationError(ctx)}
+		}
+	}
+
+	p.active.Add(1)
+
+	defer func() {
+		p.active.Add(-1)
+		<-p.slots
+	}()
+
+	return f()
+}
+
+func (p *workerPool) stats() *WorkerPoolStats {
+	if p == nil {
+		return nil
+	}
+
+	return &WorkerPoolStats{
+		Size:              p.size,
+		ActiveWorkers:     int(p.active.Load()),
+		QueuedEvaluations: int(p.queued.Load()),
+		Saturations:       p.saturations.Load(),
+	}
+}
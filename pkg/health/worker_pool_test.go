@@ -0,0 +1,119 @@
+package health_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openkcm/common-sdk/pkg/health"
+)
+
+func blockingChecks(num int, release <-chan struct{}) []health.Check {
+	checks := make([]health.Check, 0, num)
+	for i := range num {
+		checks = append(checks, health.Check{
+			Name: fmt.Sprintf("check-%d", i),
+			Check: func(ctx context.Context) error {
+				select {
+				case <-release:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		})
+	}
+
+	return checks
+}
+
+func TestWorkerPoolReportsSaturation(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithWorkerPool(2),
+		health.WithChecks(blockingChecks(5, release)...),
+	)
+
+	done := make(chan health.Result, 1)
+
+	// Act
+	go func() { done <- ckr.Check(t.Context()) }()
+
+	// Assert
+	require.Eventually(t, func() bool {
+		stats := ckr.(health.StatsProvider).Stats().WorkerPool
+		return stats.ActiveWorkers == 2 && stats.QueuedEvaluations == 3
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, 2, ckr.(health.StatsProvider).Stats().WorkerPool.Size)
+	assert.Equal(t, uint64(1), ckr.(health.StatsProvider).Stats().WorkerPool.Saturations)
+
+	close(release)
+
+	result := <-done
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Len(t, result.Details, 5)
+	assert.Nil(t, result.Stats)
+
+	stats := ckr.(health.StatsProvider).Stats().WorkerPool
+	assert.Equal(t, 0, stats.ActiveWorkers)
+	assert.Equal(t, 0, stats.QueuedEvaluations)
+}
+
+func TestWorkerPoolQueuedEvaluationTimesOut(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	defer close(release)
+
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithWorkerPool(1),
+		health.WithTimeout(50*time.Millisecond),
+		health.WithChecks(blockingChecks(2, release)...),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, health.ReasonGlobalTimeout, result.Details["check-0"].Reason)
+	assert.Equal(t, health.ReasonGlobalTimeout, result.Details["check-1"].Reason)
+	assert.Equal(t, 0, ckr.(health.StatsProvider).Stats().WorkerPool.QueuedEvaluations)
+}
+
+func TestStatsInResult(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithWorkerPool(4),
+		health.WithStatsInResult(),
+		health.WithCheck(health.Check{Name: "cache", Check: func(context.Context) error { return nil }}),
+	)
+
+	// Act
+	result := ckr.Check(t.Context())
+
+	// Assert
+	require.NotNil(t, result.Stats)
+	require.NotNil(t, result.Stats.WorkerPool)
+	assert.Equal(t, 4, result.Stats.WorkerPool.Size)
+}
+
+func TestStatsWithoutWorkerPool(t *testing.T) {
+	// Arrange
+	ckr := health.NewChecker(health.WithDisabledAutostart())
+
+	// Act
+	stats := ckr.(health.StatsProvider).Stats()
+
+	// Assert
+	assert.Nil(t, stats.WorkerPool)
+}